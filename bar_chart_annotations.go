@@ -0,0 +1,112 @@
+package go_benchpress
+
+import (
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+)
+
+// errorBarCapWidth is the width, in pixels, of the horizontal caps at either end of an error bar.
+const errorBarCapWidth = 12
+
+// summariseSamples groups repeated samples of each benchmark, run with the same configuration from configs, into a
+// single benchmark holding their mean, along with the size of the error bar for each.
+func summariseSamples(benchmarks []parse.Benchmark, configs BenchmarkConfigs, dimension Dimension, errorBars ErrorBars) ([]parse.Benchmark, []float64, error) {
+	groups := GroupSamplesByConfig(benchmarks, configs)
+
+	means := make([]parse.Benchmark, 0, len(groups))
+	errs := make([]float64, 0, len(groups))
+	for _, group := range groups {
+		values, err := group.Values(dimension)
+		if err != nil {
+			return nil, nil, err
+		}
+		e, err := errorBars.Error(values)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		means = append(means, group.Mean())
+		errs = append(errs, e)
+	}
+	return means, errs, nil
+}
+
+// barPosition provides the horizontal bounds of the bar at the index, replicating the layout used by go-chart.
+func barPosition(graph *chart.BarChart, canvasBox chart.Box, index int) (left, right int) {
+	count := len(graph.Bars)
+	barWidth := graph.GetBarWidth()
+	spacing := graph.GetBarSpacing()
+
+	if count*(barWidth+spacing) > canvasBox.Width() {
+		spacing = 0
+		if remaining := canvasBox.Width() - count*barWidth; remaining > 0 {
+			spacing = int(math.Ceil(float64(remaining) / float64(count)))
+		}
+	}
+	if count*(barWidth+spacing) > canvasBox.Width() {
+		barWidth = 0
+		if remaining := canvasBox.Width() - count*spacing; remaining > 0 {
+			barWidth = int(math.Ceil(float64(remaining) / float64(count)))
+		}
+	}
+
+	left = canvasBox.Left + spacing>>1 + index*(barWidth+spacing)
+	return left, left + barWidth
+}
+
+// renderValueLabels provides a chart element drawing the value of each bar above it, clear of any error bar.
+func renderValueLabels(graph *chart.BarChart, dimension Dimension, errs []float64, colour drawing.Color) chart.Renderable {
+	return func(r chart.Renderer, canvasBox chart.Box, defaults chart.Style) {
+		style := chart.Style{
+			FontColor: colour,
+			FontSize:  chart.DefaultAxisFontSize,
+		}.InheritFrom(defaults)
+
+		for index, bar := range graph.Bars {
+			style.WriteTextOptionsToRenderer(r)
+			label := dimension.FormatValue(bar.Value)
+			box := r.MeasureText(label)
+
+			left, right := barPosition(graph, canvasBox, index)
+			x := (left+right)>>1 - box.Width()>>1
+			top := bar.Value
+			if index < len(errs) {
+				top += errs[index]
+			}
+			y := canvasBox.Bottom - graph.YAxis.Range.Translate(top) - 4
+			r.Text(label, x, y)
+		}
+	}
+}
+
+// renderErrorBars provides a chart element drawing an error bar over each bar, covering the error either side of
+// the bar's value.  Bars without an error are skipped.
+func renderErrorBars(graph *chart.BarChart, errs []float64, colour drawing.Color) chart.Renderable {
+	return func(r chart.Renderer, canvasBox chart.Box, defaults chart.Style) {
+		style := chart.Style{
+			StrokeColor: colour,
+			StrokeWidth: 1.5,
+		}
+		for index, bar := range graph.Bars {
+			if index >= len(errs) || errs[index] == 0 {
+				continue
+			}
+
+			left, right := barPosition(graph, canvasBox, index)
+			x := (left + right) >> 1
+			top := canvasBox.Bottom - graph.YAxis.Range.Translate(bar.Value+errs[index])
+			bottom := canvasBox.Bottom - graph.YAxis.Range.Translate(bar.Value-errs[index])
+
+			style.WriteDrawingOptionsToRenderer(r)
+			r.MoveTo(x, top)
+			r.LineTo(x, bottom)
+			r.MoveTo(x-errorBarCapWidth>>1, top)
+			r.LineTo(x+errorBarCapWidth>>1, top)
+			r.MoveTo(x-errorBarCapWidth>>1, bottom)
+			r.LineTo(x+errorBarCapWidth>>1, bottom)
+			r.Stroke()
+		}
+	}
+}
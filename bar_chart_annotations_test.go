@@ -0,0 +1,118 @@
+package go_benchpress
+
+import (
+	"errors"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// ===== summariseSamples tests =====
+
+func TestSummariseSamples(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/A", N: 10, NsPerOp: 10},
+		{Name: "BenchmarkOne/B", N: 10, NsPerOp: 100},
+		{Name: "BenchmarkOne/A", N: 10, NsPerOp: 20},
+	}
+
+	gotBenchmarks, gotErrs, err := summariseSamples(benchmarks, nil, RenderNsPerOp, StdDevErrorBars)
+	if err != nil {
+		t.Fatalf("Could not summarise samples - error: %v", err)
+	}
+
+	wantBenchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/A", N: 10, NsPerOp: 15},
+		{Name: "BenchmarkOne/B", N: 10, NsPerOp: 100},
+	}
+	if !reflect.DeepEqual(wantBenchmarks, gotBenchmarks) {
+		t.Errorf("Want benchmarks %v, got benchmarks %v", wantBenchmarks, gotBenchmarks)
+	}
+
+	wantErrs := []float64{math.Sqrt(50), 0}
+	if !reflect.DeepEqual(wantErrs, gotErrs) {
+		t.Errorf("Want errors %v, got errors %v", wantErrs, gotErrs)
+	}
+
+	_, _, err = summariseSamples(benchmarks, nil, RenderDimension(1000), StdDevErrorBars)
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+}
+
+func TestSummariseSamples_Configs(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/A", N: 10, NsPerOp: 10, Ord: 0},
+		{Name: "BenchmarkOne/A", N: 10, NsPerOp: 100, Ord: 1},
+		{Name: "BenchmarkOne/A", N: 10, NsPerOp: 20, Ord: 2},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a"},
+		1: {"pkg": "example.com/b"},
+		2: {"pkg": "example.com/a"},
+	}
+
+	gotBenchmarks, _, err := summariseSamples(benchmarks, configs, RenderNsPerOp, StdDevErrorBars)
+	if err != nil {
+		t.Fatalf("Could not summarise samples - error: %v", err)
+	}
+
+	wantBenchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/A", N: 10, NsPerOp: 15, Ord: 0},
+		{Name: "BenchmarkOne/A", N: 10, NsPerOp: 100, Ord: 1},
+	}
+	if !reflect.DeepEqual(wantBenchmarks, gotBenchmarks) {
+		t.Errorf("Want benchmarks %v, got benchmarks %v", wantBenchmarks, gotBenchmarks)
+	}
+}
+
+// ===== barPosition tests =====
+
+func TestBarPosition(t *testing.T) {
+	bars := []chart.Value{{Value: 1}, {Value: 2}, {Value: 3}}
+
+	tests := []struct {
+		name      string
+		barWidth  int
+		canvasBox chart.Box
+		index     int
+		wantLeft  int
+		wantRight int
+	}{
+		{
+			name:      "first bar",
+			barWidth:  60,
+			canvasBox: chart.Box{Left: 20, Right: 1000},
+			index:     0,
+			wantLeft:  70,
+			wantRight: 130,
+		},
+		{
+			name:      "third bar",
+			barWidth:  60,
+			canvasBox: chart.Box{Left: 20, Right: 1000},
+			index:     2,
+			wantLeft:  390,
+			wantRight: 450,
+		},
+		{
+			name:      "bars squeezed into narrow canvas",
+			barWidth:  60,
+			canvasBox: chart.Box{Left: 0, Right: 150},
+			index:     1,
+			wantLeft:  50,
+			wantRight: 100,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graph := &chart.BarChart{BarWidth: test.barWidth, Bars: bars}
+			gotLeft, gotRight := barPosition(graph, test.canvasBox, test.index)
+			if test.wantLeft != gotLeft || test.wantRight != gotRight {
+				t.Errorf("Want bar bounds [%d, %d], got [%d, %d]", test.wantLeft, test.wantRight, gotLeft, gotRight)
+			}
+		})
+	}
+}
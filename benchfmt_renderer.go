@@ -0,0 +1,106 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// benchfmtConfigOrder is the order in which `go test` reports the configuration keys it sets.  Other keys follow in
+// key order.
+var benchfmtConfigOrder = []string{"goos", "goarch", "pkg", "cpu"}
+
+// BenchfmtRenderer outputs the benchmarks in the standard Go benchmark format, as written by `go test -bench` - for
+// further processing by tools such as benchstat, or by gobenchpress itself.  Each sample is written separately, with
+// every measured metric, and preceded by any changes to the configuration it was read with - so that results of the
+// same name from different packages keep their own "pkg".
+type BenchfmtRenderer struct {
+	// Configs holds the configuration of each benchmark result, such as its "goos" - written as configuration lines.
+	Configs BenchmarkConfigs
+}
+
+func (b *BenchfmtRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
+	if len(benchmarks) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	buf := bytes.Buffer{}
+	written := make(Config)
+	for _, benchmark := range benchmarks {
+		config := b.Configs[benchmark.Ord]
+
+		// As with `go test`, only configuration which has changed is written - with keys no longer set cleared by an
+		// empty value.
+		for _, key := range benchfmtConfigKeys(written, config) {
+			value, ok := config[key]
+			previous, wasWritten := written[key]
+			if ok == wasWritten && value == previous {
+				continue
+			}
+			buf.WriteString(key)
+			buf.WriteString(":")
+			if value != "" {
+				buf.WriteString(" ")
+				buf.WriteString(value)
+			}
+			buf.WriteString("\n")
+			if ok {
+				written[key] = value
+			} else {
+				delete(written, key)
+			}
+		}
+
+		buf.WriteString(benchfmtLine(benchmark))
+		buf.WriteString("\n")
+	}
+
+	_, err := writer.Write(buf.Bytes())
+	return err
+}
+
+// benchfmtConfigKeys provides the keys of both configurations, in the order `go test` would write them.
+func benchfmtConfigKeys(a, b Config) []string {
+	var keys []string
+	ordered := make(map[string]bool, len(benchfmtConfigOrder))
+	for _, key := range benchfmtConfigOrder {
+		ordered[key] = true
+		_, inA := a[key]
+		_, inB := b[key]
+		if inA || inB {
+			keys = append(keys, key)
+		}
+	}
+
+	var others []string
+	for _, config := range []Config{a, b} {
+		for key := range config {
+			if !ordered[key] {
+				others = appendUnique(others, key)
+			}
+		}
+	}
+	sort.Strings(others)
+	return append(keys, others...)
+}
+
+// benchfmtLine formats the benchmark as a result line, with its metrics in the order `go test` writes them.  Unlike
+// parse.Benchmark's String method, values are written at full precision.
+func benchfmtLine(benchmark parse.Benchmark) string {
+	line := benchmark.Name + "\t" + strconv.Itoa(benchmark.N)
+	if benchmark.Measured&parse.NsPerOp != 0 {
+		line += "\t" + strconv.FormatFloat(benchmark.NsPerOp, 'f', -1, 64) + " ns/op"
+	}
+	if benchmark.Measured&parse.MBPerS != 0 {
+		line += "\t" + strconv.FormatFloat(benchmark.MBPerS, 'f', -1, 64) + " MB/s"
+	}
+	if benchmark.Measured&parse.AllocedBytesPerOp != 0 {
+		line += "\t" + strconv.FormatUint(benchmark.AllocedBytesPerOp, 10) + " B/op"
+	}
+	if benchmark.Measured&parse.AllocsPerOp != 0 {
+		line += "\t" + strconv.FormatUint(benchmark.AllocsPerOp, 10) + " allocs/op"
+	}
+	return line
+}
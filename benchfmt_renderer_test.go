@@ -0,0 +1,99 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"testing"
+)
+
+func TestBenchfmtRenderer_Render(t *testing.T) {
+	linux := Config{"goos": "linux", "goarch": "amd64", "pkg": "example.com/sort", "cpu": "Intel Xeon", "branch": "main"}
+	darwin := Config{"goos": "darwin", "goarch": "amd64", "pkg": "example.com/search"}
+
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/quick-8", N: 1000, NsPerOp: 123.456789, AllocedBytesPerOp: 64, AllocsPerOp: 2, Measured: parse.NsPerOp | parse.AllocedBytesPerOp | parse.AllocsPerOp, Ord: 0},
+		{Name: "BenchmarkSort/quick-8", N: 1200, NsPerOp: 120, AllocedBytesPerOp: 64, AllocsPerOp: 2, Measured: parse.NsPerOp | parse.AllocedBytesPerOp | parse.AllocsPerOp, Ord: 1},
+		{Name: "BenchmarkSearch-8", N: 50, NsPerOp: 0.25, MBPerS: 1500.5, Measured: parse.NsPerOp | parse.MBPerS, Ord: 2},
+		{Name: "BenchmarkUnknown", N: 10, NsPerOp: 5, Measured: parse.NsPerOp, Ord: 3},
+	}
+	configs := BenchmarkConfigs{0: linux, 1: linux, 2: darwin}
+
+	renderer := BenchfmtRenderer{Configs: configs}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkSort", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering benchmarks: %v", err)
+	}
+
+	want := `goos: linux
+goarch: amd64
+pkg: example.com/sort
+cpu: Intel Xeon
+branch: main
+BenchmarkSort/quick-8	1000	123.456789 ns/op	64 B/op	2 allocs/op
+BenchmarkSort/quick-8	1200	120 ns/op	64 B/op	2 allocs/op
+goos: darwin
+pkg: example.com/search
+cpu:
+branch:
+BenchmarkSearch-8	50	0.25 ns/op	1500.5 MB/s
+goos:
+goarch:
+pkg:
+BenchmarkUnknown	10	5 ns/op
+`
+	if want != output.String() {
+		t.Errorf("want %q, got %q", want, output.String())
+	}
+}
+
+func TestBenchfmtRenderer_RenderRoundTrip(t *testing.T) {
+	input := `goos: linux
+goarch: amd64
+pkg: example.com/sort
+BenchmarkSort/quick-8	1000	123.456789 ns/op	64 B/op	2 allocs/op
+BenchmarkSort/radix-8	2000	100 ns/op	10.5 MB/s	0 B/op	0 allocs/op
+pkg: example.com/othersort
+BenchmarkSort/quick-8	500	900 ns/op	128 B/op	4 allocs/op
+pkg: example.com/sort
+BenchmarkSort/quick-8	1100	120 ns/op	64 B/op	2 allocs/op
+`
+	benchmarks, configs, err := ReadBenchmarksWithConfig(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatalf("Could not read benchmarks - error: %v", err)
+	}
+
+	renderer := BenchfmtRenderer{Configs: configs}
+	var output bytes.Buffer
+	err = renderer.Render(&output, "BenchmarkSort", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering benchmarks: %v", err)
+	}
+	rendered := output.String()
+
+	gotBenchmarks, gotConfigs, err := ReadBenchmarksWithConfig(&output)
+	if err != nil {
+		t.Fatalf("Could not read rendered benchmarks - error: %v", err)
+	}
+	if !reflect.DeepEqual(benchmarks, gotBenchmarks) {
+		t.Errorf("want benchmarks %v, got %v", benchmarks, gotBenchmarks)
+	}
+	if !reflect.DeepEqual(configs, gotConfigs) {
+		t.Errorf("want configs %v, got %v", configs, gotConfigs)
+	}
+	// Results of the same name are written under the package they were read from.
+	if input != rendered {
+		t.Errorf("want %q, got %q", input, rendered)
+	}
+}
+
+func TestBenchfmtRenderer_RenderNoBenchmarks(t *testing.T) {
+	renderer := BenchfmtRenderer{}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkSort", RenderNsPerOp, nil)
+	if !errors.Is(err, ErrNoBenchmarksProvided) {
+		t.Errorf("Wanted error '%v', got error '%v'", ErrNoBenchmarksProvided, err)
+	}
+}
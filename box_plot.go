@@ -0,0 +1,236 @@
+package go_benchpress
+
+import (
+	"github.com/wcharczuk/go-chart"
+	"io"
+	"math"
+)
+
+const (
+	// boxPlotAxisWidth is the space reserved to the left of the boxes for the value axis ticks.
+	boxPlotAxisWidth = 70
+	// boxPlotLabelLines is the most lines a box's label is wrapped across.
+	boxPlotLabelLines = 3
+	// boxPlotPointRadius is the radius of the points drawn for samples and outliers.
+	boxPlotPointRadius = 3
+)
+
+// boxPlotSeries is a single box of a box plot chart, with the samples it summarises.
+type boxPlotSeries struct {
+	label   string
+	box     BoxPlot
+	samples []float64
+}
+
+// newBoxPlotSeries summarises the samples of each benchmark, in the provided dimension, as a box.
+func newBoxPlotSeries(samples []BenchmarkSamples, dimension Dimension) ([]boxPlotSeries, error) {
+	results := make([]boxPlotSeries, 0, len(samples))
+	for _, s := range samples {
+		values, err := s.Values(dimension)
+		if err != nil {
+			return nil, err
+		}
+		box, err := NewBoxPlot(values)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, boxPlotSeries{
+			label:   subBenchmarkLabel(s.Name),
+			box:     box,
+			samples: values,
+		})
+	}
+	return results, nil
+}
+
+// boxPlotChart draws the distribution of each benchmark's samples as a box and whiskers.
+type boxPlotChart struct {
+	title     string
+	width     int
+	height    int
+	boxWidth  int
+	series    []boxPlotSeries
+	dimension Dimension
+	palette   chart.ColorPalette
+	logScale  bool
+	// showSamples draws each sample as a point over its box.
+	showSamples bool
+	// subtitle is drawn beneath the title, if not empty.
+	subtitle string
+}
+
+// valueRange provides the range of the value axis, covering every sample.
+func (b boxPlotChart) valueRange() chart.Range {
+	var values []float64
+	for _, s := range b.series {
+		values = append(values, s.samples...)
+	}
+	if b.logScale {
+		return newLogarithmicRange(values)
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		min = math.Min(min, value)
+		max = math.Max(max, value)
+	}
+
+	// Leave a margin, so the extreme samples are not drawn on the edges of the chart.
+	margin := (max - min) * 0.05
+	if margin == 0 {
+		margin = math.Max(math.Abs(max)*0.1, 1)
+	}
+	lower := min - margin
+	if min >= 0 && lower < 0 {
+		lower = 0
+	}
+	return &chart.ContinuousRange{Min: lower, Max: max + margin}
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+func (b boxPlotChart) Render(rp chart.RendererProvider, w io.Writer) error {
+	if len(b.series) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	r, err := rp(b.width, b.height)
+	if err != nil {
+		return err
+	}
+	font, err := chart.GetDefaultFont()
+	if err != nil {
+		return err
+	}
+
+	textStyle := chart.Style{
+		Font:      font,
+		FontSize:  chart.DefaultAxisFontSize,
+		FontColor: b.palette.TextColor(),
+	}
+	lineStyle := chart.Style{
+		StrokeColor: b.palette.AxisStrokeColor(),
+		StrokeWidth: 1,
+	}
+
+	chart.Draw.Box(r, chart.Box{Right: b.width, Bottom: b.height}, chart.Style{
+		FillColor:   b.palette.BackgroundColor(),
+		StrokeColor: b.palette.BackgroundStrokeColor(),
+		StrokeWidth: chart.DefaultStrokeWidth,
+	})
+
+	if b.title != "" {
+		titleStyle := textStyle
+		titleStyle.FontSize = 18
+		titleStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(b.title)
+		r.Text(b.title, b.width>>1-box.Width()>>1, chart.DefaultTitleTop+box.Height())
+	}
+	drawSubtitle(r, textStyle, b.subtitle, b.width)
+
+	textStyle.WriteTextOptionsToRenderer(r)
+	lineHeight := r.MeasureText("Ag").Height() + textStyle.GetTextLineSpacing()
+
+	grid := chart.Box{
+		Top:    horizontalTitleHeight,
+		Left:   boxPlotAxisWidth,
+		Right:  b.width - horizontalPadding,
+		Bottom: b.height - chart.DefaultXAxisMargin - boxPlotLabelLines*lineHeight,
+	}
+	slotWidth := grid.Width() / len(b.series)
+	boxWidth := int(math.Min(float64(slotWidth)*0.5, float64(b.boxWidth)))
+
+	valueRange := b.valueRange()
+	valueRange.SetDomain(grid.Height())
+	y := func(value float64) int {
+		return grid.Bottom - valueRange.Translate(value)
+	}
+
+	for index, s := range b.series {
+		centre := grid.Left + index*slotWidth + slotWidth>>1
+		left, right := centre-boxWidth>>1, centre+boxWidth>>1
+		colour := b.palette.GetSeriesColor(index)
+		box := s.box
+
+		// Whiskers, with caps half the width of the box.
+		lineStyle.WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(centre, y(box.UpperWhisker))
+		r.LineTo(centre, y(box.UpperQuartile))
+		r.MoveTo(centre, y(box.LowerQuartile))
+		r.LineTo(centre, y(box.LowerWhisker))
+		r.MoveTo(centre-boxWidth>>2, y(box.UpperWhisker))
+		r.LineTo(centre+boxWidth>>2, y(box.UpperWhisker))
+		r.MoveTo(centre-boxWidth>>2, y(box.LowerWhisker))
+		r.LineTo(centre+boxWidth>>2, y(box.LowerWhisker))
+		r.Stroke()
+
+		chart.Draw.Box(r, chart.Box{Top: y(box.UpperQuartile), Left: left, Right: right, Bottom: y(box.LowerQuartile)}, chart.Style{
+			FillColor:   colour.WithAlpha(0x80),
+			StrokeColor: colour,
+			StrokeWidth: 2,
+		})
+
+		medianStyle := lineStyle
+		medianStyle.StrokeWidth = 2
+		medianStyle.WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(left, y(box.Median))
+		r.LineTo(right, y(box.Median))
+		r.Stroke()
+
+		for _, outlier := range box.Outliers {
+			pointStyle := chart.Style{StrokeColor: colour, StrokeWidth: 1.5, FillColor: b.palette.BackgroundColor()}
+			pointStyle.WriteDrawingOptionsToRenderer(r)
+			r.Circle(boxPlotPointRadius, centre, y(outlier))
+			r.FillStroke()
+		}
+
+		if b.showSamples {
+			// Samples are spread evenly across the box, so that equal values remain distinguishable.
+			pointStyle := chart.Style{StrokeColor: colour, StrokeWidth: 1, FillColor: colour.WithAlpha(0xa0)}
+			for sampleIndex, sample := range s.samples {
+				pointStyle.WriteDrawingOptionsToRenderer(r)
+				x := left + boxWidth*(sampleIndex+1)/(len(s.samples)+1)
+				r.Circle(boxPlotPointRadius, x, y(sample))
+				r.FillStroke()
+			}
+		}
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		for lineIndex, line := range wrapLabel(r, s.label, slotWidth-horizontalLabelGap, boxPlotLabelLines) {
+			box := r.MeasureText(line)
+			r.Text(line, centre-box.Width()>>1, grid.Bottom+chart.DefaultXAxisMargin+(lineIndex+1)*lineHeight-textStyle.GetTextLineSpacing())
+		}
+	}
+
+	b.drawValueAxis(r, grid, valueRange, textStyle, lineStyle)
+
+	return r.Save(w)
+}
+
+// drawValueAxis draws the value axis along the left of the boxes, with its ticks and name.
+func (b boxPlotChart) drawValueAxis(r chart.Renderer, grid chart.Box, valueRange chart.Range, textStyle, lineStyle chart.Style) {
+	lineStyle.WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(grid.Left, grid.Top)
+	r.LineTo(grid.Left, grid.Bottom)
+	r.LineTo(grid.Right, grid.Bottom)
+	r.Stroke()
+
+	name, formatter := axisLabels(b.dimension, valueRange, b.logScale)
+
+	ticks := chart.YAxis{Range: valueRange}.GetTicks(r, valueRange, textStyle, formatter)
+	for _, tick := range ticks {
+		tickY := grid.Bottom - valueRange.Translate(tick.Value)
+
+		lineStyle.WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(grid.Left-chart.DefaultHorizontalTickWidth, tickY)
+		r.LineTo(grid.Left, tickY)
+		r.Stroke()
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(tick.Label)
+		r.Text(tick.Label, grid.Left-chart.DefaultYAxisMargin-box.Width(), tickY+box.Height()>>1)
+	}
+
+	textStyle.WriteTextOptionsToRenderer(r)
+	box := r.MeasureText(name)
+	r.Text(name, grid.Left-box.Width()>>1, grid.Top-chart.DefaultYAxisMargin-box.Height()>>1)
+}
@@ -0,0 +1,151 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ===== boxPlotSeries tests =====
+
+func TestNewBoxPlotSeries(t *testing.T) {
+	samples := GroupSamples([]parse.Benchmark{
+		{Name: "BenchmarkOne/A", NsPerOp: 10},
+		{Name: "BenchmarkOne/B", NsPerOp: 20},
+		{Name: "BenchmarkOne/A", NsPerOp: 12},
+	})
+
+	got, err := newBoxPlotSeries(samples, RenderNsPerOp)
+	if err != nil {
+		t.Fatalf("Could not create series - error: %v", err)
+	}
+
+	want := []boxPlotSeries{
+		{
+			label:   "A",
+			box:     BoxPlot{LowerWhisker: 10, LowerQuartile: 10.5, Median: 11, UpperQuartile: 11.5, UpperWhisker: 12},
+			samples: []float64{10, 12},
+		},
+		{
+			label:   "B",
+			box:     BoxPlot{LowerWhisker: 20, LowerQuartile: 20, Median: 20, UpperQuartile: 20, UpperWhisker: 20},
+			samples: []float64{20},
+		},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	_, err = newBoxPlotSeries(samples, RenderDimension(1000))
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+}
+
+// ===== boxPlotChart tests =====
+
+func TestBoxPlotChart_ValueRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  []float64
+		logScale bool
+		wantMin  float64
+		wantMax  float64
+	}{
+		{
+			name:    "margin around samples",
+			samples: []float64{100, 300},
+			wantMin: 90,
+			wantMax: 310,
+		},
+		{
+			name:    "margin does not go below zero",
+			samples: []float64{1, 101},
+			wantMin: 0,
+			wantMax: 106,
+		},
+		{
+			name:    "identical samples",
+			samples: []float64{50, 50},
+			wantMin: 45,
+			wantMax: 55,
+		},
+		{
+			name:     "logarithmic",
+			samples:  []float64{15, 1500},
+			logScale: true,
+			wantMin:  10,
+			wantMax:  10000,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := boxPlotChart{
+				series:   []boxPlotSeries{{samples: test.samples}},
+				logScale: test.logScale,
+			}
+			got := b.valueRange()
+			if test.wantMin != got.GetMin() || test.wantMax != got.GetMax() {
+				t.Errorf("Want range [%v, %v], got [%v, %v]", test.wantMin, test.wantMax, got.GetMin(), got.GetMax())
+			}
+		})
+	}
+}
+
+func TestBoxPlotChart_Render(t *testing.T) {
+	palette, err := LightTheme.palette()
+	if err != nil {
+		t.Fatalf("Could not create palette - error: %v", err)
+	}
+	samples := GroupSamples([]parse.Benchmark{
+		{Name: "BenchmarkOne/size=10", NsPerOp: 1000},
+		{Name: "BenchmarkOne/size=10", NsPerOp: 1100},
+		{Name: "BenchmarkOne/size=10", NsPerOp: 5000},
+		{Name: "BenchmarkOne/size=100", NsPerOp: 2000},
+	})
+	series, err := newBoxPlotSeries(samples, RenderNsPerOp)
+	if err != nil {
+		t.Fatalf("Could not create series - error: %v", err)
+	}
+
+	for _, logScale := range []bool{false, true} {
+		buf := bytes.Buffer{}
+		b := boxPlotChart{
+			title:       "BenchmarkOne",
+			width:       1024,
+			height:      512,
+			boxWidth:    60,
+			series:      series,
+			dimension:   RenderNsPerOp,
+			palette:     palette,
+			logScale:    logScale,
+			showSamples: true,
+		}
+		err = b.Render(chart.SVG, &buf)
+		if err != nil {
+			t.Fatalf("Could not render chart - error: %v", err)
+		}
+
+		xmlData := make([]interface{}, 0)
+		err = xml.Unmarshal(buf.Bytes(), &xmlData)
+		if err != nil {
+			t.Errorf("Error unmarshalling SVG as XML - error: %v", err)
+		}
+
+		for _, want := range []string{"BenchmarkOne", "size=10", "size=100", "Time per op"} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("Wanted SVG output to contain %q", want)
+			}
+		}
+	}
+
+	err = boxPlotChart{}.Render(chart.SVG, &bytes.Buffer{})
+	if !errors.Is(err, ErrNoBenchmarksProvided) {
+		t.Errorf("Want error '%v', got error '%v'", ErrNoBenchmarksProvided, err)
+	}
+}
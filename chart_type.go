@@ -0,0 +1,55 @@
+package go_benchpress
+
+import "fmt"
+
+// ChartType determines the kind of chart drawn by the RasterRenderer.
+type ChartType int
+
+const (
+	// BarChartType draws a bar for each benchmark.
+	BarChartType ChartType = iota
+	// HeatmapChartType arranges the benchmarks in a grid by two of their name parameters, colouring each cell by
+	// its value.
+	HeatmapChartType
+	// BoxPlotChartType draws the distribution of each benchmark's repeated samples as a box and whiskers.
+	BoxPlotChartType
+	// ScatterChartType plots the benchmarks against two dimensions, highlighting those which are Pareto optimal.
+	ScatterChartType
+	// LineChartType plots a line for each series of the benchmarks, across the values of an X axis - both chosen
+	// from the parts of the benchmark names or their configuration.
+	LineChartType
+)
+
+func (c ChartType) String() string {
+	switch c {
+	case BarChartType:
+		return "BAR"
+	case HeatmapChartType:
+		return "HEATMAP"
+	case BoxPlotChartType:
+		return "BOX_PLOT"
+	case ScatterChartType:
+		return "SCATTER"
+	case LineChartType:
+		return "LINE"
+	default:
+		return fmt.Sprintf("Unknown (%d)", c)
+	}
+}
+
+func ChartTypeFromString(str string) (ChartType, error) {
+	switch str {
+	case "BAR":
+		return BarChartType, nil
+	case "HEATMAP":
+		return HeatmapChartType, nil
+	case "BOX_PLOT":
+		return BoxPlotChartType, nil
+	case "SCATTER":
+		return ScatterChartType, nil
+	case "LINE":
+		return LineChartType, nil
+	default:
+		return -1, fmt.Errorf("chart type %q not supported: %w", str, ErrUnknownChartType)
+	}
+}
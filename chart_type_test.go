@@ -0,0 +1,54 @@
+package go_benchpress
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChartType_String(t *testing.T) {
+	tests := []struct {
+		input ChartType
+		want  string
+	}{
+		{BarChartType, "BAR"},
+		{HeatmapChartType, "HEATMAP"},
+		{BoxPlotChartType, "BOX_PLOT"},
+		{ScatterChartType, "SCATTER"},
+		{LineChartType, "LINE"},
+		{ChartType(1000), "Unknown (1000)"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			got := test.input.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestChartTypeFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ChartType
+		wantErr error
+	}{
+		{input: "BAR", want: BarChartType},
+		{input: "HEATMAP", want: HeatmapChartType},
+		{input: "BOX_PLOT", want: BoxPlotChartType},
+		{input: "SCATTER", want: ScatterChartType},
+		{input: "LINE", want: LineChartType},
+		{input: "abc123", want: ChartType(-1), wantErr: ErrUnknownChartType},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ChartTypeFromString(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
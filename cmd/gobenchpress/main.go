@@ -4,66 +4,369 @@ import (
 	"flag"
 	"github.com/rpickz/go-benchpress"
 	"golang.org/x/tools/benchmark/parse"
-	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
-var input = flag.String("input", "STDIN", "The input filename")
+var input = flag.String("input", "STDIN", "The input filename.  Several inputs can be separated by commas - each benchmark then gains a 'file' configuration key naming its input, such as 'old' from 'old.txt', for use with '-groupBy', '-xAxis' and '-series'")
 var outputFilename = flag.String("output", "output_{}", "The output filename.  Using '{}' within the name acts as a placeholder for either the benchmark name, or 'all_together' if run in 'no separation' mode.")
-var renderType = flag.String("renderType", "SVG", "The render type - can be 'SVG', 'PNG', 'JSON', 'CSV', or 'XML'")
-var dimension = flag.String("dimension", "NS_PER_OP", "The dimension to compare - can be 'NS_PER_OP', 'BYTES_PER_OP', 'ALLOCS_PER_OP'")
+var renderType = flag.String("renderType", "SVG", "The render type - can be 'SVG', 'PNG', 'JSON', 'CSV', 'XML', 'VEGALITE', 'GNUPLOT', 'PGFPLOTS', 'MERMAID', 'PROMETHEUS', 'INFLUX', 'JUNIT', 'SARIF', or 'BENCHFMT'.  'GNUPLOT' writes the plotted data to a '.dat' file beside each script, which reads it by its absolute path")
+var dimension = flag.String("dimension", "NS_PER_OP", "The dimension to compare - can be 'NS_PER_OP', 'BYTES_PER_OP', 'ALLOCS_PER_OP', or the name of a dimension from '-define' or '-defineFile'")
 var noSeparation = flag.Bool("noSep", false, "Whether to separate the sub-benchmarks, and group by their parent benchmark.  If true, the benchmarks are put together into a single output")
+var grid = flag.Bool("grid", false, "Whether to render every benchmark as a panel of a single grid image, rather than a file per benchmark.  Only the 'SVG' and 'PNG' render types are supported.  Using '{}' within the output filename acts as a placeholder for 'grid'")
+var columns = flag.Int("columns", 3, "The number of panels in each row of a grid image")
+var themeName = flag.String("theme", "LIGHT", "The chart colour theme - can be 'LIGHT', 'DARK', 'HIGH_CONTRAST', or 'COLOUR_BLIND_SAFE'")
+var valueLabels = flag.Bool("valueLabels", false, "Whether to annotate each bar of a chart with its value")
+var errorBars = flag.String("errorBars", "NONE", "How to show the variance of repeated samples (from 'go test -count') on charts - can be 'NONE', 'STDDEV', or 'CI95'.  If not 'NONE', samples are combined into a single bar showing their mean")
+var chartType = flag.String("chartType", "BAR", "The kind of chart drawn for PNG and SVG output - can be 'BAR', 'HEATMAP', 'BOX_PLOT', 'SCATTER', or 'LINE'")
+var heatmapX = flag.String("heatmapX", "", "The benchmark name parameter placed on the X axis of heatmaps - for instance, 'size' for 'BenchmarkCache/workers=4/size=1024'.  If empty, the first parameter is used")
+var heatmapY = flag.String("heatmapY", "", "The benchmark name parameter placed on the Y axis of heatmaps - for instance, 'workers' for 'BenchmarkCache/workers=4/size=1024'.  If empty, the second parameter is used")
+var secondaryDimension = flag.String("secondaryDimension", "BYTES_PER_OP", "The dimension plotted against '-dimension' on scatter charts, and used to find the Pareto frontier, differing from '-dimension' - can be 'NS_PER_OP', 'BYTES_PER_OP', 'ALLOCS_PER_OP', or the name of a defined dimension")
+var pareto = flag.Bool("pareto", false, "Whether to include the benchmarks which are Pareto optimal in '-dimension' and '-secondaryDimension' in JSON and CSV output")
+var vegaLiteVariant = flag.String("vegaLiteVariant", "BAR", "The kind of chart described by 'VEGALITE' output - can be 'BAR', 'GROUPED', or 'LINE'.  'GROUPED' and 'LINE' group the benchmarks by the last parameter of their names")
+var mermaidLine = flag.Bool("mermaidLine", false, "Whether 'MERMAID' output plots the benchmarks as a line, rather than bars")
+var timestamp = flag.String("timestamp", "", "The time of the benchmarks in 'INFLUX' output, in RFC 3339 format - for instance, '2021-06-01T12:00:00Z'.  If empty, the modification time of the input file is used, or the current time when reading from STDIN")
+var baseline = flag.String("baseline", "", "A file of baseline benchmark results to compare against - in 'JUNIT' output, benchmarks which regress from the baseline by more than '-tolerance' fail, and in 'SARIF' output they are reported at their source (found within '-source', or the current directory)")
+var tolerance = flag.Float64("tolerance", 5, "The percentage by which benchmarks may regress from the baseline before failing")
+var threshold = flag.Float64("threshold", 0, "The highest value of '-dimension' (in ns, bytes or allocations) a benchmark may have before failing in 'JUNIT' output.  If zero, there is no threshold")
+var source = flag.String("source", "", "The directory holding the source of the benchmarks.  If set, benchmarks defined in the source but missing from the results are reported, and 'SARIF' output locates regressions within it rather than the current directory")
+var filter = flag.String("filter", "", "An expression selecting the benchmarks to output - for instance, 'pkg:example.com/sort AND (algo:quick OR size>=1000) AND NOT ns_per_op>500'.  Terms compare the benchmark name ('name', or '/regex/'), its 'benchmark', 'sub' or 'procs', configuration such as 'pkg' or 'goos', name parameters, or metrics ('n', 'ns_per_op', 'bytes_per_op', 'allocs_per_op' or 'mb_per_s').  If empty, every benchmark is output")
+var groupBy = flag.String("groupBy", "", "The comma separated keys grouping the benchmarks into separate outputs (or grid panels) - for instance, 'pkg,size'.  Keys are 'name', 'name.N' (the first N parts of the name), 'benchmark', 'sub', 'procs', configuration such as 'pkg' or 'file', or name parameters such as 'size'.  If empty, benchmarks are grouped by the first part of their name - and by their 'file', given several inputs")
+var xAxis = flag.String("xAxis", "", "The comma separated keys placed on the X axis of 'LINE' charts and grouped or line 'VEGALITE' output, using the keys of '-groupBy'.  If empty, the last parameter of the benchmark names is used")
+var series = flag.String("series", "", "The comma separated keys forming the series of 'LINE' charts and grouped or line 'VEGALITE' output, using the keys of '-groupBy'.  If empty, the parts of the benchmark names not on the X axis are used, following their package where the benchmarks span several")
+var sortOrder = flag.String("sort", "INPUT", "The order of the benchmarks within each output, such as the bars of a chart or rows of a table - can be 'INPUT', 'NAME', 'VALUE_ASC', 'VALUE_DESC', or 'LABEL'.  'NAME' compares numbers within names by value, so that 'size=20' comes before 'size=100', the 'VALUE' orders use '-dimension', and 'LABEL' uses '-sortKey'")
+var sortSets = flag.String("sortSets", "NAME", "The order in which separate outputs are written, and of the panels of a grid image - can be 'INPUT', 'NAME', 'VALUE_ASC', 'VALUE_DESC', or 'LABEL', as for '-sort'")
+var sortKey = flag.String("sortKey", "", "The comma separated keys ordering benchmarks for the 'LABEL' sort order, compared in turn, using the keys of '-groupBy' - for instance, 'size'")
+var top = flag.Int("top", 0, "The number of benchmarks kept on each 'SVG' or 'PNG' chart, chosen by their '-dimension' - useful for sets with many sub-benchmarks.  The benchmarks left out are noted in the chart subtitle, and kept in other outputs, such as 'JSON' and 'CSV'.  If zero, every benchmark is drawn")
+var topSelect = flag.String("topSelect", "LARGEST", "Which benchmarks '-top' keeps - can be 'LARGEST' (for instance, the slowest) or 'SMALLEST' (the fastest)")
+var others = flag.String("others", "DROP", "What becomes of the benchmarks left out by '-top' - can be 'DROP', or 'AGGREGATE' to draw their mean as a single 'Others' benchmark")
+var relative = flag.String("relative", "", "Whether to show values relative to a reference, rather than their absolute '-dimension' - can be 'RATIO', 'PERCENTAGE' (of the reference), or 'SPEEDUP' (the reference divided by each value).  Charts draw a line where values equal their reference.  If empty, absolute values are shown")
+var reference = flag.String("reference", "", "The label of the reference benchmark for '-relative', as 'key=value' using the keys of '-groupBy' - for instance, 'algo=std' compares 'BenchmarkSort/algo=quick/size=10' with 'BenchmarkSort/algo=std/size=10' from the same package, and 'file=old' compares benchmarks with those of the same name from the 'old' input.  If empty, benchmarks are compared with those of the same name and package in '-baseline'")
+var define = flag.String("define", "", "Dimensions defined as expressions over the metrics and numeric labels of the benchmarks, separated by semicolons - for instance, 'per_element=ns_per_op / size; alloc_size=bytes_per_op / allocs_per_op'.  Expressions use '+', '-', '*', '/', parentheses and the functions 'abs', 'log', 'log2', 'log10' and 'sqrt', over the metrics ('n', 'ns_per_op', 'bytes_per_op', 'allocs_per_op' or 'mb_per_s') and the keys of '-groupBy'.  Defined dimensions can be used by name wherever a dimension is accepted")
+var defineFile = flag.String("defineFile", "", "A JSON or YAML file listing dimension definitions, each with a 'name', an 'expression' (as for '-define') and an optional 'title'")
+var complexity = flag.Bool("complexity", false, "Whether to fit complexity models - O(1), O(log n), O(n), O(n log n) and O(n²) - to each series across the sizes on the X axis, as arranged by '-xAxis' and '-series'.  The best fit of each series is logged with its goodness of fit, and drawn as a dashed curve on 'LINE' charts.  X values are sizes if they are numbers, or names holding a single number, such as 'Length_10'")
+var showSamples = flag.Bool("showSamples", false, "Whether to draw each sample over its box, on box plots")
+var orientation = flag.String("orientation", "AUTO", "The direction of the bars on charts - can be 'VERTICAL', 'HORIZONTAL', or 'AUTO'.  'AUTO' uses horizontal bars when the benchmark names are too long to fit beneath vertical bars")
+var themeFile = flag.String("themeFile", "", "A JSON or YAML file defining a custom chart colour theme.  If provided, takes precedence over '-theme'")
+var logScale = flag.Bool("logScale", false, "Whether to render the value axis of charts on a logarithmic scale - useful where results span several orders of magnitude")
 
 var _logError = logError
 
 func main() {
 	flag.Parse()
 
-	var reader io.Reader
-	if *input == "STDIN" {
-		reader = os.Stdin
-	} else {
-		file, err := os.Open(*input)
+	definitions := readDimensionDefinitions()
+
+	errBars, err := go_benchpress.ErrorBarsFromString(*errorBars)
+	if err != nil {
+		_logError("Could not determine valid error bars - error: %v", err)
+	}
+
+	orient, err := go_benchpress.OrientationFromString(*orientation)
+	if err != nil {
+		_logError("Could not determine valid orientation - error: %v", err)
+	}
+
+	chartKind, err := go_benchpress.ChartTypeFromString(*chartType)
+	if err != nil {
+		_logError("Could not determine valid chart type - error: %v", err)
+	}
+
+	variant, err := go_benchpress.VegaLiteVariantFromString(*vegaLiteVariant)
+	if err != nil {
+		_logError("Could not determine valid Vega-Lite variant - error: %v", err)
+	}
+
+	benchmarkOrder, err := go_benchpress.SortOrderFromString(*sortOrder)
+	if err != nil {
+		_logError("Could not determine valid sort order - error: %v", err)
+	}
+
+	setOrder, err := go_benchpress.SortOrderFromString(*sortSets)
+	if err != nil {
+		_logError("Could not determine valid set sort order - error: %v", err)
+	}
+
+	selection, err := go_benchpress.TopSelectionFromString(*topSelect)
+	if err != nil {
+		_logError("Could not determine valid top selection - error: %v", err)
+	}
+
+	othersMode, err := go_benchpress.OthersModeFromString(*others)
+	if err != nil {
+		_logError("Could not determine valid others mode - error: %v", err)
+	}
+
+	var relativeMode go_benchpress.RelativeMode
+	if *relative != "" {
+		relativeMode, err = go_benchpress.RelativeModeFromString(*relative)
 		if err != nil {
-			_logError("Could not open output.txt for reading - error: %v", err)
+			_logError("Could not determine valid relative mode - error: %v", err)
 		}
-		defer file.Close()
-		reader = file
 	}
 
-	dim, err := go_benchpress.RenderDimensionFromString(*dimension)
+	benchmarkFilter := parseFilter()
+	groupKeys := parseProjectionKeys(*groupBy, "grouping")
+	if len(groupKeys) == 0 && strings.Contains(*input, ",") {
+		// Benchmarks of the same name from several inputs are kept apart, rather than combined as samples.
+		groupKeys = []string{"benchmark", "file"}
+	}
+	sortKeys := parseProjectionKeys(*sortKey, "sort")
+	if (benchmarkOrder == go_benchpress.LabelOrder || setOrder == go_benchpress.LabelOrder) && len(sortKeys) == 0 {
+		_logError("Could not determine valid sort keys - error: '-sortKey' is required by the 'LABEL' sort order")
+	}
+
+	baselineBenchmarks, baselineConfigs := loadBaseline()
+	options := renderOptions{
+		theme:           loadTheme(),
+		logScale:        *logScale,
+		valueLabels:     *valueLabels,
+		errorBars:       errBars,
+		orientation:     orient,
+		chartType:       chartKind,
+		heatmapX:        *heatmapX,
+		heatmapY:        *heatmapY,
+		showSamples:     *showSamples,
+		pareto:          *pareto,
+		vegaLite:        variant,
+		mermaidLine:     *mermaidLine,
+		timestamp:       determineTimestamp(),
+		baseline:        baselineBenchmarks,
+		baselineConfigs: baselineConfigs,
+		tolerance:       *tolerance / 100,
+		threshold:       *threshold,
+		source:          *source,
+		pivotX:          parseProjectionKeys(*xAxis, "X axis"),
+		pivotSeries:     parseProjectionKeys(*series, "series"),
+		top:             go_benchpress.TopN{Count: *top, Selection: selection, Others: othersMode},
+		complexity:      *complexity,
+	}
+
+	benchmarks, configs := readInputs()
+	options.configs = configs
+
+	// Defined dimensions are evaluated with the configuration of each benchmark, so are only usable once the inputs
+	// are read.
+	defined := defineDimensions(definitions, configs)
+	dim, err := go_benchpress.DimensionFromString(*dimension, defined)
 	if err != nil {
 		_logError("Render dimension %q invalid", *dimension)
 	}
+	options.secondary, err = go_benchpress.DimensionFromString(*secondaryDimension, defined)
+	if err != nil {
+		_logError("Secondary render dimension %q invalid", *secondaryDimension)
+	}
+	options.top.Dimension = dim
+	options.top.Configs = configs
+	options.locations = locateBenchmarks(benchmarks, configs)
 
-	// If no separation required, read the benchmarks and output to single file.
-	if *noSeparation {
-		benchmarks, err := go_benchpress.ReadBenchmarks(reader)
-		if err != nil {
-			_logError("Could not read benchmarks from input - error: %v")
+	if benchmarkFilter != nil {
+		benchmarks = benchmarkFilter.Apply(benchmarks, configs)
+		if len(benchmarks) == 0 {
+			_logError("No benchmarks match filter %q", *filter)
 		}
-		writeBenchmarks("all_together", benchmarks, dim, *outputFilename)
+	}
+
+	if *relative != "" {
+		dim = relativeDimension(benchmarks, configs, dim, relativeMode, options.baseline, options.baselineConfigs)
+		options.top.Dimension = dim
+	}
+	benchmarks = valuedBenchmarks(benchmarks, dim)
+
+	benchmarkSorter := go_benchpress.Sorter{Order: benchmarkOrder, Dimension: dim, Keys: sortKeys, Configs: configs}
+	setSorter := go_benchpress.Sorter{Order: setOrder, Dimension: dim, Keys: sortKeys, Configs: configs}
+
+	// If no separation required, output the benchmarks to a single file.
+	if *noSeparation && !*grid {
+		writeBenchmarks("all_together", sortBenchmarks(benchmarkSorter, benchmarks), dim, *outputFilename, options)
+		return
+	}
+
+	sets := separateBenchmarks(benchmarks, configs, groupKeys)
+	for name, set := range sets {
+		sets[name] = sortBenchmarks(benchmarkSorter, set)
+	}
+	names, err := setSorter.SortSets(sets, benchmarks)
+	if err != nil {
+		_logError("Could not sort benchmark sets - error: %v", err)
+	}
+
+	// If a grid is required, output each set of separated benchmarks as a panel of a single image.
+	if *grid {
+		writeGrid(sets, names, dim, *outputFilename, options)
 		return
 	}
 
 	// Alternatively, separate the benchmarks so they are grouped by their parent benchmark, and write the results
 	// to separate files.
-	separatedBenchmarks, err := go_benchpress.ReadAndSeparateBenchmarks(reader)
+	for _, name := range names {
+		writeBenchmarks(name, sets[name], dim, *outputFilename, options)
+	}
+}
+
+// sortBenchmarks orders the benchmarks using the sorter.
+func sortBenchmarks(sorter go_benchpress.Sorter, benchmarks []parse.Benchmark) []parse.Benchmark {
+	result, err := sorter.Sort(benchmarks)
 	if err != nil {
-		_logError("Could not read benchmarks from input - error: %v", err)
+		_logError("Could not sort benchmarks - error: %v", err)
 	}
+	return result
+}
+
+// renderOptions holds the CLI options which configure individual renderers.
+type renderOptions struct {
+	theme           go_benchpress.Theme
+	logScale        bool
+	valueLabels     bool
+	errorBars       go_benchpress.ErrorBars
+	orientation     go_benchpress.Orientation
+	chartType       go_benchpress.ChartType
+	heatmapX        string
+	heatmapY        string
+	showSamples     bool
+	secondary       go_benchpress.Dimension
+	pareto          bool
+	vegaLite        go_benchpress.VegaLiteVariant
+	mermaidLine     bool
+	configs         go_benchpress.BenchmarkConfigs
+	timestamp       time.Time
+	baseline        []parse.Benchmark
+	baselineConfigs go_benchpress.BenchmarkConfigs
+	tolerance       float64
+	threshold       float64
+	source          string
+	locations       go_benchpress.BenchmarkLocations
+	pivotX          []string
+	pivotSeries     []string
+	top             go_benchpress.TopN
+	complexity      bool
+}
 
-	for name, benchmarks := range separatedBenchmarks {
-		writeBenchmarks(name, benchmarks, dim, *outputFilename)
+// loadTheme loads the chart theme, either from the theme file (if provided) or the built-in themes.
+func loadTheme() go_benchpress.Theme {
+	if *themeFile == "" {
+		theme, err := go_benchpress.ThemeFromString(*themeName)
+		if err != nil {
+			_logError("Could not determine valid theme - error: %v", err)
+		}
+		return theme
+	}
+
+	file, err := os.Open(*themeFile)
+	if err != nil {
+		_logError("Could not open theme file %q for reading - error: %v", *themeFile, err)
+	}
+	defer file.Close()
+
+	theme, err := go_benchpress.ReadTheme(file)
+	if err != nil {
+		_logError("Could not read theme file %q - error: %v", *themeFile, err)
+	}
+	return theme
+}
+
+// readDimensionDefinitions reads the dimension definitions given by the flag and file, if provided.
+func readDimensionDefinitions() []go_benchpress.DimensionDefinition {
+	var definitions []go_benchpress.DimensionDefinition
+	if *defineFile != "" {
+		file, err := os.Open(*defineFile)
+		if err != nil {
+			_logError("Could not open dimension definition file %q for reading - error: %v", *defineFile, err)
+		}
+		defer file.Close()
+
+		definitions, err = go_benchpress.ReadDimensionDefinitions(file)
+		if err != nil {
+			_logError("Could not read dimension definition file %q - error: %v", *defineFile, err)
+		}
+	}
+
+	if *define != "" {
+		for _, str := range strings.Split(*define, ";") {
+			definition, err := go_benchpress.ParseDimensionDefinition(str)
+			if err != nil {
+				_logError("Could not determine valid dimension definition - error: %v", err)
+			}
+			definitions = append(definitions, definition)
+		}
+	}
+	return definitions
+}
+
+// defineDimensions defines the dimensions, evaluated with the configuration of each benchmark in configs.
+func defineDimensions(definitions []go_benchpress.DimensionDefinition, configs go_benchpress.BenchmarkConfigs) []go_benchpress.Dimension {
+	defined := make([]go_benchpress.Dimension, 0, len(definitions))
+	for _, definition := range definitions {
+		for _, previous := range defined {
+			if previous.String() == definition.Name {
+				_logError("Could not define dimension %q - error: dimension name %q is already defined", definition.Name, definition.Name)
+			}
+		}
+
+		dimension, err := definition.Define(configs)
+		if err != nil {
+			_logError("Could not define dimension %q - error: %v", definition.Name, err)
+		}
+		defined = append(defined, dimension)
 	}
+	return defined
 }
 
-func writeBenchmarks(name string, benchmarks []parse.Benchmark, dimension go_benchpress.RenderDimension, outputFilename string) {
+// loadBaseline reads the baseline benchmarks and their configurations, if a baseline file is provided.
+func loadBaseline() ([]parse.Benchmark, go_benchpress.BenchmarkConfigs) {
+	if *baseline == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(*baseline)
+	if err != nil {
+		_logError("Could not open baseline file %q for reading - error: %v", *baseline, err)
+	}
+	defer file.Close()
+
+	benchmarks, configs, err := go_benchpress.ReadBenchmarksWithConfig(file)
+	if err != nil {
+		_logError("Could not read baseline benchmarks - error: %v", err)
+	}
+	return benchmarks, configs
+}
 
-	outputName := strings.ReplaceAll(outputFilename, "{}", name)
+// determineTimestamp determines the time of the benchmarks - either the time provided, or the time the input was
+// written.
+func determineTimestamp() time.Time {
+	if *timestamp != "" {
+		result, err := time.Parse(time.RFC3339, *timestamp)
+		if err != nil {
+			_logError("Could not determine valid timestamp - error: %v", err)
+		}
+		return result
+	}
+
+	if *input == "STDIN" {
+		return time.Now()
+	}
+
+	// With several inputs, the most recently written is used.
+	var result time.Time
+	for _, filename := range strings.Split(*input, ",") {
+		info, err := os.Stat(filename)
+		if err != nil {
+			_logError("Could not read input file information - error: %v", err)
+		}
+		if info.ModTime().After(result) {
+			result = info.ModTime()
+		}
+	}
+	return result
+}
+
+func writeBenchmarks(name string, benchmarks []parse.Benchmark, dimension go_benchpress.Dimension, outputFilename string, options renderOptions) {
+
+	// Group names may contain slashes, such as those of packages, which cannot be used within filenames.
+	outputName := strings.ReplaceAll(outputFilename, "{}", strings.ReplaceAll(name, "/", "_"))
 
 	renderType, err := go_benchpress.RenderTypeFromString(*renderType)
 	if err != nil {
@@ -76,6 +379,9 @@ func writeBenchmarks(name string, benchmarks []parse.Benchmark, dimension go_ben
 	if err != nil {
 		_logError("Could not find renderer for type %q - error: %v", renderType, err)
 	}
+	configureRenderer(renderer, options)
+	suggestLogScale(name, renderer, dimension, benchmarks)
+	reportComplexity(name, dimension, benchmarks, options)
 
 	file, err := os.Create(outputName)
 	if err != nil {
@@ -83,6 +389,23 @@ func writeBenchmarks(name string, benchmarks []parse.Benchmark, dimension go_ben
 	}
 	defer file.Close()
 
+	// Gnuplot scripts read their data from a file beside them, named after the script.  The script refers to it by
+	// its absolute path, as gnuplot resolves relative paths against its working directory rather than the script's.
+	if gnuplot, ok := renderer.(*go_benchpress.GnuplotRenderer); ok {
+		dataName, err := filepath.Abs(strings.TrimSuffix(outputName, filepath.Ext(outputName)) + ".dat")
+		if err != nil {
+			_logError("Could not determine data file path - error: %v", err)
+		}
+		dataFile, err := os.Create(dataName)
+		if err != nil {
+			_logError("Could not open data file for writing - error: %v", err)
+		}
+		defer dataFile.Close()
+
+		gnuplot.DataWriter = dataFile
+		gnuplot.DataFilename = dataName
+	}
+
 	err = renderer.Render(file, name, dimension, benchmarks)
 	if err != nil {
 		// TODO: Update error detection method once merge request has been merged and released.
@@ -92,6 +415,300 @@ func writeBenchmarks(name string, benchmarks []parse.Benchmark, dimension go_ben
 	}
 }
 
+func writeGrid(sets go_benchpress.BenchmarkSets, order []string, dimension go_benchpress.Dimension, outputFilename string, options renderOptions) {
+
+	outputName := strings.ReplaceAll(outputFilename, "{}", "grid")
+
+	renderType, err := go_benchpress.RenderTypeFromString(*renderType)
+	if err != nil {
+		_logError("Could not determine valid render type - error: %v", err)
+	}
+
+	outputName = determineOutputFilename(outputName, renderType)
+
+	gridRenderer := go_benchpress.NewGridRenderer(renderType)
+	gridRenderer.Columns = *columns
+	gridRenderer.Order = order
+	configureRenderer(gridRenderer.Panel, options)
+	for _, name := range order {
+		reportComplexity(name, dimension, sets[name], options)
+	}
+
+	file, err := os.Create(outputName)
+	if err != nil {
+		_logError("Could not open file for writing - error: %v", err)
+	}
+	defer file.Close()
+
+	err = gridRenderer.RenderSets(file, dimension, sets)
+	if err != nil {
+		_logError("Could not output grid - error: %v", err)
+	}
+}
+
+// readInputs reads the benchmarks, along with their configuration, from each input.  Where there are several inputs,
+// each benchmark gains a 'file' configuration key naming its input - so that benchmarks of the same name can be told
+// apart, while their names are left as they were.
+func readInputs() ([]parse.Benchmark, go_benchpress.BenchmarkConfigs) {
+	if *input == "STDIN" {
+		benchmarks, configs, err := go_benchpress.ReadBenchmarksWithConfig(os.Stdin)
+		if err != nil {
+			_logError("Could not read benchmarks from input - error: %v", err)
+		}
+		return benchmarks, configs
+	}
+
+	filenames := strings.Split(*input, ",")
+	labels := inputLabels(filenames)
+
+	var results []parse.Benchmark
+	var resultConfigs go_benchpress.BenchmarkConfigs
+	for index, filename := range filenames {
+		file, err := os.Open(filename)
+		if err != nil {
+			_logError("Could not open %q for reading - error: %v", filename, err)
+		}
+		benchmarks, configs, err := go_benchpress.ReadBenchmarksWithConfig(file)
+		file.Close()
+		if err != nil {
+			_logError("Could not read benchmarks from input %q - error: %v", filename, err)
+		}
+
+		if len(filenames) > 1 {
+			configs = go_benchpress.AddConfigKey(benchmarks, configs, "file", labels[index])
+		}
+		results, resultConfigs = go_benchpress.AppendBenchmarks(results, resultConfigs, benchmarks, configs)
+	}
+	return results, resultConfigs
+}
+
+// inputLabelReplacer replaces the slashes of input labels, as the values of grouping keys are joined by slashes.
+var inputLabelReplacer = strings.NewReplacer("/", "_", string(filepath.Separator), "_")
+
+// inputLabels provides the label of each input file - its name without the directory or extension, unless that is
+// shared with another input, in which case its path is used.  Slashes are replaced by underscores, so that the labels
+// are not mistaken for several grouping values.
+func inputLabels(filenames []string) []string {
+	labels := make([]string, 0, len(filenames))
+	counts := make(map[string]int)
+	for _, filename := range filenames {
+		base := filepath.Base(filename)
+		label := inputLabelReplacer.Replace(strings.TrimSuffix(base, filepath.Ext(base)))
+		labels = append(labels, label)
+		counts[label]++
+	}
+
+	for index, filename := range filenames {
+		if counts[labels[index]] > 1 {
+			labels[index] = inputLabelReplacer.Replace(filepath.Clean(filename))
+		}
+	}
+	return labels
+}
+
+// relativeDimension provides a dimension comparing benchmarks with their reference benchmark, or otherwise their
+// baseline.
+func relativeDimension(benchmarks []parse.Benchmark, configs go_benchpress.BenchmarkConfigs, dimension go_benchpress.Dimension, mode go_benchpress.RelativeMode, baseline []parse.Benchmark, baselineConfigs go_benchpress.BenchmarkConfigs) go_benchpress.Dimension {
+	var references go_benchpress.References
+	var description string
+	var err error
+	switch {
+	case *reference != "":
+		key, value, ok := strings.Cut(*reference, "=")
+		if !ok || key == "" {
+			_logError("Could not determine valid reference - error: reference %q is not of the form 'key=value'", *reference)
+		}
+		references, err = go_benchpress.ReferenceValues(benchmarks, configs, dimension, key, value)
+		description = *reference
+	case baseline != nil:
+		references, err = go_benchpress.BaselineValues(baseline, baselineConfigs, configs, dimension)
+		description = "baseline"
+	default:
+		_logError("Could not determine valid reference - error: '-relative' requires either '-reference' or '-baseline'")
+	}
+	if err != nil {
+		_logError("Could not determine reference values - error: %v", err)
+	}
+
+	result, err := go_benchpress.RelativeDimension(dimension, mode, references, description)
+	if err != nil {
+		_logError("Could not determine valid relative dimension - error: %v", err)
+	}
+	return result
+}
+
+// valuedBenchmarks provides the benchmarks which have a value in the dimension - those without, such as benchmarks
+// lacking a reference or a label used by a defined dimension, are left out.
+func valuedBenchmarks(benchmarks []parse.Benchmark, dimension go_benchpress.Dimension) []parse.Benchmark {
+	results := make([]parse.Benchmark, 0, len(benchmarks))
+	missing := make(map[string]bool)
+	for _, benchmark := range benchmarks {
+		if _, err := dimension.Value(benchmark); err != nil {
+			if !missing[benchmark.Name] {
+				log.Printf("Leaving benchmark out - error: %v", err)
+				missing[benchmark.Name] = true
+			}
+			continue
+		}
+		results = append(results, benchmark)
+	}
+	if len(results) == 0 {
+		_logError("No benchmarks have a value in dimension %q", dimension.Title())
+	}
+	return results
+}
+
+// separateBenchmarks groups the benchmarks by the keys provided, or otherwise by their parent benchmark.
+func separateBenchmarks(benchmarks []parse.Benchmark, configs go_benchpress.BenchmarkConfigs, keys []string) go_benchpress.BenchmarkSets {
+	if len(keys) == 0 {
+		return go_benchpress.SeparateBenchmarks(benchmarks)
+	}
+	return go_benchpress.GroupBenchmarks(benchmarks, configs, keys)
+}
+
+// parseProjectionKeys parses the comma separated keys of a flag, if provided.
+func parseProjectionKeys(keys string, purpose string) []string {
+	if keys == "" {
+		return nil
+	}
+
+	result, err := go_benchpress.ParseProjectionKeys(keys)
+	if err != nil {
+		_logError("Could not determine valid %s keys - error: %v", purpose, err)
+	}
+	return result
+}
+
+// parseFilter parses the filter expression, if provided.
+func parseFilter() *go_benchpress.Filter {
+	if *filter == "" {
+		return nil
+	}
+
+	result, err := go_benchpress.ParseFilter(*filter)
+	if err != nil {
+		_logError("Could not determine valid filter - error: %v", err)
+	}
+	return result
+}
+
+// locateBenchmarks locates the benchmarks within the source directory (if provided), reporting those without results.
+func locateBenchmarks(benchmarks []parse.Benchmark, configs go_benchpress.BenchmarkConfigs) go_benchpress.BenchmarkLocations {
+	if *source == "" {
+		return nil
+	}
+
+	locations, err := go_benchpress.LocateBenchmarks(*source)
+	if err != nil {
+		_logError("Could not locate benchmarks within source %q - error: %v", *source, err)
+	}
+
+	for _, missing := range locations.Missing(benchmarks, configs) {
+		log.Printf("Benchmark %q is defined at %s:%d but has no results", missing.Name, filepath.Join(*source, missing.Location.File), missing.Location.Line)
+	}
+	return locations
+}
+
+// configureRenderer applies the CLI options to the renderer, where the renderer supports them.
+func configureRenderer(renderer go_benchpress.Renderer, options renderOptions) {
+	switch r := renderer.(type) {
+	case *go_benchpress.RasterRenderer:
+		r.Theme = options.theme
+		r.LogScale = options.logScale
+		r.ValueLabels = options.valueLabels
+		r.ErrorBars = options.errorBars
+		r.Orientation = options.orientation
+		r.ChartType = options.chartType
+		r.HeatmapX = options.heatmapX
+		r.HeatmapY = options.heatmapY
+		r.ShowSamples = options.showSamples
+		r.SecondaryDimension = options.secondary
+		r.PivotX = options.pivotX
+		r.PivotSeries = options.pivotSeries
+		r.Configs = options.configs
+		r.Top = options.top
+		r.Complexity = options.complexity
+	case *go_benchpress.JSONRenderer:
+		r.Pareto = options.pareto
+		r.SecondaryDimension = options.secondary
+		r.Configs = options.configs
+	case *go_benchpress.CSVRenderer:
+		r.Pareto = options.pareto
+		r.SecondaryDimension = options.secondary
+		r.Configs = options.configs
+	case *go_benchpress.VegaLiteRenderer:
+		r.Variant = options.vegaLite
+		r.LogScale = options.logScale
+		r.PivotX = options.pivotX
+		r.PivotSeries = options.pivotSeries
+		r.Configs = options.configs
+	case *go_benchpress.GnuplotRenderer:
+		r.LogScale = options.logScale
+		r.ErrorBars = options.errorBars
+	case *go_benchpress.PGFPlotsRenderer:
+		r.LogScale = options.logScale
+		r.ErrorBars = options.errorBars
+	case *go_benchpress.MermaidRenderer:
+		r.Line = options.mermaidLine
+	case *go_benchpress.PrometheusRenderer:
+		r.Configs = options.configs
+	case *go_benchpress.InfluxRenderer:
+		r.Configs = options.configs
+		r.Timestamp = options.timestamp
+	case *go_benchpress.JUnitRenderer:
+		r.Configs = options.configs
+		r.Baseline = options.baseline
+		r.BaselineConfigs = options.baselineConfigs
+		r.Tolerance = options.tolerance
+		r.Threshold = options.threshold
+	case *go_benchpress.SARIFRenderer:
+		r.Baseline = options.baseline
+		r.Tolerance = options.tolerance
+		r.SourceDir = options.source
+		r.Locations = options.locations
+		r.Configs = options.configs
+		r.BaselineConfigs = options.baselineConfigs
+	case *go_benchpress.BenchfmtRenderer:
+		r.Configs = options.configs
+	}
+}
+
+// suggestLogScale logs a suggestion to use a logarithmic scale, if the chart would be hard to read on a linear scale.
+func suggestLogScale(name string, renderer go_benchpress.Renderer, dimension go_benchpress.Dimension, benchmarks []parse.Benchmark) {
+	raster, ok := renderer.(*go_benchpress.RasterRenderer)
+	if !ok || raster.LogScale {
+		return
+	}
+
+	suggest, err := go_benchpress.SuggestLogScale(dimension, benchmarks)
+	if err == nil && suggest {
+		log.Printf("Results for %q span several orders of magnitude - consider using '-logScale'", name)
+	}
+}
+
+// reportComplexity logs the complexity model best fitting each series of the benchmarks, if requested.
+func reportComplexity(name string, dimension go_benchpress.Dimension, benchmarks []parse.Benchmark, options renderOptions) {
+	if !options.complexity {
+		return
+	}
+
+	pivot := go_benchpress.NewPivot(benchmarks, options.configs, options.pivotX, options.pivotSeries)
+	fits, err := go_benchpress.FitPivotComplexity(pivot, dimension)
+	if err != nil {
+		log.Printf("Could not fit complexity of %q - error: %v", name, err)
+		return
+	}
+
+	for _, series := range pivot.Series {
+		fit, ok := fits[series.Name]
+		if !ok {
+			log.Printf("Could not fit complexity of %q - too few sizes", series.Name)
+			continue
+		}
+		log.Printf("Complexity of %q is %v - %s", series.Name, fit, fit.Formula())
+	}
+}
+
 // determineOutputFilename corrects the filename to output to if the wrong file extension is provided.
 func determineOutputFilename(outputName string, renderType go_benchpress.RenderType) string {
 	result := outputName
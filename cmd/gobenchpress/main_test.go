@@ -9,8 +9,13 @@ import (
 	"github.com/rpickz/go-benchpress"
 	"image/png"
 	"io/ioutil"
+	"log"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSVGOutput(t *testing.T) {
@@ -135,6 +140,46 @@ func TestJSONOutputNoSeparation(t *testing.T) {
 	}
 }
 
+func TestJSONOutputPareto(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.JSON)
+	defer file.Close()
+
+	*noSeparation = true
+	*pareto = true
+	defer func() {
+		*pareto = false
+	}()
+
+	setupRenderType(go_benchpress.JSON)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	type jsonRecord struct {
+		ParetoFrontier []string
+	}
+
+	var data jsonRecord
+	err = json.Unmarshal(content, &data)
+	if err != nil {
+		t.Errorf("Could not decode JSON file - error: %v", err)
+	}
+
+	// The fastest benchmark allocates no more than any other, so it alone is Pareto optimal.
+	want := []string{"BenchmarkParseCSVLineFields/10_Fields-12"}
+	if !reflect.DeepEqual(want, data.ParetoFrontier) {
+		t.Errorf("Wanted Pareto frontier %v, got %v", want, data.ParetoFrontier)
+	}
+}
+
 func TestCSVOutput(t *testing.T) {
 	benchmarkFile := setupBenchmarkInput(t)
 	defer benchmarkFile.Close()
@@ -273,6 +318,1444 @@ func TestXMLOutputNoSeparation(t *testing.T) {
 	}
 }
 
+func TestVegaLiteOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.VEGALITE)
+	defer file.Close()
+
+	*noSeparation = true
+	*vegaLiteVariant = "LINE"
+	defer func() {
+		*vegaLiteVariant = go_benchpress.VegaLiteBar.String()
+	}()
+
+	setupRenderType(go_benchpress.VEGALITE)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	type vegaLiteRecord struct {
+		Mark struct {
+			Type string
+		}
+		Data struct {
+			Values []interface{}
+		}
+	}
+
+	var data vegaLiteRecord
+	err = json.Unmarshal(content, &data)
+	if err != nil {
+		t.Errorf("Could not decode Vega-Lite file - error: %v", err)
+	}
+
+	if data.Mark.Type != "line" {
+		t.Errorf("Wanted mark %q, got %q", "line", data.Mark.Type)
+	}
+
+	wantLen := 16
+	gotLen := len(data.Data.Values)
+	if wantLen != gotLen {
+		t.Errorf("Wanted %d data values, got %d", wantLen, gotLen)
+	}
+}
+
+func TestGnuplotOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.GNUPLOT)
+	defer file.Close()
+
+	dataName := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())) + ".dat"
+	defer os.Remove(dataName)
+
+	*noSeparation = true
+
+	setupRenderType(go_benchpress.GNUPLOT)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	absData, err := filepath.Abs(dataName)
+	if err != nil {
+		t.Fatalf("Could not determine data file path - error: %v", err)
+	}
+	wantPlot := fmt.Sprintf("plot %q using 2:xtic(1)", absData)
+	if !strings.Contains(string(content), wantPlot) {
+		t.Errorf("Wanted script to contain %q, got %q", wantPlot, content)
+	}
+
+	data, err := ioutil.ReadFile(dataName)
+	if err != nil {
+		t.Fatalf("Could not read data file - error: %v", err)
+	}
+
+	// The header, and a line for each benchmark.
+	wantLen := 17
+	gotLen := len(strings.Split(strings.TrimSpace(string(data)), "\n"))
+	if wantLen != gotLen {
+		t.Errorf("Wanted %d data lines, got %d", wantLen, gotLen)
+	}
+}
+
+func TestMermaidOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.MERMAID)
+	defer file.Close()
+
+	*noSeparation = true
+	*mermaidLine = true
+	defer func() {
+		*mermaidLine = false
+	}()
+
+	setupRenderType(go_benchpress.MERMAID)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if lines[0] != "xychart-beta" {
+		t.Errorf("Wanted first line %q, got %q", "xychart-beta", lines[0])
+	}
+	last := lines[len(lines)-1]
+	if !strings.HasPrefix(strings.TrimSpace(last), "line [") {
+		t.Errorf("Wanted the benchmarks plotted as a line, got %q", last)
+	}
+	if got := strings.Count(last, ",") + 1; got != 16 {
+		t.Errorf("Wanted 16 values, got %d", got)
+	}
+}
+
+func TestPrometheusOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.PROMETHEUS)
+	defer file.Close()
+
+	*noSeparation = true
+
+	setupRenderType(go_benchpress.PROMETHEUS)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	want := `go_benchmark_ns_per_op{benchmark="BenchmarkParseCSVLineFields",sub="10_Fields",procs="12",cpu="Intel(R) Core(TM) i9-8950HK CPU @ 2.90GHz",goarch="amd64",goos="darwin",pkg="go-benchpress/m/v2/cmd/examples/csvparser"} 193.9`
+	if !strings.Contains(string(content), want) {
+		t.Errorf("Wanted output to contain %q, got %q", want, content)
+	}
+}
+
+func TestInfluxOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.INFLUX)
+	defer file.Close()
+
+	*noSeparation = true
+	*timestamp = "2021-06-01T12:00:00Z"
+	defer func() {
+		*timestamp = ""
+	}()
+
+	setupRenderType(go_benchpress.INFLUX)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	wantLen := 16
+	if len(lines) != wantLen {
+		t.Errorf("Wanted %d points, got %d", wantLen, len(lines))
+	}
+
+	want := `go_benchmark,benchmark=BenchmarkParseCSVLineFields,cpu=Intel(R)\ Core(TM)\ i9-8950HK\ CPU\ @\ 2.90GHz,goarch=amd64,goos=darwin,pkg=go-benchpress/m/v2/cmd/examples/csvparser,procs=12,sub=10_Fields n=5764971i,ns_per_op=193.9,bytes_per_op=64i,allocs_per_op=2i 1622548800000000000`
+	if lines[0] != want {
+		t.Errorf("Wanted first point %q, got %q", want, lines[0])
+	}
+}
+
+func TestInfluxOutputInputTime(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	modified := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	err := os.Chtimes(benchmarkFile.Name(), modified, modified)
+	if err != nil {
+		t.Fatalf("Could not set input file times - error: %v", err)
+	}
+
+	file := setupOutputFile(t, go_benchpress.INFLUX)
+	defer file.Close()
+
+	*noSeparation = true
+
+	setupRenderType(go_benchpress.INFLUX)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	// Without a timestamp provided, points are timestamped with the modification time of the input.
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	want := " 1622548800000000000"
+	if !strings.HasSuffix(lines[0], want) {
+		t.Errorf("Wanted first point to end with %q, got %q", want, lines[0])
+	}
+}
+
+func TestJUnitOutputBaseline(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	// The baseline is the same as the input, except one benchmark was twice as fast.
+	content, err := ioutil.ReadFile(benchmarkFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read benchmark input - error: %v", err)
+	}
+	baselineFile, err := os.CreateTemp("", "benchmark-baseline-*.txt")
+	if err != nil {
+		t.Fatalf("Could not create temporary file for baseline data - error: %v", err)
+	}
+	defer baselineFile.Close()
+	_, err = baselineFile.WriteString(strings.Replace(string(content), "193.9 ns/op", "96.9 ns/op", 1))
+	if err != nil {
+		t.Fatalf("Could not write baseline data - error: %v", err)
+	}
+
+	file := setupOutputFile(t, go_benchpress.JUNIT)
+	defer file.Close()
+
+	*noSeparation = true
+	*baseline = baselineFile.Name()
+	defer func() {
+		*baseline = ""
+	}()
+
+	setupRenderType(go_benchpress.JUNIT)
+
+	// Call program entry point.
+	main()
+
+	content, err = ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	type junitRecord struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+	}
+
+	var data junitRecord
+	err = xml.Unmarshal(content, &data)
+	if err != nil {
+		t.Errorf("Could not decode JUnit file - error: %v", err)
+	}
+
+	want := junitRecord{Tests: 16, Failures: 1}
+	if want != data {
+		t.Errorf("Wanted %+v, got %+v", want, data)
+	}
+}
+
+func TestSARIFOutputBaseline(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	// The baseline is the same as the input, except one benchmark was twice as fast.
+	content, err := ioutil.ReadFile(benchmarkFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read benchmark input - error: %v", err)
+	}
+	baselineFile, err := os.CreateTemp("", "benchmark-baseline-*.txt")
+	if err != nil {
+		t.Fatalf("Could not create temporary file for baseline data - error: %v", err)
+	}
+	defer baselineFile.Close()
+	_, err = baselineFile.WriteString(strings.Replace(string(content), "193.9 ns/op", "96.9 ns/op", 1))
+	if err != nil {
+		t.Fatalf("Could not write baseline data - error: %v", err)
+	}
+
+	file := setupOutputFile(t, go_benchpress.SARIF)
+	defer file.Close()
+
+	*noSeparation = true
+	*baseline = baselineFile.Name()
+	defer func() {
+		*baseline = ""
+	}()
+
+	setupRenderType(go_benchpress.SARIF)
+
+	// Call program entry point.
+	main()
+
+	content, err = ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	var data struct {
+		Runs []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	err = json.Unmarshal(content, &data)
+	if err != nil {
+		t.Fatalf("Could not decode SARIF file - error: %v", err)
+	}
+
+	if len(data.Runs) != 1 || len(data.Runs[0].Results) != 1 {
+		t.Errorf("Wanted a single run with 1 result, got %+v", data.Runs)
+	}
+}
+
+func TestSourceReportsMissingBenchmarks(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.JSON)
+	defer file.Close()
+
+	dir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "csvparse_test.go"), []byte(`package csvparser
+
+import "testing"
+
+func BenchmarkParseCSVLineFields(b *testing.B) {}
+
+func BenchmarkParseCSVLineQuoted(b *testing.B) {}
+`), 0664)
+	if err != nil {
+		t.Fatalf("Could not write source file - error: %v", err)
+	}
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	*noSeparation = true
+	*source = dir
+	defer func() {
+		*source = ""
+	}()
+
+	setupRenderType(go_benchpress.JSON)
+
+	// Call program entry point.
+	main()
+
+	want := fmt.Sprintf("Benchmark \"BenchmarkParseCSVLineQuoted\" is defined at %s:7 but has no results", filepath.Join(dir, "csvparse_test.go"))
+	if !strings.Contains(logged.String(), want) {
+		t.Errorf("Wanted log containing %q, got %q", want, logged.String())
+	}
+	if strings.Contains(logged.String(), "BenchmarkParseCSVLineFields") {
+		t.Errorf("Wanted no report of benchmarks with results, got %q", logged.String())
+	}
+}
+
+func TestBenchfmtOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.BENCHFMT)
+	defer file.Close()
+
+	*noSeparation = true
+
+	setupRenderType(go_benchpress.BENCHFMT)
+
+	// Call program entry point.
+	main()
+
+	_, err := benchmarkFile.Seek(0, 0)
+	if err != nil {
+		t.Fatalf("Could not rewind benchmark input - error: %v", err)
+	}
+	wantBenchmarks, wantConfigs, err := go_benchpress.ReadBenchmarksWithConfig(benchmarkFile)
+	if err != nil {
+		t.Fatalf("Could not read benchmark input - error: %v", err)
+	}
+
+	// The output should be read back as the same benchmarks.
+	gotBenchmarks, gotConfigs, err := go_benchpress.ReadBenchmarksWithConfig(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+	if !reflect.DeepEqual(wantBenchmarks, gotBenchmarks) {
+		t.Errorf("Wanted benchmarks %v, got %v", wantBenchmarks, gotBenchmarks)
+	}
+	if !reflect.DeepEqual(wantConfigs, gotConfigs) {
+		t.Errorf("Wanted configs %v, got %v", wantConfigs, gotConfigs)
+	}
+}
+
+func TestFilterOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.BENCHFMT)
+	defer file.Close()
+
+	*noSeparation = true
+	*filter = "/Length_/ AND (bytes_per_op>64 OR NOT ns_per_op<1000) AND pkg:go-benchpress/m/v2/cmd/examples/csvparser"
+	defer func() {
+		*filter = ""
+	}()
+
+	setupRenderType(go_benchpress.BENCHFMT)
+
+	// Call program entry point.
+	main()
+
+	benchmarks, configs, err := go_benchpress.ReadBenchmarksWithConfig(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	var got []string
+	for _, benchmark := range benchmarks {
+		got = append(got, benchmark.Name)
+	}
+	want := []string{
+		"BenchmarkParseCSVLineFieldLength/Length_20-12",
+		"BenchmarkParseCSVLineFieldLength/Length_80-12",
+		"BenchmarkParseCSVLineFieldLength/Length_160-12",
+		"BenchmarkParseCSVLineFieldLength/Length_640-12",
+		"BenchmarkParseCSVLineFieldLength/Length_1280-12",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Wanted %v, got %v", want, got)
+	}
+
+	// The configuration of the benchmarks is kept.
+	if configs[benchmarks[0].Ord]["goos"] != "darwin" {
+		t.Errorf("Wanted configuration to be kept, got %v", configs[benchmarks[0].Ord])
+	}
+}
+
+func TestSortedOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.BENCHFMT)
+	defer file.Close()
+
+	*noSeparation = true
+	*filter = "/Length_/"
+	*sortOrder = "VALUE_DESC"
+	defer func() {
+		*filter = ""
+		*sortOrder = "INPUT"
+	}()
+
+	setupRenderType(go_benchpress.BENCHFMT)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+
+	// Call program entry point.
+	main()
+
+	benchmarks, err := go_benchpress.ReadBenchmarks(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	var got []string
+	for _, benchmark := range benchmarks {
+		got = append(got, benchmark.Name)
+	}
+	want := []string{
+		"BenchmarkParseCSVLineFieldLength/Length_1280-12",
+		"BenchmarkParseCSVLineFieldLength/Length_640-12",
+		"BenchmarkParseCSVLineFieldLength/Length_160-12",
+		"BenchmarkParseCSVLineFieldLength/Length_320-12",
+		"BenchmarkParseCSVLineFieldLength/Length_80-12",
+		"BenchmarkParseCSVLineFieldLength/Length_20-12",
+		"BenchmarkParseCSVLineFieldLength/Length_40-12",
+		"BenchmarkParseCSVLineFieldLength/Length_10-12",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Wanted %v, got %v", want, got)
+	}
+}
+
+func TestDefinedDimensionOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.BENCHFMT)
+	defer file.Close()
+
+	definePath := filepath.Join(t.TempDir(), "dimensions.yaml")
+	err := ioutil.WriteFile(definePath, []byte("- name: alloc_size\n  expression: bytes_per_op / allocs_per_op\n"), 0664)
+	if err != nil {
+		t.Fatalf("Could not write dimension definition file - error: %v", err)
+	}
+
+	*noSeparation = true
+	*filter = "/Length_/"
+	*sortOrder = "VALUE_DESC"
+	*defineFile = definePath
+	defer func() {
+		*filter = ""
+		*sortOrder = "INPUT"
+		*defineFile = ""
+	}()
+
+	setupRenderType(go_benchpress.BENCHFMT)
+	*dimension = "alloc_size"
+	defer setupRenderDimension(go_benchpress.RenderNsPerOp)
+
+	// Call program entry point.
+	main()
+
+	benchmarks, err := go_benchpress.ReadBenchmarks(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	var got []string
+	for _, benchmark := range benchmarks {
+		got = append(got, benchmark.Name)
+	}
+	// Sorted by bytes per allocation - 8025/5, 711/2, 5638/23, 387/2 and 64/2.
+	want := []string{
+		"BenchmarkParseCSVLineFieldLength/Length_640-12",
+		"BenchmarkParseCSVLineFieldLength/Length_160-12",
+		"BenchmarkParseCSVLineFieldLength/Length_1280-12",
+		"BenchmarkParseCSVLineFieldLength/Length_20-12",
+		"BenchmarkParseCSVLineFieldLength/Length_80-12",
+		"BenchmarkParseCSVLineFieldLength/Length_10-12",
+		"BenchmarkParseCSVLineFieldLength/Length_40-12",
+		"BenchmarkParseCSVLineFieldLength/Length_320-12",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Wanted %v, got %v", want, got)
+	}
+}
+
+func TestMultipleInputsGroupedOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	content, err := ioutil.ReadFile(benchmarkFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read benchmark input - error: %v", err)
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"old.txt", "new.txt"} {
+		err = ioutil.WriteFile(filepath.Join(dir, name), content, 0664)
+		if err != nil {
+			t.Fatalf("Could not write benchmark input - error: %v", err)
+		}
+	}
+
+	*input = filepath.Join(dir, "old.txt") + "," + filepath.Join(dir, "new.txt")
+	*outputFilename = filepath.Join(dir, "output_{}")
+	*noSeparation = false
+	*groupBy = "file,pkg"
+	defer func() {
+		*groupBy = ""
+	}()
+
+	setupRenderType(go_benchpress.BENCHFMT)
+
+	// Call program entry point.
+	main()
+
+	for _, label := range []string{"old", "new"} {
+		// The package within each group name has its slashes replaced in the filename.
+		file, err := os.Open(filepath.Join(dir, "output_"+label+"_go-benchpress_m_v2_cmd_examples_csvparser.txt"))
+		if err != nil {
+			t.Fatalf("Could not open output for %q - error: %v", label, err)
+		}
+		benchmarks, configs, err := go_benchpress.ReadBenchmarksWithConfig(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("Could not read output for %q - error: %v", label, err)
+		}
+
+		if len(benchmarks) != 16 {
+			t.Errorf("Wanted 16 benchmarks for %q, got %d", label, len(benchmarks))
+		}
+		// The input is named by the configuration, leaving the benchmark names unchanged.
+		for _, benchmark := range benchmarks {
+			if strings.Contains(benchmark.Name, "file=") {
+				t.Errorf("Wanted benchmark name to be unchanged, got %q", benchmark.Name)
+			}
+			if configs[benchmark.Ord]["file"] != label {
+				t.Errorf("Wanted benchmark configuration to name its input %q, got %v", label, configs[benchmark.Ord])
+			}
+		}
+	}
+}
+
+func TestLineChartOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	*noSeparation = true
+	*chartType = go_benchpress.LineChartType.String()
+	*xAxis = "sub"
+	*series = "benchmark"
+	defer func() {
+		*chartType = go_benchpress.BarChartType.String()
+		*xAxis = ""
+		*series = ""
+	}()
+
+	setupRenderType(go_benchpress.SVG)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	for _, want := range []string{"BenchmarkParseCSVLineFields", "BenchmarkParseCSVLineFieldLength", "10_Fields", ">sub<"} {
+		if !bytes.Contains(content, []byte(want)) {
+			t.Errorf("Wanted line chart to contain %q", want)
+		}
+	}
+}
+
+func TestTopOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	*noSeparation = true
+	*top = 3
+	*topSelect = go_benchpress.SmallestSelection.String()
+	*others = go_benchpress.AggregateOthers.String()
+	defer func() {
+		*top = 0
+		*topSelect = go_benchpress.LargestSelection.String()
+		*others = go_benchpress.DropOthers.String()
+	}()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	for _, want := range []string{"10_Fields", "20_Fields", "Length_10", "Others (13)", "Smallest 3 of 16 by time per op, 13 others averaged"} {
+		if !bytes.Contains(content, []byte(want)) {
+			t.Errorf("Wanted chart to contain %q", want)
+		}
+	}
+	if bytes.Contains(content, []byte("1280_Fields")) {
+		t.Error("Wanted benchmarks left out by top to be omitted from the chart")
+	}
+}
+
+func TestRelativeOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	*noSeparation = true
+	*relative = go_benchpress.SpeedupRelative.String()
+	*reference = "name=BenchmarkParseCSVLineFields/10_Fields-12"
+	*orientation = go_benchpress.HorizontalOrientation.String()
+	*valueLabels = true
+	defer func() {
+		*relative = ""
+		*reference = ""
+		*orientation = go_benchpress.AutoOrientation.String()
+		*valueLabels = false
+	}()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	// The reference benchmark is as fast as itself, and the slowest is 193.9/109451 as fast.
+	for _, want := range []string{"Speedup over name=BenchmarkParseCSVLineFields/10_Fields-12 in time per op", ">1×<", ">0×<", "stroke-dasharray"} {
+		if !bytes.Contains(content, []byte(want)) {
+			t.Errorf("Wanted chart to contain %q", want)
+		}
+	}
+}
+
+func TestComplexityOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	*noSeparation = true
+	*chartType = go_benchpress.LineChartType.String()
+	*complexity = true
+	defer func() {
+		*chartType = go_benchpress.BarChartType.String()
+		*complexity = false
+	}()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderAllocsPerOp)
+	defer setupRenderDimension(go_benchpress.RenderNsPerOp)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	// Every field count allocates the same, while long fields allocate ever more.
+	for _, want := range []string{"BenchmarkParseCSVLineFields-12 ~ O(1)", "BenchmarkParseCSVLineFieldLength-12 ~ O(n²)", "stroke-dasharray"} {
+		if !bytes.Contains(content, []byte(want)) {
+			t.Errorf("Wanted line chart to contain %q", want)
+		}
+	}
+	for _, want := range []string{`Complexity of "BenchmarkParseCSVLineFields-12" is O(1)`, `Complexity of "BenchmarkParseCSVLineFieldLength-12" is O(n²)`} {
+		if !strings.Contains(logged.String(), want) {
+			t.Errorf("Wanted log containing %q, got %q", want, logged.String())
+		}
+	}
+}
+
+func TestThemeFileOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	*noSeparation = false
+
+	setupRenderType(go_benchpress.SVG)
+
+	themePath := filepath.Join(t.TempDir(), "theme.yaml")
+	err := ioutil.WriteFile(themePath, []byte("name: Docs\nbackground: \"#0a0b0c\"\n"), 0664)
+	if err != nil {
+		t.Fatalf("Could not write theme file - error: %v", err)
+	}
+	*themeFile = themePath
+	defer func() {
+		*themeFile = ""
+	}()
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	wantBackground := "rgba(10,11,12,1.0)"
+	if !strings.Contains(string(content), wantBackground) {
+		t.Errorf("Wanted SVG output to contain theme background %q", wantBackground)
+	}
+}
+
+func TestLogScaleOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	*noSeparation = false
+	*logScale = true
+	defer func() {
+		*logScale = false
+	}()
+
+	setupRenderType(go_benchpress.SVG)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	// Unmarshal SVG as XML - test file not corrupt, or wrong format.
+	xmlData := make([]interface{}, 0)
+	err = xml.Unmarshal(content, &xmlData)
+	if err != nil {
+		t.Errorf("Error unmarshalling SVG as XML - error: %v", err)
+	}
+}
+
+func TestAnnotatedOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	*noSeparation = true
+	*valueLabels = true
+	*errorBars = go_benchpress.StdDevErrorBars.String()
+	defer func() {
+		*valueLabels = false
+		*errorBars = go_benchpress.NoErrorBars.String()
+	}()
+
+	setupRenderType(go_benchpress.SVG)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	// Unmarshal SVG as XML - test file not corrupt, or wrong format.
+	xmlData := make([]interface{}, 0)
+	err = xml.Unmarshal(content, &xmlData)
+	if err != nil {
+		t.Errorf("Error unmarshalling SVG as XML - error: %v", err)
+	}
+
+	// Value labels are scaled to readable units.
+	if !strings.Contains(string(content), "95.95µs") {
+		t.Error("Wanted SVG output to contain value label '95.95µs'")
+	}
+}
+
+func TestGridOutput(t *testing.T) {
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.PNG)
+	defer file.Close()
+
+	*grid = true
+	*columns = 1
+	defer func() {
+		*grid = false
+		*columns = 3
+	}()
+
+	setupRenderType(go_benchpress.PNG)
+
+	// Call program entry point.
+	main()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Could not read output file - error: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewBuffer(content))
+	if err != nil {
+		t.Fatalf("Could not decode PNG file - error: %v", err)
+	}
+
+	// Both benchmarks are rendered as panels in a single column.
+	if img.Bounds().Dy() < 2*img.Bounds().Dx()*320/512 {
+		t.Errorf("Wanted two panels stacked vertically, got image size %v", img.Bounds().Size())
+	}
+}
+
+func TestInvalidTheme(t *testing.T) {
+	wantErr := `Could not determine valid theme - error: theme "abc123" not supported: unknown theme`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*themeName = "LIGHT"
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*themeName = "abc123"
+
+	// Call program entry point.
+	main()
+}
+
+func TestInvalidOrientation(t *testing.T) {
+	wantErr := `Could not determine valid orientation - error: orientation "abc123" not supported: unknown orientation`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*orientation = go_benchpress.AutoOrientation.String()
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*orientation = "abc123"
+
+	// Call program entry point.
+	main()
+}
+
+func TestInvalidChartType(t *testing.T) {
+	wantErr := `Could not determine valid chart type - error: chart type "abc123" not supported: unknown chart type`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*chartType = go_benchpress.BarChartType.String()
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*chartType = "abc123"
+
+	// Call program entry point.
+	main()
+}
+
+func TestInvalidVegaLiteVariant(t *testing.T) {
+	wantErr := `Could not determine valid Vega-Lite variant - error: vega-lite variant "abc123" not supported: unknown vega-lite variant`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*vegaLiteVariant = go_benchpress.VegaLiteBar.String()
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.VEGALITE)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.VEGALITE)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*vegaLiteVariant = "abc123"
+
+	// Call program entry point.
+	main()
+}
+
+func TestInvalidTimestamp(t *testing.T) {
+	wantErr := `Could not determine valid timestamp - error: parsing time "abc123" as "2006-01-02T15:04:05Z07:00": cannot parse "abc123" as "2006"`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*timestamp = ""
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.INFLUX)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.INFLUX)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*timestamp = "abc123"
+
+	// Call program entry point.
+	main()
+}
+
+func TestInvalidFilter(t *testing.T) {
+	wantErr := `Could not determine valid filter - error: filter "size>=" invalid at position 6, expected a value: invalid filter`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*filter = ""
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.JSON)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.JSON)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*filter = "size>="
+
+	// Call program entry point.
+	main()
+}
+
+func TestInvalidGroupBy(t *testing.T) {
+	wantErr := `Could not determine valid grouping keys - error: key "name.0" does not have a valid depth: invalid projection key`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*groupBy = ""
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.JSON)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.JSON)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*groupBy = "pkg,name.0"
+
+	// Call program entry point.
+	main()
+}
+
+func TestInvalidSortOrder(t *testing.T) {
+	wantErr := `Could not determine valid sort order - error: sort order "abc123" not supported: unknown sort order`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*sortOrder = "INPUT"
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.JSON)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.JSON)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*sortOrder = "abc123"
+
+	// Call program entry point.
+	main()
+}
+
+func TestMissingSortKey(t *testing.T) {
+	wantErr := `Could not determine valid sort keys - error: '-sortKey' is required by the 'LABEL' sort order`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*sortSets = "NAME"
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.JSON)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.JSON)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*sortSets = "LABEL"
+
+	// Call program entry point.
+	main()
+}
+
+func TestInvalidOthersMode(t *testing.T) {
+	wantErr := `Could not determine valid others mode - error: others mode "abc123" not supported: unknown others mode`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*others = go_benchpress.DropOthers.String()
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*others = "abc123"
+
+	// Call program entry point.
+	main()
+}
+
+func TestMissingReference(t *testing.T) {
+	wantErr := `Could not determine valid reference - error: '-relative' requires either '-reference' or '-baseline'`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*relative = ""
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*relative = go_benchpress.RatioRelative.String()
+
+	// Call program entry point.
+	main()
+}
+
+func TestInvalidDefinition(t *testing.T) {
+	wantErr := `Could not define dimension "per_field" - error: expression "ns_per_op /" invalid at position 11, expected a value: invalid expression`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*define = ""
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*define = "per_field=ns_per_op /"
+
+	// Call program entry point.
+	main()
+}
+
+func TestDuplicateDefinition(t *testing.T) {
+	wantErr := `Could not define dimension "per_field" - error: dimension name "per_field" is already defined`
+	errorLogger := fakeErrorLogger{}
+
+	defer func() {
+		p := recover()
+		if p != nil {
+			if !errorLogger.called {
+				t.Fatalf("Unexpected panic - not triggered by error logger - panic: %v", p)
+			}
+
+			if wantErr != errorLogger.msg {
+				t.Errorf("Wanted error msg %q, got error msg %q", wantErr, errorLogger.msg)
+			}
+		}
+
+		// Ensure error logger is called, regardless of if panic occurs.
+		if !errorLogger.called {
+			t.Error("Error logger not called - expected error")
+		}
+
+		*define = ""
+		_logError = logError
+	}()
+
+	_logError = errorLogger.logError
+
+	benchmarkFile := setupBenchmarkInput(t)
+	defer benchmarkFile.Close()
+
+	file := setupOutputFile(t, go_benchpress.SVG)
+	defer file.Close()
+
+	setupRenderType(go_benchpress.SVG)
+	setupRenderDimension(go_benchpress.RenderNsPerOp)
+	*define = "per_field=ns_per_op / 2; per_field=ns_per_op / 4"
+
+	// Call program entry point.
+	main()
+}
+
 func TestInvalidRenderType(t *testing.T) {
 	wantErr := `Could not determine valid render type - error: render type "Unknown (1000)" not supported: unknown render type`
 	errorLogger := fakeErrorLogger{}
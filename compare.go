@@ -0,0 +1,82 @@
+package go_benchpress
+
+import (
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+)
+
+// Comparison is the change of a benchmark from its baseline, in a single dimension.
+type Comparison struct {
+	Name string
+	// Package is the import path of the benchmark's package - its "pkg" configuration - or empty if unknown.
+	Package  string
+	Baseline float64
+	Current  float64
+	// Change is the fractional change from the baseline - for instance, 0.1 when the current value is 10% higher.
+	Change float64
+}
+
+// Regressed reports whether the benchmark has become worse than its baseline by more than the tolerated fraction -
+// for instance, a tolerance of 0.05 allows a 5% increase.  Lower values are better in every dimension.
+func (c Comparison) Regressed(tolerance float64) bool {
+	return c.Change > tolerance
+}
+
+// Compare compares each benchmark, with its configuration from configs, with the baseline benchmark of the same name
+// and package (its "pkg" configuration), with its configuration from baselineConfigs, in the dimension.  Repeated
+// samples of a benchmark run with the same configuration are combined into their mean.  The comparisons are in order
+// of each benchmark's first appearance, omitting any without a baseline.  If the dimension is unknown, an
+// ErrUnknownDimensionType is returned.
+func Compare(baseline []parse.Benchmark, baselineConfigs BenchmarkConfigs, benchmarks []parse.Benchmark, configs BenchmarkConfigs, dimension Dimension) ([]Comparison, error) {
+	baselines, err := newComparisonBaselines(baseline, baselineConfigs, dimension)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Comparison
+	for _, samples := range GroupSamplesByConfig(benchmarks, configs) {
+		comparison, ok, err := baselines.compare(samples.Mean(), configs, dimension)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			results = append(results, comparison)
+		}
+	}
+	return results, nil
+}
+
+// comparisonBaselines holds the mean value of each baseline benchmark, keyed by name and package - see referenceKey.
+type comparisonBaselines map[string]float64
+
+// newComparisonBaselines provides the mean value of each baseline benchmark in the dimension.
+func newComparisonBaselines(baseline []parse.Benchmark, baselineConfigs BenchmarkConfigs, dimension Dimension) (comparisonBaselines, error) {
+	return meanValues(baseline, baselineConfigs, dimension, baselineKeys)
+}
+
+// compare compares the benchmark - the mean of its samples - with its baseline, and reports whether it has one.
+func (c comparisonBaselines) compare(benchmark parse.Benchmark, configs BenchmarkConfigs, dimension Dimension) (Comparison, bool, error) {
+	current, err := dimension.Value(benchmark)
+	if err != nil {
+		return Comparison{}, false, err
+	}
+	config := configs[benchmark.Ord]
+	base, ok := c[referenceKey(benchmark.Name, config, baselineKeys)]
+	if !ok {
+		return Comparison{}, false, nil
+	}
+
+	comparison := Comparison{
+		Name:     benchmark.Name,
+		Package:  config["pkg"],
+		Baseline: base,
+		Current:  current,
+	}
+	if base != 0 {
+		comparison.Change = (current - base) / base
+	} else if current > 0 {
+		// Any increase from nothing is an infinite change, which is never tolerated.
+		comparison.Change = math.Inf(1)
+	}
+	return comparison, true, nil
+}
@@ -0,0 +1,117 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	baseline := []parse.Benchmark{
+		{Name: "BenchmarkOne/Faster", NsPerOp: 200},
+		{Name: "BenchmarkOne/Slower", NsPerOp: 100},
+		{Name: "BenchmarkOne/Slower", NsPerOp: 300},
+		{Name: "BenchmarkOne/Removed", NsPerOp: 100},
+		{Name: "BenchmarkOne/FromZero", NsPerOp: 0},
+	}
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Slower", NsPerOp: 250},
+		{Name: "BenchmarkOne/Added", NsPerOp: 100},
+		{Name: "BenchmarkOne/Faster", NsPerOp: 150},
+		{Name: "BenchmarkOne/FromZero", NsPerOp: 10},
+	}
+
+	got, err := Compare(baseline, nil, benchmarks, nil, RenderNsPerOp)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []Comparison{
+		{Name: "BenchmarkOne/Slower", Baseline: 200, Current: 250, Change: 0.25},
+		{Name: "BenchmarkOne/Faster", Baseline: 200, Current: 150, Change: -0.25},
+		{Name: "BenchmarkOne/FromZero", Baseline: 0, Current: 10, Change: math.Inf(1)},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestCompare_Packages(t *testing.T) {
+	baseline := []parse.Benchmark{
+		{Name: "BenchmarkOne", NsPerOp: 100, Ord: 0},
+		{Name: "BenchmarkOne", NsPerOp: 200, Ord: 1},
+	}
+	baselineConfigs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a"},
+		1: {"pkg": "example.com/b"},
+	}
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne", NsPerOp: 100, Ord: 0},
+		{Name: "BenchmarkOne", NsPerOp: 300, Ord: 1},
+		{Name: "BenchmarkOne", NsPerOp: 300, Ord: 2},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a", "cpu": "x"},
+		1: {"pkg": "example.com/b", "cpu": "x"},
+		2: {"pkg": "example.com/c", "cpu": "x"},
+	}
+
+	got, err := Compare(baseline, baselineConfigs, benchmarks, configs, RenderNsPerOp)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []Comparison{
+		{Name: "BenchmarkOne", Package: "example.com/a", Baseline: 100, Current: 100, Change: 0},
+		{Name: "BenchmarkOne", Package: "example.com/b", Baseline: 200, Current: 300, Change: 0.5},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestCompare_UnknownDimension(t *testing.T) {
+	benchmarks := []parse.Benchmark{{Name: "BenchmarkOne", NsPerOp: 100}}
+	_, err := Compare(benchmarks, nil, benchmarks, nil, RenderDimension(1000))
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Wanted error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+}
+
+func TestComparison_Regressed(t *testing.T) {
+	tests := []struct {
+		name      string
+		change    float64
+		tolerance float64
+		want      bool
+	}{
+		{
+			name:      "improved",
+			change:    -0.5,
+			tolerance: 0,
+			want:      false,
+		},
+		{
+			name:      "within tolerance",
+			change:    0.05,
+			tolerance: 0.05,
+			want:      false,
+		},
+		{
+			name:      "beyond tolerance",
+			change:    0.06,
+			tolerance: 0.05,
+			want:      true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Comparison{Change: test.change}.Regressed(test.tolerance)
+			if test.want != got {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ===== ComplexityModel =====
+
+// ComplexityModel is a model of how a metric grows with the size of the input of a benchmark.
+type ComplexityModel int
+
+const (
+	// ConstantComplexity does not grow with the size - O(1).
+	ConstantComplexity ComplexityModel = iota
+	// LogarithmicComplexity grows with the logarithm of the size - O(log n).
+	LogarithmicComplexity
+	// LinearComplexity grows in proportion to the size - O(n).
+	LinearComplexity
+	// LinearithmicComplexity grows with the size times its logarithm - O(n log n).
+	LinearithmicComplexity
+	// QuadraticComplexity grows with the square of the size - O(n²).
+	QuadraticComplexity
+)
+
+// complexityModels holds every model, from the slowest growing to the fastest.
+var complexityModels = []ComplexityModel{
+	ConstantComplexity,
+	LogarithmicComplexity,
+	LinearComplexity,
+	LinearithmicComplexity,
+	QuadraticComplexity,
+}
+
+func (c ComplexityModel) String() string {
+	switch c {
+	case ConstantComplexity:
+		return "CONSTANT"
+	case LogarithmicComplexity:
+		return "LOGARITHMIC"
+	case LinearComplexity:
+		return "LINEAR"
+	case LinearithmicComplexity:
+		return "LINEARITHMIC"
+	case QuadraticComplexity:
+		return "QUADRATIC"
+	default:
+		return fmt.Sprintf("Unknown (%d)", c)
+	}
+}
+
+// Notation provides the model in big O notation - for instance, "O(n log n)".
+func (c ComplexityModel) Notation() string {
+	if term := c.term(); term != "" {
+		return "O(" + term + ")"
+	}
+	return "O(1)"
+}
+
+// term provides the term of the model scaled by its coefficient - empty for ConstantComplexity, which has none.
+func (c ComplexityModel) term() string {
+	switch c {
+	case LogarithmicComplexity:
+		return "log n"
+	case LinearComplexity:
+		return "n"
+	case LinearithmicComplexity:
+		return "n log n"
+	case QuadraticComplexity:
+		return "n²"
+	default:
+		return ""
+	}
+}
+
+// scale provides the term of the model for the size.
+func (c ComplexityModel) scale(size float64) float64 {
+	switch c {
+	case LogarithmicComplexity:
+		return math.Log(size)
+	case LinearComplexity:
+		return size
+	case LinearithmicComplexity:
+		return size * math.Log(size)
+	case QuadraticComplexity:
+		return size * size
+	default:
+		return 0
+	}
+}
+
+// ===== ComplexityFit =====
+
+// complexityGrowthThreshold is the growth across the sizes, as a fraction of the mean value, below which a metric is
+// considered constant - so that noise in a flat metric is not mistaken for a trend.
+const complexityGrowthThreshold = 0.1
+
+// ComplexityFit is a complexity model fitted to the values of a metric across sizes, as Intercept + Coefficient × the
+// term of the model - for instance, 120 + 0.5 × n log n.
+type ComplexityFit struct {
+	Model       ComplexityModel
+	Intercept   float64
+	Coefficient float64
+	// RSquared is the fraction of the variation of the values explained by the model - from 1 for a perfect fit, to
+	// zero for ConstantComplexity, which explains none of it.
+	RSquared float64
+	// RMS is the root mean square of the differences between the values and the model, as a fraction of the mean
+	// value.
+	RMS float64
+}
+
+// Value provides the value of the model at the size.
+func (c ComplexityFit) Value(size float64) float64 {
+	return c.Intercept + c.Coefficient*c.Model.scale(size)
+}
+
+// Formula provides the fitted model as a formula of the size - for instance, "120 + 0.5 × n log n".
+func (c ComplexityFit) Formula() string {
+	intercept := strconv.FormatFloat(c.Intercept, 'g', 3, 64)
+	if c.Model == ConstantComplexity {
+		return intercept
+	}
+	return fmt.Sprintf("%s + %s × %s", intercept, strconv.FormatFloat(c.Coefficient, 'g', 3, 64), c.Model.term())
+}
+
+func (c ComplexityFit) String() string {
+	return fmt.Sprintf("%s (R² %.3f, RMS %.1f%%)", c.Model.Notation(), c.RSquared, c.RMS*100)
+}
+
+// FitComplexity fits each complexity model to the values at the sizes by least squares, and provides the best fit -
+// the model with the greatest R², adjusted for the intercept-only ConstantComplexity having fewer terms.  Models whose
+// values fall as the size grows are not considered, and neither are those growing by less than a tenth of the mean
+// value across the sizes, in favour of ConstantComplexity.  At least three distinct sizes, all positive, are required
+// - otherwise, an ErrInsufficientSizes is returned.
+func FitComplexity(sizes, values []float64) (ComplexityFit, error) {
+	if len(sizes) != len(values) {
+		return ComplexityFit{}, fmt.Errorf("%d sizes given for %d values: %w", len(sizes), len(values), ErrInsufficientSizes)
+	}
+	distinct := make(map[float64]bool, len(sizes))
+	for _, size := range sizes {
+		if size <= 0 {
+			return ComplexityFit{}, fmt.Errorf("size %v is not positive: %w", size, ErrInsufficientSizes)
+		}
+		distinct[size] = true
+	}
+	if len(distinct) < 3 {
+		return ComplexityFit{}, fmt.Errorf("%d distinct sizes given, at least 3 are required: %w", len(distinct), ErrInsufficientSizes)
+	}
+
+	mean := 0.0
+	for _, value := range values {
+		mean += value
+	}
+	mean /= float64(len(values))
+
+	best := fitComplexityModel(ConstantComplexity, sizes, values, mean)
+	bestAdjusted := 0.0
+	for _, model := range complexityModels[1:] {
+		fit := fitComplexityModel(model, sizes, values, mean)
+		if fit.Coefficient <= 0 {
+			continue
+		}
+
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, size := range sizes {
+			low = math.Min(low, fit.Value(size))
+			high = math.Max(high, fit.Value(size))
+		}
+		if high-low < complexityGrowthThreshold*math.Abs(mean) {
+			continue
+		}
+
+		// Adjusted R² penalises the term of the model, which ConstantComplexity does without.
+		adjusted := 1 - (1-fit.RSquared)*float64(len(values)-1)/float64(len(values)-2)
+		if adjusted > bestAdjusted {
+			best, bestAdjusted = fit, adjusted
+		}
+	}
+	return best, nil
+}
+
+// fitComplexityModel fits the model to the values at the sizes by least squares.
+func fitComplexityModel(model ComplexityModel, sizes, values []float64, mean float64) ComplexityFit {
+	result := ComplexityFit{Model: model, Intercept: mean}
+
+	if model != ConstantComplexity {
+		terms := make([]float64, len(sizes))
+		termMean := 0.0
+		for index, size := range sizes {
+			terms[index] = model.scale(size)
+			termMean += terms[index]
+		}
+		termMean /= float64(len(terms))
+
+		var covariance, variance float64
+		for index, term := range terms {
+			covariance += (term - termMean) * (values[index] - mean)
+			variance += (term - termMean) * (term - termMean)
+		}
+		if variance > 0 {
+			result.Coefficient = covariance / variance
+			result.Intercept = mean - result.Coefficient*termMean
+		}
+	}
+
+	var residual, total float64
+	for index, size := range sizes {
+		difference := values[index] - result.Value(size)
+		residual += difference * difference
+		total += (values[index] - mean) * (values[index] - mean)
+	}
+	if total > 0 {
+		result.RSquared = 1 - residual/total
+	}
+	if mean != 0 {
+		result.RMS = math.Sqrt(residual/float64(len(values))) / math.Abs(mean)
+	}
+	return result
+}
+
+// ===== Pivots =====
+
+// FitPivotComplexity fits complexity models to each series of the pivot, across the sizes along its X axis - see
+// FitComplexity.  The samples at each point are combined into their mean.  The fits are keyed by series name, and
+// series with too few points are omitted.  The X values provide the sizes - either numbers, or names holding a single
+// number, such as "Length_10".  If any X value is not a size, an ErrInsufficientSizes is returned, and if the
+// dimension is unknown, an ErrUnknownDimensionType.
+func FitPivotComplexity(pivot Pivot, dimension Dimension) (map[string]ComplexityFit, error) {
+	sizes, err := pivotSizes(pivot)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := newLineSeries(pivot, dimension)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]ComplexityFit, len(series))
+	for _, s := range series {
+		var seriesSizes, values []float64
+		for index, value := range s.values {
+			if !math.IsNaN(value) {
+				seriesSizes = append(seriesSizes, sizes[index])
+				values = append(values, value)
+			}
+		}
+
+		fit, err := FitComplexity(seriesSizes, values)
+		if err != nil {
+			continue
+		}
+		results[s.name] = fit
+	}
+	return results, nil
+}
+
+// pivotSizes provides the size given by each X value of the pivot.
+func pivotSizes(pivot Pivot) ([]float64, error) {
+	sizes := make([]float64, 0, len(pivot.X))
+	for _, x := range pivot.X {
+		size, ok := sizeValue(x)
+		if !ok {
+			return nil, fmt.Errorf("x axis value %q is not a size: %w", x, ErrInsufficientSizes)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// sizeValue provides the size given by the value - either a number, or a name holding a single whole number, such as
+// "Length_10" or "10_Fields" - and whether the value gives one.
+func sizeValue(value string) (float64, bool) {
+	if size, err := strconv.ParseFloat(value, 64); err == nil {
+		return size, true
+	}
+
+	numbers := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+	if len(numbers) != 1 {
+		return 0, false
+	}
+	size, err := strconv.ParseFloat(numbers[0], 64)
+	return size, err == nil
+}
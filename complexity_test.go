@@ -0,0 +1,183 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestComplexityModel_String(t *testing.T) {
+	tests := []struct {
+		model        ComplexityModel
+		want         string
+		wantNotation string
+	}{
+		{model: ConstantComplexity, want: "CONSTANT", wantNotation: "O(1)"},
+		{model: LogarithmicComplexity, want: "LOGARITHMIC", wantNotation: "O(log n)"},
+		{model: LinearComplexity, want: "LINEAR", wantNotation: "O(n)"},
+		{model: LinearithmicComplexity, want: "LINEARITHMIC", wantNotation: "O(n log n)"},
+		{model: QuadraticComplexity, want: "QUADRATIC", wantNotation: "O(n²)"},
+		{model: 10, want: "Unknown (10)", wantNotation: "O(1)"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if got := test.model.String(); test.want != got {
+				t.Errorf("Want %q, got %q", test.want, got)
+			}
+			if got := test.model.Notation(); test.wantNotation != got {
+				t.Errorf("Want notation %q, got %q", test.wantNotation, got)
+			}
+		})
+	}
+}
+
+func TestFitComplexity(t *testing.T) {
+	sizes := []float64{10, 20, 40, 80, 160, 320, 640, 1280}
+	// noise keeps the values from fitting any model perfectly.
+	noise := []float64{1.02, 0.98, 1.01, 0.99, 1.03, 0.97, 1.01, 0.99}
+
+	tests := []struct {
+		name  string
+		model func(size float64) float64
+		want  ComplexityModel
+	}{
+		{name: "constant", model: func(float64) float64 { return 500 }, want: ConstantComplexity},
+		{name: "logarithmic", model: func(size float64) float64 { return 100 + 80*math.Log(size) }, want: LogarithmicComplexity},
+		{name: "linear", model: func(size float64) float64 { return 200 + 3*size }, want: LinearComplexity},
+		{name: "linearithmic", model: func(size float64) float64 { return 50 + 2*size*math.Log(size) }, want: LinearithmicComplexity},
+		{name: "quadratic", model: func(size float64) float64 { return 300 + 0.5*size*size }, want: QuadraticComplexity},
+		{name: "falling", model: func(size float64) float64 { return 5000 - size }, want: ConstantComplexity},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values := make([]float64, 0, len(sizes))
+			for index, size := range sizes {
+				values = append(values, test.model(size)*noise[index])
+			}
+
+			got, err := FitComplexity(sizes, values)
+			if err != nil {
+				t.Fatalf("Could not fit complexity - error: %v", err)
+			}
+			if test.want != got.Model {
+				t.Errorf("Want model %v, got %v", test.want, got)
+			}
+			if got.Model != ConstantComplexity && got.RSquared < 0.9 {
+				t.Errorf("Want R² of at least 0.9, got %v", got.RSquared)
+			}
+		})
+	}
+}
+
+func TestFitComplexityExact(t *testing.T) {
+	sizes := []float64{1, 2, 3, 4}
+	values := []float64{12, 14, 16, 18}
+
+	got, err := FitComplexity(sizes, values)
+	if err != nil {
+		t.Fatalf("Could not fit complexity - error: %v", err)
+	}
+	want := ComplexityFit{Model: LinearComplexity, Intercept: 10, Coefficient: 2, RSquared: 1}
+	if math.Abs(want.Intercept-got.Intercept) > 1e-9 || math.Abs(want.Coefficient-got.Coefficient) > 1e-9 ||
+		math.Abs(want.RSquared-got.RSquared) > 1e-9 || got.RMS > 1e-9 || want.Model != got.Model {
+		t.Errorf("Want %+v, got %+v", want, got)
+	}
+	if value := got.Value(10); math.Abs(30-value) > 1e-9 {
+		t.Errorf("Want value 30 at size 10, got %v", value)
+	}
+	if formula := got.Formula(); formula != "10 + 2 × n" {
+		t.Errorf("Want formula %q, got %q", "10 + 2 × n", formula)
+	}
+	if str := got.String(); str != "O(n) (R² 1.000, RMS 0.0%)" {
+		t.Errorf("Want %q, got %q", "O(n) (R² 1.000, RMS 0.0%)", str)
+	}
+}
+
+func TestFitComplexityErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		sizes  []float64
+		values []float64
+	}{
+		{name: "too few sizes", sizes: []float64{10, 20}, values: []float64{1, 2}},
+		{name: "repeated sizes", sizes: []float64{10, 10, 20, 20}, values: []float64{1, 1, 2, 2}},
+		{name: "non-positive size", sizes: []float64{0, 10, 20}, values: []float64{1, 2, 3}},
+		{name: "mismatched values", sizes: []float64{10, 20, 40}, values: []float64{1, 2}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := FitComplexity(test.sizes, test.values)
+			if !errors.Is(err, ErrInsufficientSizes) {
+				t.Errorf("Want error '%v', got error '%v'", ErrInsufficientSizes, err)
+			}
+		})
+	}
+}
+
+func TestFitPivotComplexity(t *testing.T) {
+	var benchmarks []parse.Benchmark
+	for _, size := range []int{10, 20, 40, 80} {
+		benchmarks = append(benchmarks,
+			parse.Benchmark{Name: "BenchmarkParse/Length_" + strconv.Itoa(size), N: 100, NsPerOp: float64(size * size), Measured: parse.NsPerOp},
+			parse.Benchmark{Name: "BenchmarkScan/Length_" + strconv.Itoa(size), N: 100, NsPerOp: float64(100 + size), Measured: parse.NsPerOp},
+		)
+	}
+	// A series with too few sizes is omitted.
+	benchmarks = append(benchmarks, parse.Benchmark{Name: "BenchmarkSkip/Length_10", N: 100, NsPerOp: 10, Measured: parse.NsPerOp})
+
+	pivot := NewPivot(benchmarks, nil, nil, nil)
+	fits, err := FitPivotComplexity(pivot, RenderNsPerOp)
+	if err != nil {
+		t.Fatalf("Could not fit complexity - error: %v", err)
+	}
+
+	got := make(map[string]ComplexityModel)
+	for series, fit := range fits {
+		got[series] = fit.Model
+	}
+	want := map[string]ComplexityModel{
+		"BenchmarkParse": QuadraticComplexity,
+		"BenchmarkScan":  LinearComplexity,
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+}
+
+func TestFitPivotComplexityNotSizes(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=quick", N: 100, NsPerOp: 10, Measured: parse.NsPerOp},
+		{Name: "BenchmarkSort/algo=std", N: 100, NsPerOp: 20, Measured: parse.NsPerOp},
+	}
+
+	_, err := FitPivotComplexity(NewPivot(benchmarks, nil, nil, nil), RenderNsPerOp)
+	if !errors.Is(err, ErrInsufficientSizes) {
+		t.Errorf("Want error '%v', got error '%v'", ErrInsufficientSizes, err)
+	}
+}
+
+func TestSizeValue(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   float64
+		wantOk bool
+	}{
+		{value: "1024", want: 1024, wantOk: true},
+		{value: "2.5", want: 2.5, wantOk: true},
+		{value: "Length_10", want: 10, wantOk: true},
+		{value: "10_Fields", want: 10, wantOk: true},
+		{value: "quick", wantOk: false},
+		{value: "10x20", wantOk: false},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, ok := sizeValue(test.value)
+			if test.wantOk != ok || test.want != got {
+				t.Errorf("Want %v (%v), got %v (%v)", test.want, test.wantOk, got, ok)
+			}
+		})
+	}
+}
@@ -9,14 +9,33 @@ import (
 )
 
 type CSVRenderer struct {
-
+	// Pareto adds a column to the output, reporting whether each benchmark is Pareto optimal in the rendered
+	// dimension and SecondaryDimension - comparing the mean of each benchmark's samples, as scatter charts do, so
+	// every sample of a benchmark run with the same configuration is reported alike.  The dimensions must differ.
+	Pareto             bool
+	SecondaryDimension Dimension
+	// Configs holds the configuration of each benchmark, by its Ord.  Samples run with different configurations are
+	// compared separately for the Pareto column.
+	Configs BenchmarkConfigs
 }
 
-func (c *CSVRenderer) Render(writer io.Writer, parentBenchmark string, dimension RenderDimension, benchmarks []parse.Benchmark) error {
+func (c *CSVRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
 	csvWriter := csv.NewWriter(writer)
 
 	// Write header
 	header := []string{"Name", "N", "NsPerOp", "AllocedBytesPerOp", "AllocsPerOp", "MBPerS", "Measured", "Ord"}
+	// optimal holds whether each benchmark is Pareto optimal, keyed by its name and configuration.
+	optimal := make(map[string]bool)
+	if c.Pareto {
+		frontier, err := ParetoFrontier(benchmarks, c.Configs, dimension, c.SecondaryDimension)
+		if err != nil {
+			return err
+		}
+		for _, benchmark := range frontier {
+			optimal[sampleKey(benchmark, c.Configs)] = true
+		}
+		header = append(header, "ParetoOptimal")
+	}
 	err := csvWriter.Write(header)
 	if err != nil {
 		return err
@@ -33,6 +52,9 @@ func (c *CSVRenderer) Render(writer io.Writer, parentBenchmark string, dimension
 		ord := strconv.Itoa(benchmark.Ord)
 
 		record := []string{benchmark.Name, n, nsPerOp, allocedBytesPerOp, allocsPerOp, mbPerS, measured, ord}
+		if c.Pareto {
+			record = append(record, strconv.FormatBool(optimal[sampleKey(benchmark, c.Configs)]))
+		}
 		err := csvWriter.Write(record)
 		if err != nil {
 			return err
@@ -113,6 +113,68 @@ func TestCSVRenderer_Render_WriteErrors(t *testing.T) {
 	}
 }
 
+func TestCSVRenderer_RenderPareto(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Small", NsPerOp: 300, AllocedBytesPerOp: 100},
+		{Name: "BenchmarkOne/Slow", NsPerOp: 400, AllocedBytesPerOp: 200},
+		{Name: "BenchmarkOne/Small", NsPerOp: 500, AllocedBytesPerOp: 100},
+	}
+
+	var output bytes.Buffer
+	renderer := CSVRenderer{Pareto: true, SecondaryDimension: RenderBytesPerOp}
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering CSV: %v", err)
+	}
+
+	want := `Name,N,NsPerOp,AllocedBytesPerOp,AllocsPerOp,MBPerS,Measured,Ord,ParetoOptimal
+BenchmarkOne/Small,0,300.000000000000,100,0,0.000000000000,0,0,true
+BenchmarkOne/Slow,0,400.000000000000,200,0,0.000000000000,0,0,false
+BenchmarkOne/Small,0,500.000000000000,100,0,0.000000000000,0,0,true
+`
+	if want != output.String() {
+		t.Errorf("want %q, got %q", want, output.String())
+	}
+
+	renderer.SecondaryDimension = RenderDimension(1000)
+	err = renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+
+	renderer.SecondaryDimension = RenderNsPerOp
+	err = renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if !errors.Is(err, ErrIdenticalDimensions) {
+		t.Errorf("Want error '%v', got error '%v'", ErrIdenticalDimensions, err)
+	}
+}
+
+func TestCSVRenderer_RenderParetoConfigs(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Small", NsPerOp: 300, AllocedBytesPerOp: 100, Ord: 0},
+		{Name: "BenchmarkOne/Small", NsPerOp: 400, AllocedBytesPerOp: 200, Ord: 1},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a"},
+		1: {"pkg": "example.com/b"},
+	}
+
+	var output bytes.Buffer
+	renderer := CSVRenderer{Pareto: true, SecondaryDimension: RenderBytesPerOp, Configs: configs}
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering CSV: %v", err)
+	}
+
+	want := `Name,N,NsPerOp,AllocedBytesPerOp,AllocsPerOp,MBPerS,Measured,Ord,ParetoOptimal
+BenchmarkOne/Small,0,300.000000000000,100,0,0.000000000000,0,0,true
+BenchmarkOne/Small,0,400.000000000000,200,0,0.000000000000,0,1,false
+`
+	if want != output.String() {
+		t.Errorf("want %q, got %q", want, output.String())
+	}
+}
+
 func BenchmarkCSVRenderer_Render(b *testing.B) {
 
 	benchmark := parse.Benchmark{
@@ -0,0 +1,127 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"gopkg.in/yaml.v3"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// derivedDimension is a dimension computed from the benchmarks, rather than read from a single metric - such as the
+// ratio of each benchmark to a reference, or a defined expression.
+type derivedDimension struct {
+	name  string
+	title string
+	// scales are the units the dimension can be scaled to, from smallest to largest.
+	scales []unit
+	// referenceValue is the value at which charts draw a reference line, if hasReference is set.
+	referenceValue float64
+	hasReference   bool
+	value          func(benchmark parse.Benchmark) (float64, error)
+}
+
+func (d *derivedDimension) String() string {
+	return d.name
+}
+
+func (d *derivedDimension) Title() string {
+	return d.title
+}
+
+func (d *derivedDimension) Value(benchmark parse.Benchmark) (float64, error) {
+	return d.value(benchmark)
+}
+
+func (d *derivedDimension) FormatValue(value float64) string {
+	return formatValue(d, value)
+}
+
+func (d *derivedDimension) units() []unit {
+	if len(d.scales) == 0 {
+		return countUnits
+	}
+	return d.scales
+}
+
+func (d *derivedDimension) reference() (float64, bool) {
+	return d.referenceValue, d.hasReference
+}
+
+// ===== Defined dimensions =====
+
+// DimensionDefinition defines a dimension as an expression over the metrics and labels of the benchmarks - see
+// ParseExpression.
+type DimensionDefinition struct {
+	// Name identifies the dimension, such as in DimensionFromString.
+	Name       string `json:"name" yaml:"name"`
+	Expression string `json:"expression" yaml:"expression"`
+	// Title is used for axes and headings - if empty, the expression is used.
+	Title string `json:"title" yaml:"title"`
+}
+
+// ParseDimensionDefinition parses a definition of the form "name=expression" - for instance,
+// "per_element=ns_per_op / size".  If the definition has no name, an ErrInvalidDimensionName is returned.
+func ParseDimensionDefinition(str string) (DimensionDefinition, error) {
+	name, expression, ok := strings.Cut(str, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return DimensionDefinition{}, fmt.Errorf("definition %q is not of the form 'name=expression': %w", str, ErrInvalidDimensionName)
+	}
+	return DimensionDefinition{Name: name, Expression: strings.TrimSpace(expression)}, nil
+}
+
+// ReadDimensionDefinitions reads a list of definitions from the provided reader.  The list may be either JSON or YAML
+// formatted.  If the list cannot be read, an error is returned.
+func ReadDimensionDefinitions(reader io.Reader) ([]DimensionDefinition, error) {
+	var definitions []DimensionDefinition
+	// YAML is a superset of JSON, so a single decoder handles both formats.
+	err := yaml.NewDecoder(reader).Decode(&definitions)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode dimension definitions: %w", err)
+	}
+	return definitions, nil
+}
+
+// Define creates the dimension, whose values are its expression evaluated for each benchmark with its configuration
+// from configs.  Values are scaled to units like those of the first metric in the expression - so "ns_per_op / size"
+// is shown in units of time.  The dimension can be found by name with DimensionFromString.  If the name is empty or
+// that of a built-in dimension, an ErrInvalidDimensionName is returned, and if the
+// expression is invalid, an ErrInvalidExpression.
+func (d DimensionDefinition) Define(configs BenchmarkConfigs) (Dimension, error) {
+	if d.Name == "" || strings.ContainsFunc(d.Name, unicode.IsSpace) {
+		return nil, fmt.Errorf("dimension name %q must be a single word: %w", d.Name, ErrInvalidDimensionName)
+	}
+	for _, builtIn := range []RenderDimension{RenderNsPerOp, RenderBytesPerOp, RenderAllocsPerOp} {
+		if strings.EqualFold(d.Name, builtIn.String()) {
+			return nil, fmt.Errorf("dimension name %q is used by a built-in dimension: %w", d.Name, ErrInvalidDimensionName)
+		}
+	}
+
+	expression, err := ParseExpression(d.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	derived := &derivedDimension{
+		name:   d.Name,
+		title:  d.Title,
+		scales: countUnits,
+		value: func(benchmark parse.Benchmark) (float64, error) {
+			return expression.Evaluate(benchmark, configs[benchmark.Ord])
+		},
+	}
+	if derived.title == "" {
+		derived.title = expression.String()
+	}
+	if len(expression.metrics) > 0 {
+		switch expression.metrics[0] {
+		case "ns_per_op":
+			derived.scales = timeUnits
+		case "bytes_per_op":
+			derived.scales = byteUnits
+		}
+	}
+	return derived, nil
+}
@@ -0,0 +1,197 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDimensionDefinition_Define(t *testing.T) {
+	benchmark := parse.Benchmark{
+		Name:              "BenchmarkSort/size=100-8",
+		NsPerOp:           2000,
+		AllocedBytesPerOp: 4096,
+		AllocsPerOp:       2,
+		Measured:          parse.NsPerOp | parse.AllocedBytesPerOp | parse.AllocsPerOp,
+	}
+	configs := BenchmarkConfigs{benchmark.Ord: {"cores": "4"}}
+
+	tests := []struct {
+		definition    DimensionDefinition
+		want          float64
+		wantTitle     string
+		wantFormatted string
+	}{
+		{
+			definition:    DimensionDefinition{Name: "per_element", Expression: "ns_per_op / size"},
+			want:          20,
+			wantTitle:     "ns_per_op / size",
+			wantFormatted: "20ns",
+		},
+		{
+			definition:    DimensionDefinition{Name: "alloc_size", Expression: "bytes_per_op / allocs_per_op", Title: "Allocation size"},
+			want:          2048,
+			wantTitle:     "Allocation size",
+			wantFormatted: "2KiB",
+		},
+		{
+			definition:    DimensionDefinition{Name: "per_core", Expression: "size * 1000 / cores"},
+			want:          25000,
+			wantTitle:     "size * 1000 / cores",
+			wantFormatted: "25k",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.definition.Name, func(t *testing.T) {
+			dimension, err := test.definition.Define(configs)
+			if err != nil {
+				t.Fatalf("Could not define dimension - error: %v", err)
+			}
+
+			got, err := dimension.Value(benchmark)
+			if err != nil {
+				t.Fatalf("Could not determine value - error: %v", err)
+			}
+			if test.want != got {
+				t.Errorf("Want value %v, got value %v", test.want, got)
+			}
+			if test.definition.Name != dimension.String() {
+				t.Errorf("Want name %q, got name %q", test.definition.Name, dimension.String())
+			}
+			if test.wantTitle != dimension.Title() {
+				t.Errorf("Want title %q, got title %q", test.wantTitle, dimension.Title())
+			}
+			if formatted := dimension.FormatValue(got); test.wantFormatted != formatted {
+				t.Errorf("Want formatted value %q, got %q", test.wantFormatted, formatted)
+			}
+			if _, ok := dimension.reference(); ok {
+				t.Error("Want no reference line for defined dimensions")
+			}
+
+			found, err := DimensionFromString(test.definition.Name, []Dimension{dimension})
+			if err != nil {
+				t.Fatalf("Could not find dimension by name - error: %v", err)
+			}
+			if dimension != found {
+				t.Errorf("Want dimension %v by name, got %v", dimension, found)
+			}
+		})
+	}
+}
+
+func TestDimensionDefinition_DefineErrors(t *testing.T) {
+	tests := []struct {
+		definition DimensionDefinition
+		wantErr    error
+	}{
+		{definition: DimensionDefinition{Expression: "ns_per_op"}, wantErr: ErrInvalidDimensionName},
+		{definition: DimensionDefinition{Name: "two words", Expression: "ns_per_op"}, wantErr: ErrInvalidDimensionName},
+		{definition: DimensionDefinition{Name: "ns_per_op", Expression: "ns_per_op"}, wantErr: ErrInvalidDimensionName},
+		{definition: DimensionDefinition{Name: "invalid", Expression: "ns_per_op /"}, wantErr: ErrInvalidExpression},
+	}
+	for _, test := range tests {
+		t.Run(test.definition.Name, func(t *testing.T) {
+			_, err := test.definition.Define(nil)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDimensionFromString(t *testing.T) {
+	first, err := DimensionDefinition{Name: "redefined", Expression: "1"}.Define(nil)
+	if err != nil {
+		t.Fatalf("Could not define dimension - error: %v", err)
+	}
+	second, err := DimensionDefinition{Name: "redefined", Expression: "2"}.Define(nil)
+	if err != nil {
+		t.Fatalf("Could not define dimension - error: %v", err)
+	}
+
+	// Each caller finds only the dimensions it defined, so definitions of the same name do not interfere.
+	for _, want := range []Dimension{first, second} {
+		got, err := DimensionFromString("redefined", []Dimension{want})
+		if err != nil {
+			t.Fatalf("Could not find dimension by name - error: %v", err)
+		}
+		if want != got {
+			t.Errorf("Want dimension %v, got %v", want, got)
+		}
+	}
+	if value, err := second.Value(parse.Benchmark{}); err != nil || value != 2 {
+		t.Errorf("Want value 2, got value %v (error: %v)", value, err)
+	}
+
+	got, err := DimensionFromString("BYTES_PER_OP", []Dimension{first})
+	if err != nil || got != RenderBytesPerOp {
+		t.Errorf("Want built-in dimension %v, got %v (error: %v)", RenderBytesPerOp, got, err)
+	}
+
+	_, err = DimensionFromString("redefined", nil)
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+}
+
+func TestParseDimensionDefinition(t *testing.T) {
+	got, err := ParseDimensionDefinition(" per_element = ns_per_op / size ")
+	if err != nil {
+		t.Fatalf("Could not parse definition - error: %v", err)
+	}
+	want := DimensionDefinition{Name: "per_element", Expression: "ns_per_op / size"}
+	if want != got {
+		t.Errorf("Want %+v, got %+v", want, got)
+	}
+
+	for _, invalid := range []string{"ns_per_op / size", "=ns_per_op"} {
+		_, err = ParseDimensionDefinition(invalid)
+		if !errors.Is(err, ErrInvalidDimensionName) {
+			t.Errorf("Want error '%v' for %q, got error '%v'", ErrInvalidDimensionName, invalid, err)
+		}
+	}
+}
+
+func TestReadDimensionDefinitions(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "json",
+			input: `[{"name": "per_element", "expression": "ns_per_op / size", "title": "Time per element"}, {"name": "alloc_size", "expression": "bytes_per_op / allocs_per_op"}]`,
+		},
+		{
+			name: "yaml",
+			input: `
+- name: per_element
+  expression: ns_per_op / size
+  title: Time per element
+- name: alloc_size
+  expression: bytes_per_op / allocs_per_op
+`,
+		},
+	}
+	want := []DimensionDefinition{
+		{Name: "per_element", Expression: "ns_per_op / size", Title: "Time per element"},
+		{Name: "alloc_size", Expression: "bytes_per_op / allocs_per_op"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ReadDimensionDefinitions(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatalf("Could not read definitions - error: %v", err)
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("Want %+v, got %+v", want, got)
+			}
+		})
+	}
+
+	_, err := ReadDimensionDefinitions(strings.NewReader("name: not a list"))
+	if err == nil {
+		t.Error("Want error reading invalid definitions, got none")
+	}
+}
@@ -3,8 +3,27 @@ package go_benchpress
 import "errors"
 
 var (
-	ErrNoBenchmarksProvided = errors.New("could not render benchmarks - no benchmarks provided")
-	ErrUnknownRenderType    = errors.New("unknown render type")
-	ErrCouldNotParseLine    = errors.New("could not parse benchmark line")
-	ErrUnknownDimensionType = errors.New("unknown render dimension type")
+	ErrNoBenchmarksProvided   = errors.New("could not render benchmarks - no benchmarks provided")
+	ErrUnknownRenderType      = errors.New("unknown render type")
+	ErrCouldNotParseLine      = errors.New("could not parse benchmark line")
+	ErrUnknownDimensionType   = errors.New("unknown render dimension type")
+	ErrUnknownTheme           = errors.New("unknown theme")
+	ErrInvalidThemeColour     = errors.New("invalid theme colour")
+	ErrUnknownErrorBars       = errors.New("unknown error bars type")
+	ErrUnknownOrientation     = errors.New("unknown orientation")
+	ErrUnknownChartType       = errors.New("unknown chart type")
+	ErrMissingNameParameter   = errors.New("benchmark name parameter missing")
+	ErrUnknownVegaLiteVariant = errors.New("unknown vega-lite variant")
+	ErrInvalidFilter          = errors.New("invalid filter")
+	ErrInvalidProjectionKey   = errors.New("invalid projection key")
+	ErrUnknownSortOrder       = errors.New("unknown sort order")
+	ErrUnknownTopSelection    = errors.New("unknown top selection")
+	ErrUnknownOthersMode      = errors.New("unknown others mode")
+	ErrUnknownRelativeMode    = errors.New("unknown relative mode")
+	ErrMissingReference       = errors.New("missing reference benchmark")
+	ErrInvalidExpression      = errors.New("invalid expression")
+	ErrInvalidExpressionValue = errors.New("invalid expression value")
+	ErrInvalidDimensionName   = errors.New("invalid dimension name")
+	ErrInsufficientSizes      = errors.New("insufficient sizes to fit complexity")
+	ErrIdenticalDimensions    = errors.New("dimensions must differ")
 )
@@ -0,0 +1,316 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+// Expression is an arithmetic expression over the metrics and labels of a benchmark, parsed by ParseExpression.
+type Expression struct {
+	source string
+	root   expressionNode
+	// metrics are the metrics used by the expression, in order of their first use.
+	metrics []string
+}
+
+// expressionNode is a node of a parsed arithmetic expression.
+type expressionNode interface {
+	evaluate(benchmark parse.Benchmark, config Config) (float64, error)
+}
+
+type expressionNumber struct {
+	value float64
+}
+
+func (e expressionNumber) evaluate(parse.Benchmark, Config) (float64, error) {
+	return e.value, nil
+}
+
+// expressionKey is the value of a metric, or a numeric label, of the benchmark.
+type expressionKey struct {
+	key string
+}
+
+func (e expressionKey) evaluate(benchmark parse.Benchmark, config Config) (float64, error) {
+	value, ok := filterValue(e.key, benchmark, config)
+	if !ok {
+		return 0, fmt.Errorf("benchmark %q has no value for %q: %w", benchmark.Name, e.key, ErrInvalidExpressionValue)
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("benchmark %q value %q for %q is not a number: %w", benchmark.Name, value, e.key, ErrInvalidExpressionValue)
+	}
+	return number, nil
+}
+
+type expressionNegate struct {
+	node expressionNode
+}
+
+func (e expressionNegate) evaluate(benchmark parse.Benchmark, config Config) (float64, error) {
+	value, err := e.node.evaluate(benchmark, config)
+	return -value, err
+}
+
+type expressionBinary struct {
+	operator    byte
+	left, right expressionNode
+}
+
+func (e expressionBinary) evaluate(benchmark parse.Benchmark, config Config) (float64, error) {
+	left, err := e.left.evaluate(benchmark, config)
+	if err != nil {
+		return 0, err
+	}
+	right, err := e.right.evaluate(benchmark, config)
+	if err != nil {
+		return 0, err
+	}
+
+	switch e.operator {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	default:
+		return left / right, nil
+	}
+}
+
+// expressionFunctions are the functions which can be called within expressions, keyed by name.
+var expressionFunctions = map[string]func(float64) float64{
+	"abs":   math.Abs,
+	"log":   math.Log,
+	"log2":  math.Log2,
+	"log10": math.Log10,
+	"sqrt":  math.Sqrt,
+}
+
+type expressionCall struct {
+	function func(float64) float64
+	argument expressionNode
+}
+
+func (e expressionCall) evaluate(benchmark parse.Benchmark, config Config) (float64, error) {
+	value, err := e.argument.evaluate(benchmark, config)
+	if err != nil {
+		return 0, err
+	}
+	return e.function(value), nil
+}
+
+// ParseExpression parses an arithmetic expression, combining numbers and keys with "+", "-", "*" and "/" (with the
+// usual precedence), grouped with parentheses.  Keys are the metrics ("n", "ns_per_op", "bytes_per_op",
+// "allocs_per_op" or "mb_per_s"), or numeric labels - such as the "size" parameter of "BenchmarkSort/size=100".  The
+// functions "abs", "log", "log2", "log10" and "sqrt" may be applied to parenthesised values.  For instance,
+// "ns_per_op / size" or "bytes_per_op / allocs_per_op".  If the expression is invalid, an ErrInvalidExpression is
+// returned.
+func ParseExpression(expression string) (*Expression, error) {
+	p := expressionParser{expression: expression}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.position < len(p.expression) {
+		return nil, p.errorf("unexpected %q", p.expression[p.position:])
+	}
+	return &Expression{source: expression, root: root, metrics: p.metrics}, nil
+}
+
+// String provides the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Evaluate provides the value of the expression for the benchmark, run with the configuration.  If the benchmark
+// lacks a key, a key is not numeric, or the value is not finite (for instance, after dividing by zero), an
+// ErrInvalidExpressionValue is returned.
+func (e *Expression) Evaluate(benchmark parse.Benchmark, config Config) (float64, error) {
+	value, err := e.root.evaluate(benchmark, config)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("expression %q is not finite for benchmark %q: %w", e.source, benchmark.Name, ErrInvalidExpressionValue)
+	}
+	return value, nil
+}
+
+// expressionParser is a recursive descent parser of arithmetic expressions.
+type expressionParser struct {
+	expression string
+	position   int
+	metrics    []string
+}
+
+func (p *expressionParser) parseSum() (expressionNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		operator, ok := p.operator('+', '-')
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = expressionBinary{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *expressionParser) parseProduct() (expressionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		operator, ok := p.operator('*', '/')
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = expressionBinary{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *expressionParser) parseUnary() (expressionNode, error) {
+	if _, ok := p.operator('-'); ok {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return expressionNegate{node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *expressionParser) parsePrimary() (expressionNode, error) {
+	p.skipSpace()
+	if p.position >= len(p.expression) {
+		return nil, p.errorf("expected a value")
+	}
+
+	if p.expression[p.position] == '(' {
+		return p.parseParenthesised()
+	}
+
+	start := p.position
+	if c := p.expression[p.position]; unicode.IsDigit(rune(c)) || c == '.' {
+		for p.position < len(p.expression) && isNumberChar(p.expression, p.position) {
+			p.position++
+		}
+		value, err := strconv.ParseFloat(p.expression[start:p.position], 64)
+		if err != nil {
+			p.position = start
+			return nil, p.errorf("invalid number")
+		}
+		return expressionNumber{value: value}, nil
+	}
+
+	for p.position < len(p.expression) && isExpressionKeyChar(rune(p.expression[p.position])) {
+		p.position++
+	}
+	key := p.expression[start:p.position]
+	if key == "" {
+		return nil, p.errorf("expected a value")
+	}
+
+	if function, ok := expressionFunctions[key]; ok {
+		p.skipSpace()
+		if p.position >= len(p.expression) || p.expression[p.position] != '(' {
+			return nil, p.errorf("expected '(' after %q", key)
+		}
+		argument, err := p.parseParenthesised()
+		if err != nil {
+			return nil, err
+		}
+		return expressionCall{function: function, argument: argument}, nil
+	}
+
+	if _, ok := filterMetrics[key]; ok && !p.usesMetric(key) {
+		p.metrics = append(p.metrics, key)
+	}
+	return expressionKey{key: key}, nil
+}
+
+// parseParenthesised parses an expression between parentheses, starting at the opening parenthesis.
+func (p *expressionParser) parseParenthesised() (expressionNode, error) {
+	p.position++
+	node, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.position >= len(p.expression) || p.expression[p.position] != ')' {
+		return nil, p.errorf("expected ')'")
+	}
+	p.position++
+	return node, nil
+}
+
+// operator consumes one of the operators if it comes next, providing the operator and whether it did.
+func (p *expressionParser) operator(operators ...byte) (byte, bool) {
+	p.skipSpace()
+	if p.position >= len(p.expression) {
+		return 0, false
+	}
+	for _, operator := range operators {
+		if p.expression[p.position] == operator {
+			p.position++
+			return operator, true
+		}
+	}
+	return 0, false
+}
+
+// usesMetric reports whether the metric has already been used by the expression.
+func (p *expressionParser) usesMetric(metric string) bool {
+	for _, used := range p.metrics {
+		if used == metric {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *expressionParser) skipSpace() {
+	for p.position < len(p.expression) && unicode.IsSpace(rune(p.expression[p.position])) {
+		p.position++
+	}
+}
+
+func (p *expressionParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("expression %q invalid at position %d, %s: %w", p.expression, p.position, fmt.Sprintf(format, args...), ErrInvalidExpression)
+}
+
+// isExpressionKeyChar reports whether the character may be part of a key.  Unlike filter keys, hyphens are excluded,
+// as they are subtraction.
+func isExpressionKeyChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// isNumberChar reports whether the character at the position continues a number - including the exponent of numbers
+// such as "1e6" or "2.5e-3".
+func isNumberChar(expression string, position int) bool {
+	c := expression[position]
+	if unicode.IsDigit(rune(c)) || c == '.' || c == 'e' || c == 'E' {
+		return true
+	}
+	if (c == '+' || c == '-') && position > 0 {
+		previous := expression[position-1]
+		return previous == 'e' || previous == 'E'
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+	"testing"
+)
+
+func TestParseExpression(t *testing.T) {
+	benchmark := parse.Benchmark{
+		Name:              "BenchmarkSort/algo=quick/size=100-8",
+		N:                 1000,
+		NsPerOp:           500,
+		AllocedBytesPerOp: 96,
+		AllocsPerOp:       3,
+		Measured:          parse.NsPerOp | parse.AllocedBytesPerOp | parse.AllocsPerOp,
+	}
+	config := Config{"cores": "4"}
+
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{expression: "ns_per_op / size", want: 5},
+		{expression: "bytes_per_op/allocs_per_op", want: 32},
+		{expression: "1 + 2 * 3", want: 7},
+		{expression: "(1 + 2) * 3", want: 9},
+		{expression: "10 - 4 - 3", want: 3},
+		{expression: "12 / 2 / 3", want: 2},
+		{expression: "-ns_per_op + --1", want: -499},
+		{expression: "ns_per_op / (size * log2(size))", want: 500 / (100 * math.Log2(100))},
+		{expression: "sqrt(abs(-16)) + log10(1e3) + log(1)", want: 7},
+		{expression: "2.5e-1 * n", want: 250},
+		{expression: "procs * cores", want: 32},
+		{expression: "  ns_per_op  ", want: 500},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			expression, err := ParseExpression(test.expression)
+			if err != nil {
+				t.Fatalf("Could not parse expression - error: %v", err)
+			}
+			if test.expression != expression.String() {
+				t.Errorf("Want source %q, got %q", test.expression, expression.String())
+			}
+
+			got, err := expression.Evaluate(benchmark, config)
+			if err != nil {
+				t.Fatalf("Could not evaluate expression - error: %v", err)
+			}
+			if math.Abs(test.want-got) > 1e-9 {
+				t.Errorf("Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"ns_per_op /",
+		"(ns_per_op",
+		"ns_per_op)",
+		"ns_per_op size",
+		"log2 size",
+		"1.2.3",
+		"* 2",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := ParseExpression(test)
+			if !errors.Is(err, ErrInvalidExpression) {
+				t.Errorf("Want error '%v', got error '%v'", ErrInvalidExpression, err)
+			}
+		})
+	}
+}
+
+func TestExpression_EvaluateErrors(t *testing.T) {
+	benchmark := parse.Benchmark{Name: "BenchmarkSort/algo=quick/size=0", NsPerOp: 500, Measured: parse.NsPerOp}
+
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "missing label", expression: "ns_per_op / workers"},
+		{name: "non-numeric label", expression: "ns_per_op / algo"},
+		{name: "unmeasured metric", expression: "bytes_per_op"},
+		{name: "division by zero", expression: "ns_per_op / size"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expression, err := ParseExpression(test.expression)
+			if err != nil {
+				t.Fatalf("Could not parse expression - error: %v", err)
+			}
+			_, err = expression.Evaluate(benchmark, nil)
+			if !errors.Is(err, ErrInvalidExpressionValue) {
+				t.Errorf("Want error '%v', got error '%v'", ErrInvalidExpressionValue, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter selects benchmarks matching an expression, parsed by ParseFilter.
+type Filter struct {
+	root filterNode
+}
+
+// filterNode is a node of a parsed filter expression.
+type filterNode interface {
+	match(benchmark parse.Benchmark, config Config) bool
+}
+
+type filterAnd struct {
+	left, right filterNode
+}
+
+func (f filterAnd) match(benchmark parse.Benchmark, config Config) bool {
+	return f.left.match(benchmark, config) && f.right.match(benchmark, config)
+}
+
+type filterOr struct {
+	left, right filterNode
+}
+
+func (f filterOr) match(benchmark parse.Benchmark, config Config) bool {
+	return f.left.match(benchmark, config) || f.right.match(benchmark, config)
+}
+
+type filterNot struct {
+	node filterNode
+}
+
+func (f filterNot) match(benchmark parse.Benchmark, config Config) bool {
+	return !f.node.match(benchmark, config)
+}
+
+// filterTerm compares a single value of a benchmark, such as its name or a metric, with either a literal or a
+// regular expression.
+type filterTerm struct {
+	key      string
+	operator string
+	literal  string
+	regex    *regexp.Regexp
+}
+
+// filterMetrics are the metrics which can be compared by filters, keyed by name.
+var filterMetrics = map[string]struct {
+	measured int
+	value    func(benchmark parse.Benchmark) float64
+}{
+	"n":             {0, func(b parse.Benchmark) float64 { return float64(b.N) }},
+	"ns_per_op":     {parse.NsPerOp, func(b parse.Benchmark) float64 { return b.NsPerOp }},
+	"bytes_per_op":  {parse.AllocedBytesPerOp, func(b parse.Benchmark) float64 { return float64(b.AllocedBytesPerOp) }},
+	"allocs_per_op": {parse.AllocsPerOp, func(b parse.Benchmark) float64 { return float64(b.AllocsPerOp) }},
+	"mb_per_s":      {parse.MBPerS, func(b parse.Benchmark) float64 { return b.MBPerS }},
+}
+
+func (f filterTerm) match(benchmark parse.Benchmark, config Config) bool {
+	value, ok := filterValue(f.key, benchmark, config)
+	if !ok {
+		return false
+	}
+
+	if f.regex != nil {
+		matched := f.regex.MatchString(value)
+		if f.operator == "!=" {
+			return !matched
+		}
+		return matched
+	}
+
+	// Values are compared as numbers where both are numeric, or otherwise as strings.
+	comparison := strings.Compare(value, f.literal)
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		if literal, err := strconv.ParseFloat(f.literal, 64); err == nil {
+			switch {
+			case number < literal:
+				comparison = -1
+			case number > literal:
+				comparison = 1
+			default:
+				comparison = 0
+			}
+		}
+	}
+
+	switch f.operator {
+	case ":", "=":
+		return comparison == 0
+	case "!=":
+		return comparison != 0
+	case "<":
+		return comparison < 0
+	case "<=":
+		return comparison <= 0
+	case ">":
+		return comparison > 0
+	case ">=":
+		return comparison >= 0
+	default:
+		return false
+	}
+}
+
+// filterValue provides the value of the key for the benchmark, and whether the benchmark has that value.  Keys are
+// the metrics, or those supported by ParseProjectionKeys - such as "name", "pkg" or the parameters of the name.
+func filterValue(key string, benchmark parse.Benchmark, config Config) (string, bool) {
+	if metric, ok := filterMetrics[key]; ok {
+		if benchmark.Measured&metric.measured != metric.measured {
+			return "", false
+		}
+		return strconv.FormatFloat(metric.value(benchmark), 'f', -1, 64), true
+	}
+	return labelValue(key, benchmark, config)
+}
+
+// ParseFilter parses a filter expression, made of terms combined with AND, OR and NOT (in that order of precedence)
+// and grouped with parentheses.  Adjacent terms are combined with AND.  Each term compares a key with a value using
+// one of ":", "=", "!=", "<", "<=", ">" or ">=" - such as "size>=1000", "algo:quick" or "ns_per_op<500".  Values
+// may be quoted, and values between slashes, such as "/^quick/", are regular expressions, which ":", "=" and "!="
+// match against.  A term which is only a regular expression matches the full benchmark name.  If the expression is
+// invalid, an ErrInvalidFilter is returned.
+func ParseFilter(expression string) (*Filter, error) {
+	p := filterParser{expression: expression}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.position < len(p.expression) {
+		return nil, p.errorf("unexpected %q", p.expression[p.position:])
+	}
+	return &Filter{root: root}, nil
+}
+
+// Match reports whether the benchmark, run with the configuration, matches the filter.
+func (f *Filter) Match(benchmark parse.Benchmark, config Config) bool {
+	return f.root.match(benchmark, config)
+}
+
+// Apply provides the benchmarks which match the filter, in their original order.  Repeated samples of a benchmark, run
+// with the same configuration, are matched using their mean, so are either all kept or all removed - while benchmarks
+// of the same name from different packages are each matched with their own configuration.
+func (f *Filter) Apply(benchmarks []parse.Benchmark, configs BenchmarkConfigs) []parse.Benchmark {
+	matched := make(map[string]bool)
+	for _, samples := range GroupSamplesByConfig(benchmarks, configs) {
+		first := samples.Benchmarks[0]
+		matched[sampleKey(first, configs)] = f.Match(samples.Mean(), configs[first.Ord])
+	}
+
+	var results []parse.Benchmark
+	for _, benchmark := range benchmarks {
+		if matched[sampleKey(benchmark, configs)] {
+			results = append(results, benchmark)
+		}
+	}
+	return results
+}
+
+// filterParser is a recursive descent parser of filter expressions.
+type filterParser struct {
+	expression string
+	position   int
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if !p.keyword("AND") {
+			// Adjacent terms are also combined with AND.
+			p.skipSpace()
+			if p.position >= len(p.expression) || p.expression[p.position] == ')' || p.peekKeyword("OR") {
+				return left, nil
+			}
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = filterAnd{left: left, right: right}
+	}
+}
+
+func (p *filterParser) parseNot() (filterNode, error) {
+	if p.keyword("NOT") {
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	p.skipSpace()
+	if p.position >= len(p.expression) {
+		return nil, p.errorf("expected a term")
+	}
+
+	if p.expression[p.position] == '(' {
+		p.position++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.position >= len(p.expression) || p.expression[p.position] != ')' {
+			return nil, p.errorf("expected ')'")
+		}
+		p.position++
+		return node, nil
+	}
+
+	if p.expression[p.position] == '/' {
+		regex, err := p.parseRegex()
+		if err != nil {
+			return nil, err
+		}
+		return filterTerm{key: "name", operator: ":", regex: regex}, nil
+	}
+
+	start := p.position
+	for p.position < len(p.expression) && isFilterKeyChar(rune(p.expression[p.position])) {
+		p.position++
+	}
+	key := p.expression[start:p.position]
+	if key == "" {
+		return nil, p.errorf("expected a key")
+	}
+
+	operator := ""
+	for _, candidate := range []string{"!=", "<=", ">=", ":", "=", "<", ">"} {
+		if strings.HasPrefix(p.expression[p.position:], candidate) {
+			operator = candidate
+			break
+		}
+	}
+	if operator == "" {
+		return nil, p.errorf("expected an operator after %q", key)
+	}
+	p.position += len(operator)
+
+	term := filterTerm{key: key, operator: operator}
+	if p.position < len(p.expression) && p.expression[p.position] == '/' {
+		if operator != ":" && operator != "=" && operator != "!=" {
+			return nil, p.errorf("regular expressions cannot be compared using %q", operator)
+		}
+		regex, err := p.parseRegex()
+		if err != nil {
+			return nil, err
+		}
+		term.regex = regex
+		return term, nil
+	}
+
+	literal, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	term.literal = literal
+	return term, nil
+}
+
+// parseRegex parses a regular expression between slashes, within which a slash is escaped by a backslash.
+func (p *filterParser) parseRegex() (*regexp.Regexp, error) {
+	start := p.position
+	p.position++
+
+	var builder strings.Builder
+	for p.position < len(p.expression) {
+		c := p.expression[p.position]
+		if c == '\\' && p.position+1 < len(p.expression) && p.expression[p.position+1] == '/' {
+			builder.WriteByte('/')
+			p.position += 2
+			continue
+		}
+		if c == '/' {
+			p.position++
+			regex, err := regexp.Compile(builder.String())
+			if err != nil {
+				p.position = start
+				return nil, p.errorf("invalid regular expression - %v", err)
+			}
+			return regex, nil
+		}
+		builder.WriteByte(c)
+		p.position++
+	}
+
+	p.position = start
+	return nil, p.errorf("unterminated regular expression")
+}
+
+// parseLiteral parses a value, which is either quoted or runs until a space or closing parenthesis.
+func (p *filterParser) parseLiteral() (string, error) {
+	start := p.position
+	if p.position < len(p.expression) && p.expression[p.position] == '"' {
+		for p.position++; p.position < len(p.expression); p.position++ {
+			switch p.expression[p.position] {
+			case '\\':
+				p.position++
+			case '"':
+				p.position++
+				value, err := strconv.Unquote(p.expression[start:p.position])
+				if err != nil {
+					p.position = start
+					return "", p.errorf("invalid quoted value")
+				}
+				return value, nil
+			}
+		}
+		p.position = start
+		return "", p.errorf("unterminated quoted value")
+	}
+
+	for p.position < len(p.expression) && !unicode.IsSpace(rune(p.expression[p.position])) && p.expression[p.position] != ')' {
+		p.position++
+	}
+	if p.position == start {
+		return "", p.errorf("expected a value")
+	}
+	return p.expression[start:p.position], nil
+}
+
+// keyword consumes the keyword (matched regardless of case) if it comes next, reporting whether it did.
+func (p *filterParser) keyword(word string) bool {
+	if !p.peekKeyword(word) {
+		return false
+	}
+	p.position += len(word)
+	return true
+}
+
+// peekKeyword reports whether the keyword (matched regardless of case) comes next, as a whole word.
+func (p *filterParser) peekKeyword(word string) bool {
+	p.skipSpace()
+	end := p.position + len(word)
+	if end > len(p.expression) || !strings.EqualFold(p.expression[p.position:end], word) {
+		return false
+	}
+	return end == len(p.expression) || unicode.IsSpace(rune(p.expression[end])) || p.expression[end] == '('
+}
+
+func (p *filterParser) skipSpace() {
+	for p.position < len(p.expression) && unicode.IsSpace(rune(p.expression[p.position])) {
+		p.position++
+	}
+}
+
+func (p *filterParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("filter %q invalid at position %d, %s: %w", p.expression, p.position, fmt.Sprintf(format, args...), ErrInvalidFilter)
+}
+
+// isFilterKeyChar reports whether the character may be part of a key.
+func isFilterKeyChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
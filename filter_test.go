@@ -0,0 +1,152 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"testing"
+)
+
+func TestFilter_Match(t *testing.T) {
+	benchmark := parse.Benchmark{
+		Name:              "BenchmarkSort/algo=quick/size=1024-8",
+		N:                 1000,
+		NsPerOp:           250,
+		AllocedBytesPerOp: 64,
+		AllocsPerOp:       2,
+		Measured:          parse.NsPerOp | parse.AllocedBytesPerOp | parse.AllocsPerOp,
+	}
+	config := Config{"pkg": "example.com/sort", "goos": "linux"}
+
+	tests := []struct {
+		name       string
+		expression string
+		want       bool
+	}{
+		{name: "name regex", expression: "/^BenchmarkSort/", want: true},
+		{name: "name regex not matching", expression: "/^BenchmarkSearch/", want: false},
+		{name: "name key regex", expression: "name:/quick/", want: true},
+		{name: "name equality", expression: `name="BenchmarkSort/algo=quick/size=1024-8"`, want: true},
+		{name: "benchmark", expression: "benchmark:BenchmarkSort", want: true},
+		{name: "sub", expression: "sub:algo=quick/size=1024", want: true},
+		{name: "label match", expression: "algo:quick", want: true},
+		{name: "label mismatch", expression: "algo:radix", want: false},
+		{name: "label numeric comparison", expression: "size>=1000", want: true},
+		{name: "label numeric comparison failing", expression: "size>1024", want: false},
+		{name: "label numeric equality", expression: "size=1024.0", want: true},
+		{name: "label not equal", expression: "algo!=radix", want: true},
+		{name: "label regex not equal", expression: "algo!=/^q/", want: false},
+		{name: "missing label", expression: "workers>0", want: false},
+		{name: "negated missing label", expression: "NOT workers>0", want: true},
+		{name: "package", expression: "pkg:example.com/sort", want: true},
+		{name: "quoted package", expression: `pkg:"example.com/sort"`, want: true},
+		{name: "package regex", expression: `pkg:/\/sort$/`, want: true},
+		{name: "procs", expression: "procs=8", want: true},
+		{name: "metric threshold", expression: "ns_per_op<500", want: true},
+		{name: "metric threshold failing", expression: "allocs_per_op<=1", want: false},
+		{name: "iterations", expression: "n>=1000", want: true},
+		{name: "unmeasured metric", expression: "mb_per_s>=0", want: false},
+		{name: "and", expression: "algo:quick AND size>=1000", want: true},
+		{name: "and failing", expression: "algo:quick AND size<1000", want: false},
+		{name: "implicit and", expression: "algo:quick size<1000", want: false},
+		{name: "or", expression: "algo:radix OR size>=1000", want: true},
+		{name: "lower case keywords", expression: "algo:radix or not size<1000", want: true},
+		{name: "and binds tighter than or", expression: "algo:quick OR algo:radix AND size<1000", want: true},
+		{name: "parentheses", expression: "(algo:quick OR algo:radix) AND size<1000", want: false},
+		{name: "not parentheses", expression: "NOT (algo:radix OR goos:darwin)", want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := ParseFilter(test.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got := filter.Match(benchmark, config)
+			if test.want != got {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "empty", expression: ""},
+		{name: "missing operator", expression: "size"},
+		{name: "missing value", expression: "size>="},
+		{name: "unbalanced parenthesis", expression: "(size>=1"},
+		{name: "unexpected parenthesis", expression: "size>=1)"},
+		{name: "dangling operator", expression: "size>=1 AND"},
+		{name: "unterminated regex", expression: "/Benchmark"},
+		{name: "invalid regex", expression: "/Bench(/"},
+		{name: "ordered regex", expression: "algo</quick/"},
+		{name: "unterminated quote", expression: `pkg:"example.com`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseFilter(test.expression)
+			if !errors.Is(err, ErrInvalidFilter) {
+				t.Errorf("Wanted error '%v', got error '%v'", ErrInvalidFilter, err)
+			}
+		})
+	}
+}
+
+func TestFilter_Apply(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/quick-8", NsPerOp: 100, Measured: parse.NsPerOp, Ord: 0},
+		{Name: "BenchmarkSearch-8", NsPerOp: 100, Measured: parse.NsPerOp, Ord: 1},
+		{Name: "BenchmarkSort/radix-8", NsPerOp: 400, Measured: parse.NsPerOp, Ord: 2},
+		{Name: "BenchmarkSort/quick-8", NsPerOp: 300, Measured: parse.NsPerOp, Ord: 3},
+		{Name: "BenchmarkSort/radix-8", NsPerOp: 400, Measured: parse.NsPerOp, Ord: 4},
+	}
+	sort := Config{"pkg": "example.com/sort"}
+	configs := BenchmarkConfigs{0: sort, 1: {"pkg": "example.com/search"}, 2: sort, 3: sort, 4: sort}
+
+	// The samples of the quick sort have a mean of 200ns, so are both kept.
+	filter, err := ParseFilter("pkg:example.com/sort ns_per_op<=200")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := filter.Apply(benchmarks, configs)
+	want := []parse.Benchmark{benchmarks[0], benchmarks[3]}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestFilter_ApplyPackages(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkParse-8", NsPerOp: 100, Measured: parse.NsPerOp, Ord: 0},
+		{Name: "BenchmarkParse-8", NsPerOp: 900, Measured: parse.NsPerOp, Ord: 1},
+		{Name: "BenchmarkParse-8", NsPerOp: 200, Measured: parse.NsPerOp, Ord: 2},
+	}
+	configs := BenchmarkConfigs{0: {"pkg": "example.com/a"}, 1: {"pkg": "example.com/b"}, 2: {"pkg": "example.com/a"}}
+
+	tests := []struct {
+		filter string
+		want   []parse.Benchmark
+	}{
+		{filter: "pkg:example.com/a", want: []parse.Benchmark{benchmarks[0], benchmarks[2]}},
+		{filter: "pkg:example.com/b", want: []parse.Benchmark{benchmarks[1]}},
+		// Each package's samples are matched using their own mean - 150ns and 900ns.
+		{filter: "ns_per_op<500", want: []parse.Benchmark{benchmarks[0], benchmarks[2]}},
+	}
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			filter, err := ParseFilter(test.filter)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			got := filter.Apply(benchmarks, configs)
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// figureBar is a single bar of a figure exported for typesetting, with its value scaled to the figure's unit.
+type figureBar struct {
+	label string
+	value float64
+	err   float64
+}
+
+// newFigureBars provides a bar for each benchmark, labelled and scaled as on the RasterRenderer's bar chart, along
+// with the title of the value axis.  If errorBars is not NoErrorBars, repeated samples are combined into a single bar.
+func newFigureBars(benchmarks []parse.Benchmark, dimension Dimension, errorBars ErrorBars) ([]figureBar, string, error) {
+	bars := benchmarks
+	var errs []float64
+	if errorBars != NoErrorBars {
+		var err error
+		bars, errs, err = summariseSamples(benchmarks, nil, dimension, errorBars)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	values := make([]float64, 0, len(bars))
+	var max float64
+	for _, benchmark := range bars {
+		value, err := dimension.Value(benchmark)
+		if err != nil {
+			return nil, "", err
+		}
+		values = append(values, value)
+		max = math.Max(max, math.Abs(value))
+	}
+
+	// Scale the values to a unit suited to the largest - for instance, milliseconds rather than nanoseconds.
+	u := unitFor(dimension, max)
+	results := make([]figureBar, 0, len(bars))
+	for index, benchmark := range bars {
+		bar := figureBar{
+			label: subBenchmarkLabel(benchmark.Name),
+			value: values[index] / u.Scale,
+		}
+		if errs != nil {
+			bar.err = errs[index] / u.Scale
+		}
+		results = append(results, bar)
+	}
+	return results, axisTitle(dimension, u), nil
+}
+
+// formatFigureNumber formats a value of a figure's data to ten significant figures, hiding any floating point error
+// introduced by scaling it.
+func formatFigureNumber(value float64) string {
+	return strconv.FormatFloat(value, 'g', 10, 64)
+}
+
+// GnuplotRenderer outputs a Gnuplot script drawing a bar chart of the benchmarks, matching the RasterRenderer's.
+type GnuplotRenderer struct {
+	Title string
+	// LogScale renders the value axis on a logarithmic scale.
+	LogScale bool
+	// ErrorBars combines repeated samples of each benchmark into a single bar, showing their variance.
+	ErrorBars ErrorBars
+	// DataWriter receives the data plotted by the script, which reads it from DataFilename.  If nil, the data is
+	// inlined within the script instead.
+	DataWriter io.Writer
+	// DataFilename is written into the script as given.  Gnuplot resolves a relative path against its working
+	// directory rather than the script's, so an absolute path lets the script be run from anywhere.
+	DataFilename string
+}
+
+func (g *GnuplotRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
+	if len(benchmarks) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	title := g.Title
+	if title == "" {
+		title = parentBenchmark
+	}
+
+	bars, axisTitle, err := newFigureBars(benchmarks, dimension, g.ErrorBars)
+	if err != nil {
+		return err
+	}
+
+	data := bytes.Buffer{}
+	if g.ErrorBars != NoErrorBars {
+		data.WriteString("# label value error\n")
+	} else {
+		data.WriteString("# label value\n")
+	}
+	for _, bar := range bars {
+		fmt.Fprintf(&data, "%s %s", gnuplotString(bar.label), formatFigureNumber(bar.value))
+		if g.ErrorBars != NoErrorBars {
+			fmt.Fprintf(&data, " %s", formatFigureNumber(bar.err))
+		}
+		data.WriteString("\n")
+	}
+
+	source := "$data"
+	script := bytes.Buffer{}
+	script.WriteString("# Bar chart of benchmarks generated by gobenchpress - set a terminal and output before plotting.\n")
+	if g.DataWriter != nil {
+		_, err = g.DataWriter.Write(data.Bytes())
+		if err != nil {
+			return err
+		}
+		source = gnuplotString(g.DataFilename)
+	} else {
+		script.WriteString("$data << EOD\n")
+		script.Write(data.Bytes())
+		script.WriteString("EOD\n")
+	}
+
+	fmt.Fprintf(&script, "set title %s noenhanced\n", gnuplotString(title))
+	fmt.Fprintf(&script, "set ylabel %s noenhanced\n", gnuplotString(axisTitle))
+	script.WriteString("set style fill solid 0.8 border -1\n")
+	script.WriteString("set boxwidth 0.8\n")
+	script.WriteString("set grid ytics\n")
+	script.WriteString("set key off\n")
+	script.WriteString("set xtics noenhanced rotate by -45 nomirror\n")
+	if g.LogScale {
+		script.WriteString("set logscale y\n")
+	} else {
+		script.WriteString("set yrange [0:*]\n")
+	}
+
+	if g.ErrorBars != NoErrorBars {
+		script.WriteString("set style histogram errorbars gap 1 lw 1\n")
+		script.WriteString("set style data histograms\n")
+		fmt.Fprintf(&script, "plot %s using 2:3:xtic(1)\n", source)
+	} else {
+		script.WriteString("set style histogram clustered gap 1\n")
+		script.WriteString("set style data histograms\n")
+		fmt.Fprintf(&script, "plot %s using 2:xtic(1)\n", source)
+	}
+
+	_, err = writer.Write(script.Bytes())
+	return err
+}
+
+// gnuplotString quotes the string for use within a Gnuplot script or data file.
+func gnuplotString(str string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(str) + `"`
+}
@@ -0,0 +1,190 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewFigureBars(t *testing.T) {
+	tests := []struct {
+		name          string
+		benchmarks    []parse.Benchmark
+		errorBars     ErrorBars
+		want          []figureBar
+		wantAxisTitle string
+	}{
+		{
+			name: "scaled to largest value",
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/Small", NsPerOp: 500},
+				{Name: "BenchmarkOne/Large", NsPerOp: 2500},
+			},
+			errorBars: NoErrorBars,
+			want: []figureBar{
+				{label: "Small", value: 0.5},
+				{label: "Large", value: 2.5},
+			},
+			wantAxisTitle: "Time per op (µs)",
+		},
+		{
+			name: "samples combined",
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/Sub", NsPerOp: 100},
+				{Name: "BenchmarkOne/Sub", NsPerOp: 300},
+			},
+			errorBars: StdDevErrorBars,
+			want: []figureBar{
+				{label: "Sub", value: 200, err: 141.4213562373095},
+			},
+			wantAxisTitle: "Time per op (ns)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotAxisTitle, err := newFigureBars(test.benchmarks, RenderNsPerOp, test.errorBars)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("Wanted bars %v, got %v", test.want, got)
+			}
+			if test.wantAxisTitle != gotAxisTitle {
+				t.Errorf("Wanted axis title %q, got %q", test.wantAxisTitle, gotAxisTitle)
+			}
+		})
+	}
+}
+
+func TestGnuplotRenderer_Render(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Small_Input", NsPerOp: 500},
+		{Name: "BenchmarkOne/Large_Input", NsPerOp: 2500},
+	}
+
+	tests := []struct {
+		name     string
+		renderer GnuplotRenderer
+		// wantLines are lines wanted within the script.
+		wantLines []string
+	}{
+		{
+			name:     "inline data",
+			renderer: GnuplotRenderer{},
+			wantLines: []string{
+				`$data << EOD`,
+				`"Small_Input" 0.5`,
+				`"Large_Input" 2.5`,
+				`EOD`,
+				`set title "BenchmarkOne" noenhanced`,
+				`set ylabel "Time per op (µs)" noenhanced`,
+				`set yrange [0:*]`,
+				`plot $data using 2:xtic(1)`,
+			},
+		},
+		{
+			name:     "title and log scale",
+			renderer: GnuplotRenderer{Title: `The "Title"`, LogScale: true},
+			wantLines: []string{
+				`set title "The \"Title\"" noenhanced`,
+				`set logscale y`,
+			},
+		},
+		{
+			name:     "error bars",
+			renderer: GnuplotRenderer{ErrorBars: StdDevErrorBars},
+			wantLines: []string{
+				`"Small_Input" 0.5 0`,
+				`set style histogram errorbars gap 1 lw 1`,
+				`plot $data using 2:3:xtic(1)`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := test.renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+			if err != nil {
+				t.Fatalf("Error rendering Gnuplot: %v", err)
+			}
+
+			lines := strings.Split(output.String(), "\n")
+			for _, want := range test.wantLines {
+				if !containsLine(lines, want) {
+					t.Errorf("Wanted line %q within script %q", want, output.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGnuplotRenderer_RenderDataFile(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Sub", NsPerOp: 100},
+	}
+
+	var data bytes.Buffer
+	renderer := GnuplotRenderer{DataWriter: &data, DataFilename: "output.dat"}
+
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering Gnuplot: %v", err)
+	}
+
+	wantData := "# label value\n\"Sub\" 100\n"
+	if wantData != data.String() {
+		t.Errorf("Wanted data %q, got %q", wantData, data.String())
+	}
+	if strings.Contains(output.String(), "EOD") {
+		t.Error("Wanted data to not be inlined within the script")
+	}
+	if !containsLine(strings.Split(output.String(), "\n"), `plot "output.dat" using 2:xtic(1)`) {
+		t.Errorf("Wanted script to plot the data file, got %q", output.String())
+	}
+}
+
+func TestGnuplotRenderer_RenderErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		dimension  RenderDimension
+		benchmarks []parse.Benchmark
+		wantErr    error
+	}{
+		{
+			name:       "no benchmarks",
+			dimension:  RenderNsPerOp,
+			benchmarks: nil,
+			wantErr:    ErrNoBenchmarksProvided,
+		},
+		{
+			name:       "unknown dimension",
+			dimension:  RenderDimension(1000),
+			benchmarks: []parse.Benchmark{{Name: "BenchmarkOne/Sub", NsPerOp: 100}},
+			wantErr:    ErrUnknownDimensionType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			renderer := GnuplotRenderer{}
+			var output bytes.Buffer
+			err := renderer.Render(&output, "BenchmarkOne", test.dimension, test.benchmarks)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Wanted error '%v', got error '%v'", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// containsLine reports whether the line is one of the lines.
+func containsLine(lines []string, line string) bool {
+	for _, l := range lines {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
@@ -2,10 +2,14 @@ module github.com/rpickz/go-benchpress
 
 go 1.24
 
+require (
+	github.com/wcharczuk/go-chart v2.0.1+incompatible
+	golang.org/x/tools v0.1.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/blend/go-sdk v1.20210309.4 // indirect
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
-	github.com/wcharczuk/go-chart v2.0.1+incompatible
 	golang.org/x/image v0.0.0-20210220032944-ac19c3e999fb // indirect
-	golang.org/x/tools v0.1.0
 )
@@ -1,20 +1,20 @@
 package go_benchpress
 
 import (
-	"fmt"
 	"github.com/wcharczuk/go-chart"
 	"golang.org/x/tools/benchmark/parse"
+	"math"
 	"strings"
 )
 
-type barChartBenchmarkRenderer func(title string, height, barWidth int, dimension RenderDimension, benchmarks []parse.Benchmark) (*chart.BarChart, error)
+type barChartBenchmarkRenderer func(title string, height, barWidth int, dimension Dimension, benchmarks []parse.Benchmark) (*chart.BarChart, error)
 
-type barChartRenderer func(title string, height, barWidth int, dimension RenderDimension, values []chart.Value) *chart.BarChart
+type barChartRenderer func(title string, height, barWidth int, dimension Dimension, values []chart.Value) *chart.BarChart
 
 // Defined for testing purposes - to isolate testing of the renderer and the construction of go-chart Bar charts.
 var _renderBarChart barChartRenderer = renderBarChart
 
-func renderGraphicalBarChart(title string, height int, barWidth int, dimension RenderDimension, benchmarks []parse.Benchmark) (*chart.BarChart, error) {
+func renderGraphicalBarChart(title string, height int, barWidth int, dimension Dimension, benchmarks []parse.Benchmark) (*chart.BarChart, error) {
 
 	if len(benchmarks) == 0 {
 		return nil, ErrNoBenchmarksProvided
@@ -23,25 +23,11 @@ func renderGraphicalBarChart(title string, height int, barWidth int, dimension R
 	values := make([]chart.Value, 0)
 
 	for _, benchmark := range benchmarks {
-		name := benchmark.Name
+		name := subBenchmarkLabel(benchmark.Name)
 
-		parts := strings.Split(name, "/")
-
-		name = parts[0]
-		if len(parts) > 1 {
-			name = strings.Join(parts[1:], "/")
-		}
-
-		var value float64
-		switch dimension {
-		case RenderNsPerOp:
-			value = benchmark.NsPerOp
-		case RenderBytesPerOp:
-			value = float64(benchmark.AllocedBytesPerOp)
-		case RenderAllocsPerOp:
-			value = float64(benchmark.AllocsPerOp)
-		default:
-			return nil, fmt.Errorf("render dimension type %q not supported: %w", dimension, ErrUnknownDimensionType)
+		value, err := dimension.Value(benchmark)
+		if err != nil {
+			return nil, err
 		}
 
 		values = append(values, chart.Value{
@@ -56,14 +42,32 @@ func renderGraphicalBarChart(title string, height int, barWidth int, dimension R
 	return graph, nil
 }
 
-func renderBarChart(title string, height, barWidth int, dimension RenderDimension, values []chart.Value) *chart.BarChart {
+// subBenchmarkLabel provides the label for a benchmark - the sub-benchmark part of its name, or the whole name
+// where there is no sub-benchmark.
+func subBenchmarkLabel(name string) string {
+	parts := strings.Split(name, "/")
+	if len(parts) > 1 {
+		return strings.Join(parts[1:], "/")
+	}
+	return parts[0]
+}
+
+func renderBarChart(title string, height, barWidth int, dimension Dimension, values []chart.Value) *chart.BarChart {
+	// Scale the axis to a unit suited to the largest value - for instance, milliseconds rather than nanoseconds.
+	var max float64
+	for _, value := range values {
+		max = math.Max(max, math.Abs(value.Value))
+	}
+	axisUnit := unitFor(dimension, max)
+
 	return &chart.BarChart{
 		Title:      title,
 		TitleStyle: chart.StyleShow(),
 		XAxis: chart.StyleShow(),
 		YAxis: chart.YAxis{
-			Name: dimension.String(),
+			Name: axisTitle(dimension, axisUnit),
 			Style: chart.StyleShow(),
+			ValueFormatter: axisFormatter(axisUnit),
 		},
 		Background: chart.Style{
 			Padding: chart.Box{
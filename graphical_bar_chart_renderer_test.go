@@ -487,12 +487,12 @@ type fakeBarChartRenderer struct {
 	values           []chart.Value
 }
 
-func (f *fakeBarChartRenderer) renderBarChart(title string, height, barWidth int, dimension RenderDimension, values []chart.Value) *chart.BarChart {
+func (f *fakeBarChartRenderer) renderBarChart(title string, height, barWidth int, dimension Dimension, values []chart.Value) *chart.BarChart {
 	f.called = true
 	f.title = title
 	f.height = height
 	f.barWidth = barWidth
-	f.dimension = dimension
+	f.dimension, _ = dimension.(RenderDimension)
 	f.values = values
 	return f.replyWith
 }
@@ -555,7 +555,7 @@ func TestRenderBarChart(t *testing.T) {
 					Show: true,
 				},
 				YAxis: chart.YAxis{
-					Name: RenderNsPerOp.String(),
+					Name: "Time per op (ns)",
 					Style: chart.Style{
 						Show: true,
 					},
@@ -584,7 +584,7 @@ func TestRenderBarChart(t *testing.T) {
 					Show: true,
 				},
 				YAxis: chart.YAxis{
-					Name: RenderBytesPerOp.String(),
+					Name: "Bytes per op (B)",
 					Style: chart.Style{
 						Show: true,
 					},
@@ -613,7 +613,7 @@ func TestRenderBarChart(t *testing.T) {
 					Show: true,
 				},
 				YAxis: chart.YAxis{
-					Name: RenderAllocsPerOp.String(),
+					Name: "Allocations per op",
 					Style: chart.Style{
 						Show: true,
 					},
@@ -632,6 +632,13 @@ func TestRenderBarChart(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			got := renderBarChart(test.title, test.height, test.barWidth, test.dimension, test.values)
+
+			// Functions cannot be compared, so the formatter is checked separately.
+			if got.YAxis.ValueFormatter == nil {
+				t.Fatal("Want Y axis value formatter, got nil")
+			}
+			got.YAxis.ValueFormatter = nil
+
 			if !reflect.DeepEqual(test.want, got) {
 				t.Errorf("got %v, want %v", test.want, got)
 			}
@@ -639,6 +646,51 @@ func TestRenderBarChart(t *testing.T) {
 	}
 }
 
+func TestRenderBarChart_AxisUnits(t *testing.T) {
+	tests := []struct {
+		name      string
+		dimension RenderDimension
+		values    []chart.Value
+		wantName  string
+		wantTick  string
+	}{
+		{
+			name:      "microseconds",
+			dimension: RenderNsPerOp,
+			values:    []chart.Value{{Value: 1500}, {Value: 95948}},
+			wantName:  "Time per op (µs)",
+			wantTick:  "1.5",
+		},
+		{
+			name:      "mebibytes",
+			dimension: RenderBytesPerOp,
+			values:    []chart.Value{{Value: 1500}, {Value: 3 << 20}},
+			wantName:  "Bytes per op (MiB)",
+			wantTick:  "0",
+		},
+		{
+			name:      "thousands of allocations",
+			dimension: RenderAllocsPerOp,
+			values:    []chart.Value{{Value: 1500}, {Value: 2000}},
+			wantName:  "Allocations per op (k)",
+			wantTick:  "1.5",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := renderBarChart("Title", 512, 60, test.dimension, test.values)
+			if test.wantName != got.YAxis.Name {
+				t.Errorf("Want axis name %q, got %q", test.wantName, got.YAxis.Name)
+			}
+
+			gotTick := got.YAxis.ValueFormatter(test.values[0].Value)
+			if test.wantTick != gotTick {
+				t.Errorf("Want tick %q, got %q", test.wantTick, gotTick)
+			}
+		})
+	}
+}
+
 var resultBarChart *chart.BarChart
 
 func BenchmarkRenderBarChart(b *testing.B) {
@@ -0,0 +1,157 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"sort"
+)
+
+// GridRenderer outputs every set of benchmarks as a panel of a single image, arranged in a grid - an alternative
+// to rendering each set to its own file.
+type GridRenderer struct {
+	// Columns is the number of panels in each row of the grid.
+	Columns int
+	// Panel renders each panel of the grid - its styling and chart type are shared by every panel.
+	Panel *RasterRenderer
+	// Order is the order of the panels, by the names of their sets - see Sorter.SortSets.  If empty, panels are in
+	// order of their names.  Sets not within the order are omitted.
+	Order []string
+}
+
+// NewGridRenderer creates a GridRenderer, whose panels are half the default chart size.
+func NewGridRenderer(renderType RenderType) *GridRenderer {
+	panel := NewRasterRenderer("", renderType)
+	panel.Width = 512
+	panel.Height = 320
+	return &GridRenderer{
+		Columns: 3,
+		Panel:   panel,
+	}
+}
+
+// gridPanel is a rendered panel, with its size.
+type gridPanel struct {
+	data          []byte
+	width, height int
+}
+
+// RenderSets renders each set of benchmarks as a panel of the grid, in the Order of the grid, or otherwise in order of
+// the parent benchmark names.  If there are no sets, an ErrNoBenchmarksProvided is returned.  Only the PNG and SVG
+// render types are supported - any other returns an ErrUnknownRenderType.
+func (g *GridRenderer) RenderSets(writer io.Writer, dimension Dimension, sets BenchmarkSets) error {
+	if len(sets) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+	if g.Panel.RenderType != PNG && g.Panel.RenderType != SVG {
+		return fmt.Errorf("render type %q not supported for grids: %w", g.Panel.RenderType, ErrUnknownRenderType)
+	}
+
+	names := g.names(sets)
+	if len(names) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	// Panels may differ in size (for instance, horizontal bar charts grow with their bars), so each cell of the
+	// grid is the size of the largest panel.
+	panels := make([]gridPanel, 0, len(names))
+	var cellWidth, cellHeight int
+	for _, name := range names {
+		var panel gridPanel
+		panelRenderer := *g.Panel
+		panelRenderer.onCanvas = func(width, height int) {
+			panel.width, panel.height = width, height
+		}
+
+		buf := bytes.Buffer{}
+		err := panelRenderer.Render(&buf, name, dimension, sets[name])
+		if err != nil {
+			return fmt.Errorf("could not render panel %q: %w", name, err)
+		}
+		panel.data = buf.Bytes()
+		if panel.width > cellWidth {
+			cellWidth = panel.width
+		}
+		if panel.height > cellHeight {
+			cellHeight = panel.height
+		}
+		panels = append(panels, panel)
+	}
+
+	columns := g.Columns
+	if columns < 1 || columns > len(panels) {
+		columns = len(panels)
+	}
+	rows := (len(panels) + columns - 1) / columns
+	width, height := columns*cellWidth, rows*cellHeight
+
+	if g.Panel.RenderType == SVG {
+		return g.writeSVG(writer, panels, columns, cellWidth, cellHeight, width, height)
+	}
+	return g.writePNG(writer, panels, columns, cellWidth, cellHeight, width, height)
+}
+
+// writeSVG writes the panels as nested SVG elements, each positioned within its cell.
+func (g *GridRenderer) writeSVG(writer io.Writer, panels []gridPanel, columns, cellWidth, cellHeight, width, height int) error {
+	background, err := parseColour(g.Panel.Theme.withDefaults(LightTheme).Background)
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d">`+"\n", width, height)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" style="fill:%s"/>`+"\n", width, height, background.String())
+	for index, panel := range panels {
+		x := (index % columns) * cellWidth
+		y := (index / columns) * cellHeight
+		buf.Write(bytes.Replace(panel.data, []byte("<svg "), []byte(fmt.Sprintf(`<svg x="%d" y="%d" `, x, y)), 1))
+		buf.WriteString("\n")
+	}
+	buf.WriteString("</svg>")
+
+	_, err = writer.Write(buf.Bytes())
+	return err
+}
+
+// writePNG draws the panels onto a single image, each positioned within its cell.
+func (g *GridRenderer) writePNG(writer io.Writer, panels []gridPanel, columns, cellWidth, cellHeight, width, height int) error {
+	background, err := parseColour(g.Panel.Theme.withDefaults(LightTheme).Background)
+	if err != nil {
+		return err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	for index, panel := range panels {
+		decoded, err := png.Decode(bytes.NewReader(panel.data))
+		if err != nil {
+			return err
+		}
+		origin := image.Pt((index%columns)*cellWidth, (index/columns)*cellHeight)
+		draw.Draw(img, decoded.Bounds().Add(origin), decoded, decoded.Bounds().Min, draw.Over)
+	}
+
+	return png.Encode(writer, img)
+}
+
+// names provides the names of the sets, in the order their panels are drawn.
+func (g *GridRenderer) names(sets BenchmarkSets) []string {
+	names := make([]string, 0, len(sets))
+	if len(g.Order) > 0 {
+		for _, name := range g.Order {
+			if _, ok := sets[name]; ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
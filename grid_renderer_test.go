@@ -0,0 +1,183 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestGridRenderer_RenderSets(t *testing.T) {
+	sets := BenchmarkSets{
+		"BenchmarkOne":   {{Name: "BenchmarkOne/A", NsPerOp: 10}, {Name: "BenchmarkOne/B", NsPerOp: 30}},
+		"BenchmarkTwo":   {{Name: "BenchmarkTwo/A", NsPerOp: 20}, {Name: "BenchmarkTwo/B", NsPerOp: 40}},
+		"BenchmarkThree": {{Name: "BenchmarkThree/A", NsPerOp: 5}, {Name: "BenchmarkThree/B", NsPerOp: 50}},
+	}
+
+	tests := []struct {
+		name       string
+		columns    int
+		wantWidth  int
+		wantHeight int
+	}{
+		{name: "single row", columns: 3, wantWidth: 1536, wantHeight: 320},
+		{name: "two columns", columns: 2, wantWidth: 1024, wantHeight: 640},
+		{name: "single column", columns: 1, wantWidth: 512, wantHeight: 960},
+		{name: "more columns than panels", columns: 10, wantWidth: 1536, wantHeight: 320},
+		{name: "no columns", columns: 0, wantWidth: 1536, wantHeight: 320},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gridRenderer := NewGridRenderer(PNG)
+			gridRenderer.Columns = test.columns
+
+			buf := bytes.Buffer{}
+			err := gridRenderer.RenderSets(&buf, RenderNsPerOp, sets)
+			if err != nil {
+				t.Fatalf("Could not render grid - error: %v", err)
+			}
+
+			img, err := png.Decode(&buf)
+			if err != nil {
+				t.Fatalf("Could not decode PNG file - error: %v", err)
+			}
+			if test.wantWidth != img.Bounds().Dx() || test.wantHeight != img.Bounds().Dy() {
+				t.Errorf("Want size %dx%d, got size %dx%d", test.wantWidth, test.wantHeight, img.Bounds().Dx(), img.Bounds().Dy())
+			}
+		})
+	}
+}
+
+func TestGridRenderer_RenderSetsSVG(t *testing.T) {
+	sets := BenchmarkSets{
+		"BenchmarkOne": {{Name: "BenchmarkOne/A", NsPerOp: 10}, {Name: "BenchmarkOne/B", NsPerOp: 30}},
+		"BenchmarkTwo": {{Name: "BenchmarkTwo/A", NsPerOp: 20}, {Name: "BenchmarkTwo/B", NsPerOp: 40}},
+	}
+
+	gridRenderer := NewGridRenderer(SVG)
+	gridRenderer.Columns = 1
+
+	buf := bytes.Buffer{}
+	err := gridRenderer.RenderSets(&buf, RenderNsPerOp, sets)
+	if err != nil {
+		t.Fatalf("Could not render grid - error: %v", err)
+	}
+
+	xmlData := make([]interface{}, 0)
+	err = xml.Unmarshal(buf.Bytes(), &xmlData)
+	if err != nil {
+		t.Errorf("Error unmarshalling SVG as XML - error: %v", err)
+	}
+
+	content := buf.String()
+	for _, want := range []string{`width="512" height="640"`, `<svg x="0" y="0" `, `<svg x="0" y="320" `, "BenchmarkOne", "BenchmarkTwo"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Wanted SVG output to contain %q", want)
+		}
+	}
+	if strings.Index(content, "BenchmarkOne") > strings.Index(content, "BenchmarkTwo") {
+		t.Error("Wanted panels in order of benchmark name")
+	}
+}
+
+func TestGridRenderer_RenderSetsPanelSizes(t *testing.T) {
+	var many []parse.Benchmark
+	for index := 0; index < 12; index++ {
+		many = append(many, parse.Benchmark{Name: fmt.Sprintf("BenchmarkMany/%d", index), NsPerOp: float64(index + 1)})
+	}
+	sets := BenchmarkSets{
+		"BenchmarkFew":  {{Name: "BenchmarkFew/A", NsPerOp: 10}},
+		"BenchmarkMany": many,
+	}
+
+	gridRenderer := NewGridRenderer(SVG)
+	gridRenderer.Columns = 2
+	gridRenderer.Panel.Orientation = HorizontalOrientation
+
+	buf := bytes.Buffer{}
+	err := gridRenderer.RenderSets(&buf, RenderNsPerOp, sets)
+	if err != nil {
+		t.Fatalf("Could not render grid - error: %v", err)
+	}
+
+	// Horizontal bar charts grow with their bars, so the cells take the size of the tallest panel.
+	wantHeight := horizontalTitleHeight + horizontalAxisHeight + len(many)*minHorizontalSlotHeight
+	want := fmt.Sprintf(`width="1024" height="%d"`, wantHeight)
+	if !strings.HasPrefix(buf.String(), fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" %s>`, want)) {
+		t.Errorf("Wanted grid of size %q, got %q", want, buf.String()[:120])
+	}
+}
+
+func TestGridRenderer_RenderSetsOrder(t *testing.T) {
+	sets := BenchmarkSets{
+		"BenchmarkOne":   {{Name: "BenchmarkOne/A", NsPerOp: 10}, {Name: "BenchmarkOne/B", NsPerOp: 30}},
+		"BenchmarkTwo":   {{Name: "BenchmarkTwo/A", NsPerOp: 20}, {Name: "BenchmarkTwo/B", NsPerOp: 40}},
+		"BenchmarkThree": {{Name: "BenchmarkThree/A", NsPerOp: 5}, {Name: "BenchmarkThree/B", NsPerOp: 50}},
+	}
+
+	gridRenderer := NewGridRenderer(SVG)
+	gridRenderer.Columns = 1
+	gridRenderer.Order = []string{"BenchmarkTwo", "BenchmarkOne"}
+
+	buf := bytes.Buffer{}
+	err := gridRenderer.RenderSets(&buf, RenderNsPerOp, sets)
+	if err != nil {
+		t.Fatalf("Could not render grid - error: %v", err)
+	}
+
+	content := buf.String()
+	if !strings.Contains(content, `width="512" height="640"`) {
+		t.Error("Wanted a panel for each set within the order")
+	}
+	if strings.Contains(content, "BenchmarkThree") {
+		t.Error("Wanted sets not within the order to be omitted")
+	}
+	if strings.Index(content, "BenchmarkTwo") > strings.Index(content, "BenchmarkOne") {
+		t.Error("Wanted panels in the order provided")
+	}
+}
+
+func TestGridRenderer_RenderSetsErrors(t *testing.T) {
+	sets := BenchmarkSets{
+		"BenchmarkOne": {{Name: "BenchmarkOne/A", NsPerOp: 10}, {Name: "BenchmarkOne/B", NsPerOp: 30}},
+	}
+
+	tests := []struct {
+		name       string
+		renderType RenderType
+		sets       BenchmarkSets
+		dimension  RenderDimension
+		wantErr    error
+	}{
+		{
+			name:       "no sets",
+			renderType: PNG,
+			wantErr:    ErrNoBenchmarksProvided,
+		},
+		{
+			name:       "unsupported render type",
+			renderType: JSON,
+			sets:       sets,
+			wantErr:    ErrUnknownRenderType,
+		},
+		{
+			name:       "panel error",
+			renderType: SVG,
+			sets:       BenchmarkSets{"BenchmarkOne": []parse.Benchmark{{Name: "BenchmarkOne/A"}}},
+			dimension:  RenderDimension(1000),
+			wantErr:    ErrUnknownDimensionType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := NewGridRenderer(test.renderType).RenderSets(&bytes.Buffer{}, test.dimension, test.sets)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+		})
+	}
+}
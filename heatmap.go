@@ -0,0 +1,373 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Heatmap holds benchmark results arranged in a grid by two of their name parameters - for instance, "workers"
+// and "size" for benchmarks named like "BenchmarkCache/workers=4/size=1024".
+type Heatmap struct {
+	XKey    string
+	YKey    string
+	XValues []string
+	YValues []string
+	// Cells holds the value of each cell, indexed by Y then X.  Cells without a benchmark are NaN.  Where a cell
+	// has several samples, its value is their mean.
+	Cells [][]float64
+}
+
+// NewHeatmap arranges the benchmarks into a grid, with the xKey parameter's values along the X axis and the yKey
+// parameter's values along the Y axis.  If either key is empty, the first unused "key=value" parameter of the first
+// benchmark is chosen.  Benchmarks without both parameters are left out - if none have them, an
+// ErrMissingNameParameter is returned.
+func NewHeatmap(benchmarks []parse.Benchmark, dimension Dimension, xKey, yKey string) (Heatmap, error) {
+	if len(benchmarks) == 0 {
+		return Heatmap{}, ErrNoBenchmarksProvided
+	}
+
+	for _, key := range ParseBenchmarkName(benchmarks[0].Name).Keys() {
+		if xKey == "" && key != yKey {
+			xKey = key
+		} else if yKey == "" && key != xKey {
+			yKey = key
+		}
+	}
+	if xKey == "" || yKey == "" {
+		return Heatmap{}, fmt.Errorf("benchmark %q needs two 'key=value' parameters for a heatmap: %w", benchmarks[0].Name, ErrMissingNameParameter)
+	}
+
+	type cell struct {
+		x, y string
+	}
+	totals := make(map[cell]float64)
+	counts := make(map[cell]int)
+	var xValues, yValues []string
+	for _, benchmark := range benchmarks {
+		name := ParseBenchmarkName(benchmark.Name)
+		x, xOk := name.Parameter(xKey)
+		y, yOk := name.Parameter(yKey)
+		if !xOk || !yOk {
+			continue
+		}
+
+		value, err := dimension.Value(benchmark)
+		if err != nil {
+			return Heatmap{}, err
+		}
+
+		c := cell{x, y}
+		if counts[c] == 0 {
+			xValues = appendUnique(xValues, x)
+			yValues = appendUnique(yValues, y)
+		}
+		totals[c] += value
+		counts[c]++
+	}
+	if len(counts) == 0 {
+		return Heatmap{}, fmt.Errorf("no benchmarks have both %q and %q parameters: %w", xKey, yKey, ErrMissingNameParameter)
+	}
+
+	sortParameterValues(xValues)
+	sortParameterValues(yValues)
+
+	heatmap := Heatmap{XKey: xKey, YKey: yKey, XValues: xValues, YValues: yValues}
+	for _, y := range yValues {
+		row := make([]float64, len(xValues))
+		for index, x := range xValues {
+			c := cell{x, y}
+			row[index] = math.NaN()
+			if counts[c] > 0 {
+				row[index] = totals[c] / float64(counts[c])
+			}
+		}
+		heatmap.Cells = append(heatmap.Cells, row)
+	}
+	return heatmap, nil
+}
+
+// bounds provides the smallest and largest cell values, ignoring empty cells.
+func (h Heatmap) bounds() (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, row := range h.Cells {
+		for _, value := range row {
+			if math.IsNaN(value) {
+				continue
+			}
+			min = math.Min(min, value)
+			max = math.Max(max, value)
+		}
+	}
+	return min, max
+}
+
+// appendUnique appends the value to the values, if it is not already present.
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// sortParameterValues sorts the values numerically, if they are all numbers.  Otherwise, they are left in the
+// order they first appeared.
+func sortParameterValues(values []string) {
+	numbers := make(map[string]float64, len(values))
+	for _, value := range values {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return
+		}
+		numbers[value] = number
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return numbers[values[i]] < numbers[values[j]]
+	})
+}
+
+// ===== Heatmap chart =====
+
+const (
+	// heatmapLegendWidth is the space reserved to the right of the grid for the colour scale legend.
+	heatmapLegendWidth = 110
+	// heatmapLegendBarWidth is the width of the colour scale drawn in the legend.
+	heatmapLegendBarWidth = 20
+	// heatmapLegendSteps is the number of bands the legend's colour scale is drawn with.
+	heatmapLegendSteps = 64
+)
+
+// heatmapScale is the sequential colour scale cells are coloured with, from the smallest to the largest value.
+// These are stops from the viridis scale, which is perceptually uniform and colour blind safe.
+var heatmapScale = []drawing.Color{
+	{R: 0x44, G: 0x01, B: 0x54, A: 0xff},
+	{R: 0x3b, G: 0x52, B: 0x8b, A: 0xff},
+	{R: 0x21, G: 0x91, B: 0x8c, A: 0xff},
+	{R: 0x5e, G: 0xc9, B: 0x62, A: 0xff},
+	{R: 0xfd, G: 0xe7, B: 0x25, A: 0xff},
+}
+
+// heatmapColour provides the colour of the scale at the fraction (between 0 and 1) along it.
+func heatmapColour(fraction float64) drawing.Color {
+	fraction = math.Max(0, math.Min(1, fraction))
+	position := fraction * float64(len(heatmapScale)-1)
+	index := int(math.Min(position, float64(len(heatmapScale)-2)))
+	t := position - float64(index)
+
+	from, to := heatmapScale[index], heatmapScale[index+1]
+	blend := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*t))
+	}
+	return drawing.Color{R: blend(from.R, to.R), G: blend(from.G, to.G), B: blend(from.B, to.B), A: 0xff}
+}
+
+// contrastingTextColour provides black or white, whichever is more legible against the background colour.
+func contrastingTextColour(background drawing.Color) drawing.Color {
+	luminance := 0.299*float64(background.R) + 0.587*float64(background.G) + 0.114*float64(background.B)
+	if luminance > 140 {
+		return drawing.ColorBlack
+	}
+	return drawing.ColorWhite
+}
+
+// heatmapChart draws a Heatmap as a grid of coloured cells, with a colour scale legend.
+type heatmapChart struct {
+	title     string
+	width     int
+	height    int
+	heatmap   Heatmap
+	dimension Dimension
+	palette   chart.ColorPalette
+	// logScale positions the cell values along the colour scale logarithmically.
+	logScale bool
+	// subtitle is drawn beneath the title, if not empty.
+	subtitle string
+}
+
+// fraction provides the position of the value along the colour scale, between 0 and 1.
+func (h heatmapChart) fraction(value, min, max float64) float64 {
+	if h.logScale {
+		min, max = positiveBounds([]float64{min, max, value})
+		if min == 0 || max == min || value <= 0 {
+			return 0
+		}
+		return (math.Log10(value) - math.Log10(min)) / (math.Log10(max) - math.Log10(min))
+	}
+	if max == min {
+		return 0.5
+	}
+	return (value - min) / (max - min)
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+func (h heatmapChart) Render(rp chart.RendererProvider, w io.Writer) error {
+	heatmap := h.heatmap
+	if len(heatmap.XValues) == 0 || len(heatmap.YValues) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	r, err := rp(h.width, h.height)
+	if err != nil {
+		return err
+	}
+	font, err := chart.GetDefaultFont()
+	if err != nil {
+		return err
+	}
+
+	textStyle := chart.Style{
+		Font:      font,
+		FontSize:  chart.DefaultAxisFontSize,
+		FontColor: h.palette.TextColor(),
+	}
+	lineStyle := chart.Style{
+		StrokeColor: h.palette.AxisStrokeColor(),
+		StrokeWidth: 1,
+	}
+
+	chart.Draw.Box(r, chart.Box{Right: h.width, Bottom: h.height}, chart.Style{
+		FillColor:   h.palette.BackgroundColor(),
+		StrokeColor: h.palette.BackgroundStrokeColor(),
+		StrokeWidth: chart.DefaultStrokeWidth,
+	})
+
+	if h.title != "" {
+		titleStyle := textStyle
+		titleStyle.FontSize = 18
+		titleStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(h.title)
+		r.Text(h.title, h.width>>1-box.Width()>>1, chart.DefaultTitleTop+box.Height())
+	}
+	drawSubtitle(r, textStyle, h.subtitle, h.width)
+
+	// Size the Y label area to the widest label, or the Y axis name above them.
+	textStyle.WriteTextOptionsToRenderer(r)
+	labelWidth := r.MeasureText(heatmap.YKey).Width()
+	for _, y := range heatmap.YValues {
+		labelWidth = int(math.Max(float64(labelWidth), float64(r.MeasureText(y).Width())))
+	}
+
+	grid := chart.Box{
+		Top:    horizontalTitleHeight,
+		Left:   horizontalPadding + labelWidth + horizontalLabelGap,
+		Right:  h.width - horizontalPadding - heatmapLegendWidth,
+		Bottom: h.height - horizontalAxisHeight,
+	}
+	cellWidth := grid.Width() / len(heatmap.XValues)
+	cellHeight := grid.Height() / len(heatmap.YValues)
+
+	min, max := heatmap.bounds()
+	for yIndex, row := range heatmap.Cells {
+		// The Y values increase up the chart.
+		top := grid.Bottom - (yIndex+1)*cellHeight
+		for xIndex, value := range row {
+			if math.IsNaN(value) {
+				continue
+			}
+			left := grid.Left + xIndex*cellWidth
+			colour := heatmapColour(h.fraction(value, min, max))
+			chart.Draw.Box(r, chart.Box{Top: top, Left: left, Right: left + cellWidth, Bottom: top + cellHeight}, chart.Style{
+				FillColor:   colour,
+				StrokeColor: h.palette.BackgroundColor(),
+				StrokeWidth: 1,
+			})
+
+			// Annotate the cell with its value, where there is room.
+			cellStyle := textStyle
+			cellStyle.FontColor = contrastingTextColour(colour)
+			cellStyle.WriteTextOptionsToRenderer(r)
+			label := h.dimension.FormatValue(value)
+			box := r.MeasureText(label)
+			if box.Width() < cellWidth && box.Height() < cellHeight {
+				r.Text(label, left+cellWidth>>1-box.Width()>>1, top+cellHeight>>1+box.Height()>>1)
+			}
+		}
+	}
+
+	h.drawAxes(r, grid, cellWidth, cellHeight, textStyle, lineStyle)
+	h.drawLegend(r, grid, min, max, textStyle)
+
+	return r.Save(w)
+}
+
+// drawAxes draws the parameter values alongside the grid, with the parameter keys as the axis names.
+func (h heatmapChart) drawAxes(r chart.Renderer, grid chart.Box, cellWidth, cellHeight int, textStyle, lineStyle chart.Style) {
+	heatmap := h.heatmap
+
+	lineStyle.WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(grid.Left, grid.Top)
+	r.LineTo(grid.Left, grid.Bottom)
+	r.LineTo(grid.Left+cellWidth*len(heatmap.XValues), grid.Bottom)
+	r.Stroke()
+
+	textStyle.WriteTextOptionsToRenderer(r)
+	var labelHeight int
+	for index, x := range heatmap.XValues {
+		box := r.MeasureText(x)
+		labelHeight = box.Height()
+		centre := grid.Left + index*cellWidth + cellWidth>>1
+		r.Text(x, centre-box.Width()>>1, grid.Bottom+chart.DefaultXAxisMargin+box.Height())
+	}
+	for index, y := range heatmap.YValues {
+		box := r.MeasureText(y)
+		centre := grid.Bottom - index*cellHeight - cellHeight>>1
+		r.Text(y, grid.Left-horizontalLabelGap-box.Width(), centre+box.Height()>>1)
+	}
+
+	xBox := r.MeasureText(heatmap.XKey)
+	xCentre := grid.Left + cellWidth*len(heatmap.XValues)>>1
+	r.Text(heatmap.XKey, xCentre-xBox.Width()>>1, grid.Bottom+2*chart.DefaultXAxisMargin+labelHeight+xBox.Height())
+
+	yBox := r.MeasureText(heatmap.YKey)
+	r.Text(heatmap.YKey, grid.Left-horizontalLabelGap-yBox.Width(), grid.Bottom-cellHeight*len(heatmap.YValues)-chart.DefaultYAxisMargin)
+}
+
+// drawLegend draws the colour scale to the right of the grid, labelled with the smallest, middle and largest values.
+func (h heatmapChart) drawLegend(r chart.Renderer, grid chart.Box, min, max float64, textStyle chart.Style) {
+	left := grid.Right + 2*horizontalLabelGap
+	bandHeight := float64(grid.Height()) / heatmapLegendSteps
+	for step := 0; step < heatmapLegendSteps; step++ {
+		// Bands are drawn from the bottom (smallest) to the top (largest) of the scale.
+		bottom := grid.Bottom - int(math.Round(float64(step)*bandHeight))
+		top := grid.Bottom - int(math.Round(float64(step+1)*bandHeight))
+		colour := heatmapColour((float64(step) + 0.5) / heatmapLegendSteps)
+		chart.Draw.Box(r, chart.Box{Top: top, Left: left, Right: left + heatmapLegendBarWidth, Bottom: bottom}, chart.Style{
+			FillColor:   colour,
+			StrokeColor: colour,
+			StrokeWidth: 1,
+		})
+	}
+
+	middle := (min + max) / 2
+	if h.logScale {
+		if low, high := positiveBounds([]float64{min, max}); low > 0 {
+			middle = math.Sqrt(low * high)
+		}
+	}
+
+	textStyle.WriteTextOptionsToRenderer(r)
+	ticks := []struct {
+		value float64
+		y     int
+	}{
+		{min, grid.Bottom},
+		{middle, grid.Top + grid.Height()>>1},
+		{max, grid.Top},
+	}
+	for _, tick := range ticks {
+		label := h.dimension.FormatValue(tick.value)
+		box := r.MeasureText(label)
+		r.Text(label, left+heatmapLegendBarWidth+horizontalLabelGap>>1, tick.y+box.Height()>>1)
+	}
+
+	name := h.dimension.Title()
+	box := r.MeasureText(name)
+	r.Text(name, left, grid.Top-chart.DefaultYAxisMargin-box.Height()>>1)
+}
@@ -0,0 +1,208 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ===== NewHeatmap tests =====
+
+func TestNewHeatmap(t *testing.T) {
+	nan := math.NaN()
+	grid := []parse.Benchmark{
+		{Name: "BenchmarkCache/workers=4/size=1024-8", NsPerOp: 40},
+		{Name: "BenchmarkCache/workers=1/size=1024-8", NsPerOp: 10},
+		{Name: "BenchmarkCache/workers=1/size=64-8", NsPerOp: 1},
+		{Name: "BenchmarkCache/workers=1/size=64-8", NsPerOp: 3},
+	}
+
+	tests := []struct {
+		name       string
+		benchmarks []parse.Benchmark
+		xKey       string
+		yKey       string
+		want       Heatmap
+		wantErr    error
+	}{
+		{
+			name:       "keys chosen from names",
+			benchmarks: grid,
+			want: Heatmap{
+				XKey:    "workers",
+				YKey:    "size",
+				XValues: []string{"1", "4"},
+				YValues: []string{"64", "1024"},
+				Cells: [][]float64{
+					{2, nan},
+					{10, 40},
+				},
+			},
+		},
+		{
+			name:       "keys provided",
+			benchmarks: grid,
+			xKey:       "size",
+			yKey:       "workers",
+			want: Heatmap{
+				XKey:    "size",
+				YKey:    "workers",
+				XValues: []string{"64", "1024"},
+				YValues: []string{"1", "4"},
+				Cells: [][]float64{
+					{2, 10},
+					{nan, 40},
+				},
+			},
+		},
+		{
+			name:       "only one key provided",
+			benchmarks: grid,
+			yKey:       "workers",
+			want: Heatmap{
+				XKey:    "size",
+				YKey:    "workers",
+				XValues: []string{"64", "1024"},
+				YValues: []string{"1", "4"},
+				Cells: [][]float64{
+					{2, 10},
+					{nan, 40},
+				},
+			},
+		},
+		{
+			name: "non numeric values keep their order",
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkSort/algo=radix/input=sorted", NsPerOp: 1},
+				{Name: "BenchmarkSort/algo=quick/input=sorted", NsPerOp: 2},
+			},
+			want: Heatmap{
+				XKey:    "algo",
+				YKey:    "input",
+				XValues: []string{"radix", "quick"},
+				YValues: []string{"sorted"},
+				Cells:   [][]float64{{1, 2}},
+			},
+		},
+		{
+			name: "single parameter",
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/size=10", NsPerOp: 1},
+			},
+			wantErr: ErrMissingNameParameter,
+		},
+		{
+			name:       "unknown parameter",
+			benchmarks: grid,
+			xKey:       "missing",
+			wantErr:    ErrMissingNameParameter,
+		},
+		{
+			name:    "no benchmarks",
+			wantErr: ErrNoBenchmarksProvided,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewHeatmap(test.benchmarks, RenderNsPerOp, test.xKey, test.yKey)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+
+			// NaN never equals itself, so empty cells are compared separately.
+			if len(test.want.Cells) != len(got.Cells) {
+				t.Fatalf("Want %d rows, got %d rows", len(test.want.Cells), len(got.Cells))
+			}
+			for y, row := range test.want.Cells {
+				for x, value := range row {
+					if math.IsNaN(value) != math.IsNaN(got.Cells[y][x]) || (!math.IsNaN(value) && value != got.Cells[y][x]) {
+						t.Errorf("Want cell (%d, %d) %v, got %v", x, y, value, got.Cells[y][x])
+					}
+				}
+			}
+			test.want.Cells, got.Cells = nil, nil
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+// ===== Colour scale tests =====
+
+func TestHeatmapColour(t *testing.T) {
+	tests := []struct {
+		name     string
+		fraction float64
+		want     drawing.Color
+	}{
+		{name: "start", fraction: 0, want: heatmapScale[0]},
+		{name: "end", fraction: 1, want: heatmapScale[len(heatmapScale)-1]},
+		{name: "middle stop", fraction: 0.5, want: heatmapScale[2]},
+		{name: "below start", fraction: -1, want: heatmapScale[0]},
+		{name: "between stops", fraction: 0.125, want: drawing.Color{R: 0x40, G: 0x2a, B: 0x70, A: 0xff}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := heatmapColour(test.fraction)
+			if test.want != got {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestContrastingTextColour(t *testing.T) {
+	if got := contrastingTextColour(heatmapScale[0]); got != drawing.ColorWhite {
+		t.Errorf("Want white text on dark cells, got %v", got)
+	}
+	if got := contrastingTextColour(heatmapScale[len(heatmapScale)-1]); got != drawing.ColorBlack {
+		t.Errorf("Want black text on light cells, got %v", got)
+	}
+}
+
+// ===== heatmapChart tests =====
+
+func TestHeatmapChart_Render(t *testing.T) {
+	palette, err := LightTheme.palette()
+	if err != nil {
+		t.Fatalf("Could not create palette - error: %v", err)
+	}
+	heatmap := Heatmap{
+		XKey:    "workers",
+		YKey:    "size",
+		XValues: []string{"1", "4"},
+		YValues: []string{"64", "1024"},
+		Cells: [][]float64{
+			{2, math.NaN()},
+			{1500, 40},
+		},
+	}
+
+	for _, logScale := range []bool{false, true} {
+		buf := bytes.Buffer{}
+		err = heatmapChart{title: "BenchmarkCache", width: 1024, height: 512, heatmap: heatmap, dimension: RenderNsPerOp, palette: palette, logScale: logScale}.Render(chart.SVG, &buf)
+		if err != nil {
+			t.Fatalf("Could not render chart - error: %v", err)
+		}
+
+		xmlData := make([]interface{}, 0)
+		err = xml.Unmarshal(buf.Bytes(), &xmlData)
+		if err != nil {
+			t.Errorf("Error unmarshalling SVG as XML - error: %v", err)
+		}
+
+		for _, want := range []string{"BenchmarkCache", "workers", "size", "1.5µs", "Time per op"} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("Wanted SVG output to contain %q", want)
+			}
+		}
+	}
+}
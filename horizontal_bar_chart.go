@@ -0,0 +1,369 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"github.com/wcharczuk/go-chart"
+	"io"
+	"math"
+	"strings"
+)
+
+// ===== Orientation =====
+
+// Orientation determines the direction in which the bars of a bar chart are drawn.
+type Orientation int
+
+const (
+	// VerticalOrientation draws bars upwards, with their labels along the X axis.
+	VerticalOrientation Orientation = iota
+	// HorizontalOrientation draws bars to the right, with their labels along the Y axis - suited to long labels.
+	HorizontalOrientation
+	// AutoOrientation uses the horizontal orientation when the labels would not fit beneath vertical bars.
+	AutoOrientation
+)
+
+func (o Orientation) String() string {
+	switch o {
+	case VerticalOrientation:
+		return "VERTICAL"
+	case HorizontalOrientation:
+		return "HORIZONTAL"
+	case AutoOrientation:
+		return "AUTO"
+	default:
+		return fmt.Sprintf("Unknown (%d)", o)
+	}
+}
+
+func OrientationFromString(str string) (Orientation, error) {
+	switch str {
+	case "VERTICAL":
+		return VerticalOrientation, nil
+	case "HORIZONTAL":
+		return HorizontalOrientation, nil
+	case "AUTO":
+		return AutoOrientation, nil
+	default:
+		return -1, fmt.Errorf("orientation %q not supported: %w", str, ErrUnknownOrientation)
+	}
+}
+
+// ===== Horizontal layout =====
+
+const (
+	// horizontalTitleHeight is the space reserved above the bars for the chart title.
+	horizontalTitleHeight = 60
+	// horizontalAxisHeight is the space reserved below the bars for the value axis ticks and name.
+	horizontalAxisHeight = 50
+	// horizontalPadding is the space left at either side of the chart.
+	horizontalPadding = 20
+	// horizontalValueLabelWidth is the space reserved to the right of the bars for value labels.
+	horizontalValueLabelWidth = 70
+	// horizontalLabelGap is the space between a bar's label and the bar.
+	horizontalLabelGap = 8
+	// minHorizontalSlotHeight is the minimum height given to each bar and the space around it.
+	minHorizontalSlotHeight = 36
+	// maxLabelAreaRatio is the largest proportion of the chart width given to bar labels.
+	maxLabelAreaRatio = 0.4
+	// labelEllipsis marks where a label has been truncated.
+	labelEllipsis = "…"
+)
+
+// labelsOverflow reports whether any of the bar labels are wider than the space go-chart gives each vertical bar,
+// which would cause them to overlap one another.
+func labelsOverflow(graph *chart.BarChart) (bool, error) {
+	r, err := newMeasuringRenderer()
+	if err != nil {
+		return false, err
+	}
+
+	// Approximate the canvas width, allowing for the Y axis ticks and chart padding.
+	canvasWidth := graph.GetWidth() - 100
+	slot := graph.GetBarWidth() + graph.GetBarSpacing()
+	if len(graph.Bars)*slot > canvasWidth {
+		slot = canvasWidth / len(graph.Bars)
+	}
+
+	for _, bar := range graph.Bars {
+		if r.MeasureText(bar.Label).Width() > slot {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newMeasuringRenderer provides a renderer configured with the axis font, for measuring label widths.
+func newMeasuringRenderer() (chart.Renderer, error) {
+	r, err := chart.PNG(1, 1)
+	if err != nil {
+		return nil, err
+	}
+	font, err := chart.GetDefaultFont()
+	if err != nil {
+		return nil, err
+	}
+	r.SetFont(font)
+	r.SetFontSize(chart.DefaultAxisFontSize)
+	return r, nil
+}
+
+// wrapLabel splits the label across lines no wider than maxWidth, breaking after "/" separators.  Any segment too
+// wide for a line by itself is truncated, as is the final line if more than maxLines would be needed.
+func wrapLabel(r chart.Renderer, label string, maxWidth, maxLines int) []string {
+	segments := strings.SplitAfter(label, "/")
+
+	var lines []string
+	line := ""
+	for _, segment := range segments {
+		if line != "" && r.MeasureText(line+segment).Width() > maxWidth {
+			lines = append(lines, line)
+			line = ""
+		}
+		line += segment
+	}
+	lines = append(lines, line)
+
+	if maxLines < 1 {
+		maxLines = 1
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] += labelEllipsis
+	}
+
+	for index, l := range lines {
+		lines[index] = truncateLabel(r, l, maxWidth)
+	}
+	return lines
+}
+
+// truncateLabel shortens the label, ending it with an ellipsis, until it is no wider than maxWidth.
+func truncateLabel(r chart.Renderer, label string, maxWidth int) string {
+	if r.MeasureText(label).Width() <= maxWidth {
+		return label
+	}
+
+	runes := []rune(strings.TrimSuffix(label, labelEllipsis))
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		truncated := string(runes) + labelEllipsis
+		if r.MeasureText(truncated).Width() <= maxWidth {
+			return truncated
+		}
+	}
+	return labelEllipsis
+}
+
+// horizontalBarChart draws the bars of a go-chart bar chart horizontally, with the labels along the Y axis.
+type horizontalBarChart struct {
+	graph       *chart.BarChart
+	dimension   Dimension
+	valueLabels bool
+	// errs holds the error bar size for each bar, if error bars are displayed.
+	errs []float64
+	// subtitle is drawn beneath the title, if not empty.
+	subtitle string
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+func (h horizontalBarChart) Render(rp chart.RendererProvider, w io.Writer) error {
+	graph := h.graph
+	if len(graph.Bars) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	width := graph.GetWidth()
+	height := graph.GetHeight()
+	if minHeight := horizontalTitleHeight + horizontalAxisHeight + len(graph.Bars)*minHorizontalSlotHeight; minHeight > height {
+		height = minHeight
+	}
+
+	r, err := rp(width, height)
+	if err != nil {
+		return err
+	}
+	font, err := chart.GetDefaultFont()
+	if err != nil {
+		return err
+	}
+	r.SetDPI(graph.GetDPI())
+
+	palette := graph.GetColorPalette()
+	textStyle := chart.Style{
+		Font:      font,
+		FontSize:  chart.DefaultAxisFontSize,
+		FontColor: palette.TextColor(),
+	}
+	lineStyle := chart.Style{
+		StrokeColor: palette.AxisStrokeColor(),
+		StrokeWidth: 1,
+	}
+
+	chart.Draw.Box(r, chart.Box{Right: width, Bottom: height}, chart.Style{
+		FillColor:   palette.BackgroundColor(),
+		StrokeColor: palette.BackgroundStrokeColor(),
+		StrokeWidth: chart.DefaultStrokeWidth,
+	})
+	h.drawTitle(r, textStyle, width)
+	drawSubtitle(r, textStyle, h.subtitle, width)
+
+	// Size the label area to the widest label, within limits.
+	textStyle.WriteTextOptionsToRenderer(r)
+	labelWidth := 0
+	for _, bar := range graph.Bars {
+		labelWidth = int(math.Max(float64(labelWidth), float64(r.MeasureText(bar.Label).Width())))
+	}
+	labelWidth = int(math.Min(float64(labelWidth), float64(width)*maxLabelAreaRatio))
+
+	right := width - horizontalPadding
+	if h.valueLabels {
+		right -= horizontalValueLabelWidth
+	}
+	canvasBox := chart.Box{
+		Top:    horizontalTitleHeight,
+		Left:   horizontalPadding + labelWidth + horizontalLabelGap,
+		Right:  right,
+		Bottom: height - horizontalAxisHeight,
+	}
+
+	valueRange := h.valueRange()
+	valueRange.SetDomain(canvasBox.Width())
+	x := func(value float64) int {
+		return canvasBox.Left + valueRange.Translate(value)
+	}
+
+	slotHeight := canvasBox.Height() / len(graph.Bars)
+	barHeight := int(math.Min(float64(slotHeight)*0.6, float64(graph.GetBarWidth())))
+	lineHeight := r.MeasureText("Ag").Height() + textStyle.GetTextLineSpacing()
+
+	for index, bar := range graph.Bars {
+		centre := canvasBox.Top + index*slotHeight + slotHeight>>1
+
+		barBox := chart.Box{
+			Top:    centre - barHeight>>1,
+			Left:   x(valueRange.GetMin()),
+			Right:  x(bar.Value),
+			Bottom: centre + barHeight>>1,
+		}
+		chart.Draw.Box(r, barBox, bar.Style.InheritFrom(chart.Style{
+			StrokeColor: palette.GetSeriesColor(index),
+			StrokeWidth: 3.0,
+			FillColor:   palette.GetSeriesColor(index),
+		}))
+
+		// Labels are right aligned against the bar, and vertically centred on it.
+		textStyle.WriteTextOptionsToRenderer(r)
+		lines := wrapLabel(r, bar.Label, labelWidth, slotHeight/lineHeight)
+		top := centre - (len(lines)*lineHeight)>>1
+		for lineIndex, line := range lines {
+			box := r.MeasureText(line)
+			r.Text(line, canvasBox.Left-horizontalLabelGap-box.Width(), top+(lineIndex+1)*lineHeight-textStyle.GetTextLineSpacing())
+		}
+
+		end := bar.Value
+		if index < len(h.errs) && h.errs[index] > 0 {
+			e := h.errs[index]
+			end += e
+			lineStyle.WriteDrawingOptionsToRenderer(r)
+			r.MoveTo(x(bar.Value-e), centre)
+			r.LineTo(x(bar.Value+e), centre)
+			r.MoveTo(x(bar.Value-e), centre-errorBarCapWidth>>1)
+			r.LineTo(x(bar.Value-e), centre+errorBarCapWidth>>1)
+			r.MoveTo(x(bar.Value+e), centre-errorBarCapWidth>>1)
+			r.LineTo(x(bar.Value+e), centre+errorBarCapWidth>>1)
+			r.Stroke()
+		}
+
+		if h.valueLabels {
+			textStyle.WriteTextOptionsToRenderer(r)
+			label := h.dimension.FormatValue(bar.Value)
+			box := r.MeasureText(label)
+			r.Text(label, x(end)+4, centre+box.Height()>>1)
+		}
+	}
+
+	if reference, ok := h.dimension.reference(); ok {
+		drawReferenceLine(r, lineStyle, x(reference), canvasBox.Top, x(reference), canvasBox.Bottom)
+	}
+
+	h.drawValueAxis(r, canvasBox, valueRange, textStyle, lineStyle)
+
+	return r.Save(w)
+}
+
+// valueRange provides the range of the value axis.  The graph's own range is used if set (for instance, a
+// logarithmic range), otherwise a linear range from zero is used.
+func (h horizontalBarChart) valueRange() chart.Range {
+	if h.graph.YAxis.Range != nil {
+		if _, isLinear := h.graph.YAxis.Range.(*chart.ContinuousRange); !isLinear {
+			return h.graph.YAxis.Range
+		}
+	}
+
+	min, max := 0.0, 0.0
+	for index, bar := range h.graph.Bars {
+		e := 0.0
+		if index < len(h.errs) {
+			e = h.errs[index]
+		}
+		min = math.Min(min, bar.Value-e)
+		max = math.Max(max, bar.Value+e)
+	}
+	if reference, ok := h.dimension.reference(); ok {
+		max = math.Max(max, reference)
+	}
+	if max == min {
+		max = min + 1
+	}
+	return &chart.ContinuousRange{Min: min, Max: max}
+}
+
+// drawTitle draws the chart title, centred at the top of the chart.
+func (h horizontalBarChart) drawTitle(r chart.Renderer, textStyle chart.Style, width int) {
+	if h.graph.Title == "" || !h.graph.TitleStyle.Show {
+		return
+	}
+	titleStyle := textStyle
+	titleStyle.FontSize = 18
+	titleStyle.WriteTextOptionsToRenderer(r)
+
+	box := r.MeasureText(h.graph.Title)
+	r.Text(h.graph.Title, width>>1-box.Width()>>1, chart.DefaultTitleTop+box.Height())
+}
+
+// drawValueAxis draws the value axis along the bottom of the canvas, with its ticks and name.
+func (h horizontalBarChart) drawValueAxis(r chart.Renderer, canvasBox chart.Box, valueRange chart.Range, textStyle, lineStyle chart.Style) {
+	lineStyle.WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(canvasBox.Left, canvasBox.Top)
+	r.LineTo(canvasBox.Left, canvasBox.Bottom)
+	r.LineTo(canvasBox.Right, canvasBox.Bottom)
+	r.Stroke()
+
+	formatter := h.graph.YAxis.ValueFormatter
+	if formatter == nil {
+		formatter = chart.FloatValueFormatter
+	}
+
+	var tickHeight int
+	ticks := chart.XAxis{Range: valueRange}.GetTicks(r, valueRange, textStyle, formatter)
+	for _, tick := range ticks {
+		tickX := canvasBox.Left + valueRange.Translate(tick.Value)
+
+		lineStyle.WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(tickX, canvasBox.Bottom)
+		r.LineTo(tickX, canvasBox.Bottom+chart.DefaultVerticalTickHeight)
+		r.Stroke()
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(tick.Label)
+		tickHeight = box.Height()
+		r.Text(tick.Label, tickX-box.Width()>>1, canvasBox.Bottom+chart.DefaultXAxisMargin+box.Height())
+	}
+
+	if name := h.graph.YAxis.Name; name != "" {
+		textStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(name)
+		nameX := canvasBox.Left + canvasBox.Width()>>1 - box.Width()>>1
+		r.Text(name, nameX, canvasBox.Bottom+2*chart.DefaultXAxisMargin+tickHeight+box.Height())
+	}
+}
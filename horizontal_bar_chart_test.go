@@ -0,0 +1,227 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"github.com/wcharczuk/go-chart"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ===== Orientation tests =====
+
+func TestOrientation_String(t *testing.T) {
+	tests := []struct {
+		input Orientation
+		want  string
+	}{
+		{VerticalOrientation, "VERTICAL"},
+		{HorizontalOrientation, "HORIZONTAL"},
+		{AutoOrientation, "AUTO"},
+		{Orientation(1000), "Unknown (1000)"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			got := test.input.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestOrientationFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Orientation
+		wantErr error
+	}{
+		{input: "VERTICAL", want: VerticalOrientation},
+		{input: "HORIZONTAL", want: HorizontalOrientation},
+		{input: "AUTO", want: AutoOrientation},
+		{input: "abc123", want: Orientation(-1), wantErr: ErrUnknownOrientation},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := OrientationFromString(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+// ===== Label layout tests =====
+
+func TestLabelsOverflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []string
+		want   bool
+	}{
+		{
+			name:   "short labels",
+			labels: []string{"A", "B"},
+			want:   false,
+		},
+		{
+			name:   "long label",
+			labels: []string{"A", "algo=radix/input=sorted/size=1048576"},
+			want:   true,
+		},
+		{
+			name:   "many bars narrow the space for each label",
+			labels: strings.Split(strings.Repeat("size=1024,", 30), ",")[:30],
+			want:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graph := &chart.BarChart{}
+			for _, label := range test.labels {
+				graph.Bars = append(graph.Bars, chart.Value{Label: label, Value: 1})
+			}
+
+			got, err := labelsOverflow(graph)
+			if err != nil {
+				t.Fatalf("Could not measure labels - error: %v", err)
+			}
+			if test.want != got {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestWrapLabel(t *testing.T) {
+	r, err := newMeasuringRenderer()
+	if err != nil {
+		t.Fatalf("Could not create renderer - error: %v", err)
+	}
+	label := "algo=radix/input=sorted/size=1048576"
+
+	tests := []struct {
+		name     string
+		maxWidth int
+		maxLines int
+		want     []string
+	}{
+		{
+			name:     "fits on one line",
+			maxWidth: r.MeasureText(label).Width(),
+			maxLines: 3,
+			want:     []string{label},
+		},
+		{
+			name:     "wraps at separators",
+			maxWidth: r.MeasureText("algo=radix/input=sorted/").Width(),
+			maxLines: 3,
+			want:     []string{"algo=radix/input=sorted/", "size=1048576"},
+		},
+		{
+			name:     "one segment per line",
+			maxWidth: r.MeasureText("size=1048576").Width(),
+			maxLines: 3,
+			want:     []string{"algo=radix/", "input=sorted/", "size=1048576"},
+		},
+		{
+			name:     "too many lines",
+			maxWidth: r.MeasureText("input=sorted/…").Width(),
+			maxLines: 2,
+			want:     []string{"algo=radix/", "input=sorted/…"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := wrapLabel(r, label, test.maxWidth, test.maxLines)
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTruncateLabel(t *testing.T) {
+	r, err := newMeasuringRenderer()
+	if err != nil {
+		t.Fatalf("Could not create renderer - error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		label    string
+		maxWidth int
+		want     string
+	}{
+		{
+			name:     "fits",
+			label:    "size=1024",
+			maxWidth: r.MeasureText("size=1024").Width(),
+			want:     "size=1024",
+		},
+		{
+			name:     "truncated",
+			label:    "size=1048576",
+			maxWidth: r.MeasureText("size=10…").Width(),
+			want:     "size=10…",
+		},
+		{
+			name:     "no room",
+			label:    "size=1048576",
+			maxWidth: 0,
+			want:     "…",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := truncateLabel(r, test.label, test.maxWidth)
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+// ===== horizontalBarChart tests =====
+
+func TestHorizontalBarChart_Render(t *testing.T) {
+	graph := &chart.BarChart{
+		Title:      "BenchmarkSort",
+		TitleStyle: chart.StyleShow(),
+		Height:     512,
+		BarWidth:   60,
+		YAxis:      chart.YAxis{Name: "Time per op (ns)"},
+		Bars: []chart.Value{
+			{Value: 100, Label: "algo=radix/input=sorted/size=1048576"},
+			{Value: 300, Label: "algo=quick/input=reversed/size=1048576"},
+		},
+	}
+
+	buf := bytes.Buffer{}
+	err := horizontalBarChart{graph: graph, dimension: RenderNsPerOp, valueLabels: true, errs: []float64{10, 0}}.Render(chart.SVG, &buf)
+	if err != nil {
+		t.Fatalf("Could not render chart - error: %v", err)
+	}
+
+	xmlData := make([]interface{}, 0)
+	err = xml.Unmarshal(buf.Bytes(), &xmlData)
+	if err != nil {
+		t.Errorf("Error unmarshalling SVG as XML - error: %v", err)
+	}
+
+	for _, want := range []string{"BenchmarkSort", "Time per op (ns)", "algo=radix/input=sorted/size=1048576", "300ns"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Wanted SVG output to contain %q", want)
+		}
+	}
+
+	err = horizontalBarChart{graph: &chart.BarChart{}}.Render(chart.SVG, &buf)
+	if !errors.Is(err, ErrNoBenchmarksProvided) {
+		t.Errorf("Want error '%v', got error '%v'", ErrNoBenchmarksProvided, err)
+	}
+}
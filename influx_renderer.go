@@ -0,0 +1,94 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// influxMeasurement is the measurement of every point written by the InfluxRenderer.
+const influxMeasurement = "go_benchmark"
+
+// InfluxRenderer outputs the benchmarks in the InfluxDB line protocol, as a point for each benchmark.  Points are
+// tagged with the parts of the benchmark name and its configuration, with a field for each measured metric.
+// Repeated samples of a benchmark, run with the same configuration, are combined into their mean, as points sharing
+// tags and a timestamp overwrite each other.
+type InfluxRenderer struct {
+	// Configs holds the configuration of each benchmark, such as its "goos" - each is added to the tags.
+	Configs BenchmarkConfigs
+	// Timestamp is the time of every point.  If zero, the timestamp is omitted, and InfluxDB uses the time the points
+	// are written.
+	Timestamp time.Time
+}
+
+func (i *InfluxRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
+	if len(benchmarks) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	buf := bytes.Buffer{}
+	for _, samples := range GroupSamplesByConfig(benchmarks, i.Configs) {
+		benchmark := samples.Mean()
+
+		buf.WriteString(influxEscape(influxMeasurement, ", "))
+
+		// Tags are sorted by key, as recommended for the best performance.
+		tags := nameLabels(benchmark.Name, i.Configs[benchmark.Ord], func(key string) string {
+			return key
+		})
+		sort.SliceStable(tags, func(a, b int) bool {
+			return tags[a].key < tags[b].key
+		})
+		for _, tag := range tags {
+			// Tags cannot have empty values.
+			if tag.value == "" {
+				continue
+			}
+			buf.WriteString(",")
+			buf.WriteString(influxEscape(tag.key, ",= "))
+			buf.WriteString("=")
+			buf.WriteString(influxEscape(tag.value, ",= "))
+		}
+
+		fields := []string{"n=" + strconv.Itoa(benchmark.N) + "i"}
+		if benchmark.Measured&parse.NsPerOp != 0 {
+			fields = append(fields, "ns_per_op="+strconv.FormatFloat(benchmark.NsPerOp, 'f', -1, 64))
+		}
+		if benchmark.Measured&parse.AllocedBytesPerOp != 0 {
+			fields = append(fields, "bytes_per_op="+strconv.FormatUint(benchmark.AllocedBytesPerOp, 10)+"i")
+		}
+		if benchmark.Measured&parse.AllocsPerOp != 0 {
+			fields = append(fields, "allocs_per_op="+strconv.FormatUint(benchmark.AllocsPerOp, 10)+"i")
+		}
+		if benchmark.Measured&parse.MBPerS != 0 {
+			fields = append(fields, "mb_per_s="+strconv.FormatFloat(benchmark.MBPerS, 'f', -1, 64))
+		}
+		buf.WriteString(" ")
+		buf.WriteString(strings.Join(fields, ","))
+
+		if !i.Timestamp.IsZero() {
+			buf.WriteString(" ")
+			buf.WriteString(strconv.FormatInt(i.Timestamp.UnixNano(), 10))
+		}
+		buf.WriteString("\n")
+	}
+
+	_, err := writer.Write(buf.Bytes())
+	return err
+}
+
+// influxEscape escapes the special characters within the string with backslashes.
+func influxEscape(str string, special string) string {
+	var builder strings.Builder
+	for _, r := range str {
+		if strings.ContainsRune(special, r) {
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
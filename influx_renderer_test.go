@@ -0,0 +1,95 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"testing"
+	"time"
+)
+
+func TestInfluxRenderer_Render(t *testing.T) {
+	tests := []struct {
+		name       string
+		renderer   InfluxRenderer
+		benchmarks []parse.Benchmark
+		want       string
+	}{
+		{
+			name:     "measured fields",
+			renderer: InfluxRenderer{Timestamp: time.Unix(1622548800, 0)},
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/Sub-8", N: 1000, NsPerOp: 123.5, AllocedBytesPerOp: 64, AllocsPerOp: 2, Measured: parse.NsPerOp | parse.AllocedBytesPerOp | parse.AllocsPerOp},
+			},
+			want: "go_benchmark,benchmark=BenchmarkOne,procs=8,sub=Sub n=1000i,ns_per_op=123.5,bytes_per_op=64i,allocs_per_op=2i 1622548800000000000\n",
+		},
+		{
+			name: "config and parameter tags",
+			renderer: InfluxRenderer{
+				Configs: BenchmarkConfigs{
+					0: {"cpu": "Example CPU @ 2.90GHz", "goos": "linux"},
+				},
+			},
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkCache/size=1024-4", N: 10, NsPerOp: 5, MBPerS: 1.5, Measured: parse.NsPerOp | parse.MBPerS},
+			},
+			want: "go_benchmark,benchmark=BenchmarkCache,cpu=Example\\ CPU\\ @\\ 2.90GHz,goos=linux,procs=4,size=1024,sub=size\\=1024 n=10i,ns_per_op=5,mb_per_s=1.5\n",
+		},
+		{
+			name:     "samples combined",
+			renderer: InfluxRenderer{},
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne", N: 10, NsPerOp: 100, Measured: parse.NsPerOp},
+				{Name: "BenchmarkOne", N: 30, NsPerOp: 200, Measured: parse.NsPerOp},
+			},
+			want: "go_benchmark,benchmark=BenchmarkOne n=20i,ns_per_op=150\n",
+		},
+		{
+			name: "packages kept apart",
+			renderer: InfluxRenderer{
+				Configs: BenchmarkConfigs{
+					0: {"pkg": "example.com/a"},
+					1: {"pkg": "example.com/b"},
+					2: {"pkg": "example.com/a"},
+				},
+			},
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkParse", N: 10, NsPerOp: 100, Measured: parse.NsPerOp, Ord: 0},
+				{Name: "BenchmarkParse", N: 10, NsPerOp: 900, Measured: parse.NsPerOp, Ord: 1},
+				{Name: "BenchmarkParse", N: 10, NsPerOp: 200, Measured: parse.NsPerOp, Ord: 2},
+			},
+			want: "go_benchmark,benchmark=BenchmarkParse,pkg=example.com/a n=10i,ns_per_op=150\n" +
+				"go_benchmark,benchmark=BenchmarkParse,pkg=example.com/b n=10i,ns_per_op=900\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := test.renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, test.benchmarks)
+			if err != nil {
+				t.Fatalf("Error rendering Influx: %v", err)
+			}
+			got := output.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestInfluxRenderer_RenderNoBenchmarks(t *testing.T) {
+	renderer := InfluxRenderer{}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, nil)
+	if !errors.Is(err, ErrNoBenchmarksProvided) {
+		t.Errorf("Wanted error '%v', got error '%v'", ErrNoBenchmarksProvided, err)
+	}
+}
+
+func TestInfluxEscape(t *testing.T) {
+	want := `a\,b\=c\ d`
+	got := influxEscape("a,b=c d", ",= ")
+	if want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
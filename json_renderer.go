@@ -7,19 +7,36 @@ import (
 )
 
 type JSONRenderer struct {
-
+	// Pareto adds the names of the benchmarks which are Pareto optimal, in the rendered dimension and
+	// SecondaryDimension, to the output - comparing the mean of each benchmark's samples, as scatter charts do.  The
+	// dimensions must differ.
+	Pareto             bool
+	SecondaryDimension Dimension
+	// Configs holds the configuration of each benchmark, by its Ord.  Samples run with different configurations are
+	// compared separately for the Pareto frontier.
+	Configs BenchmarkConfigs
 }
 
 type benchmarksJSON struct {
 	ParentBenchmark string
 	Benchmarks []parse.Benchmark
+	ParetoFrontier  []string `json:",omitempty"`
 }
 
-func (j *JSONRenderer) Render(writer io.Writer, parentBenchmark string, dimension RenderDimension, benchmarks []parse.Benchmark) error {
+func (j *JSONRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
 	b := benchmarksJSON{
 		ParentBenchmark: parentBenchmark,
 		Benchmarks:      benchmarks,
 	}
+	if j.Pareto {
+		frontier, err := ParetoFrontier(benchmarks, j.Configs, dimension, j.SecondaryDimension)
+		if err != nil {
+			return err
+		}
+		for _, benchmark := range frontier {
+			b.ParetoFrontier = append(b.ParetoFrontier, benchmark.Name)
+		}
+	}
 	data, err := json.Marshal(b)
 	if err != nil {
 		return err
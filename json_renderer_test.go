@@ -2,7 +2,9 @@ package go_benchpress
 
 import (
 	"bytes"
+	"errors"
 	"golang.org/x/tools/benchmark/parse"
+	"strings"
 	"testing"
 )
 
@@ -75,6 +77,32 @@ func TestJSONRenderer_Render(t *testing.T) {
 	}
 }
 
+func TestJSONRenderer_RenderPareto(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Small", NsPerOp: 300, AllocedBytesPerOp: 100},
+		{Name: "BenchmarkOne/Slow", NsPerOp: 400, AllocedBytesPerOp: 200},
+		{Name: "BenchmarkOne/Fast", NsPerOp: 100, AllocedBytesPerOp: 500},
+	}
+
+	var output bytes.Buffer
+	renderer := JSONRenderer{Pareto: true, SecondaryDimension: RenderBytesPerOp}
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering JSON: %v", err)
+	}
+
+	want := `"ParetoFrontier":["BenchmarkOne/Fast","BenchmarkOne/Small"]}`
+	if !strings.HasSuffix(output.String(), want) {
+		t.Errorf("Want output ending %q, got %q", want, output.String())
+	}
+
+	renderer.SecondaryDimension = RenderDimension(1000)
+	err = renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+}
+
 func BenchmarkJSONRenderer_Render(b *testing.B) {
 
 	benchmark := parse.Benchmark{
@@ -0,0 +1,205 @@
+package go_benchpress
+
+import (
+	"encoding/xml"
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// JUnitRenderer outputs the benchmarks as a JUnit XML report, for CI systems which display test results.  Each
+// benchmark is a test case, with its metrics as properties.  Repeated samples of a benchmark are combined into their
+// mean.  Benchmarks fail where they regress from the Baseline, or exceed the Threshold, in the rendered dimension.
+type JUnitRenderer struct {
+	// Configs holds the configuration of each benchmark result, by its Ord.  Results run with different configurations
+	// are separate test cases, and each is compared with the baseline benchmark from its own package.
+	Configs BenchmarkConfigs
+	// Baseline holds the benchmarks to compare against.  If empty, no comparison is made.
+	Baseline []parse.Benchmark
+	// BaselineConfigs holds the configuration of each Baseline result, by its Ord.
+	BaselineConfigs BenchmarkConfigs
+	// Tolerance is the fraction by which benchmarks may regress from the Baseline before failing - for instance, 0.05
+	// allows a 5% regression.
+	Tolerance float64
+	// Threshold is the highest value a benchmark may have before failing.  If zero, there is no threshold.
+	Threshold float64
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Failures   []junitFailure  `xml:"failure"`
+	SystemOut  string          `xml:"system-out"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (j *JUnitRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
+	if len(benchmarks) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	baselines, err := newComparisonBaselines(j.Baseline, j.BaselineConfigs, dimension)
+	if err != nil {
+		return err
+	}
+
+	suite := junitTestSuite{Name: parentBenchmark}
+	var suiteTime float64
+	for _, samples := range GroupSamplesByConfig(benchmarks, j.Configs) {
+		benchmark := samples.Mean()
+		value, err := dimension.Value(benchmark)
+		if err != nil {
+			return err
+		}
+
+		// The time of each test case is an estimate of the time spent running its measured iterations.
+		caseTime := float64(benchmark.N) * benchmark.NsPerOp / 1e9 * float64(len(samples.Benchmarks))
+		suiteTime += caseTime
+
+		testCase := junitTestCase{
+			Name:       subBenchmarkLabel(benchmark.Name),
+			ClassName:  junitClassName(benchmark, j.Configs),
+			Time:       formatJUnitTime(caseTime),
+			Properties: junitProperties(benchmark, len(samples.Benchmarks)),
+			SystemOut:  junitSystemOut(samples),
+		}
+
+		comparison, ok, err := baselines.compare(benchmark, j.Configs, dimension)
+		if err != nil {
+			return err
+		}
+		if ok {
+			testCase.Properties = append(testCase.Properties,
+				junitProperty{Name: "Baseline", Value: formatJUnitNumber(comparison.Baseline)},
+				junitProperty{Name: "Change", Value: formatJUnitNumber(comparison.Change)},
+			)
+			if comparison.Regressed(j.Tolerance) {
+				testCase.Failures = append(testCase.Failures, junitFailure{
+					Message: fmt.Sprintf("%s regressed by %s from the baseline, beyond the %s tolerance", dimension.Title(), formatPercentage(comparison.Change), formatPercentage(j.Tolerance)),
+					Type:    "regression",
+					Text:    fmt.Sprintf("baseline %s, current %s", dimension.FormatValue(comparison.Baseline), dimension.FormatValue(comparison.Current)),
+				})
+			}
+		}
+
+		if j.Threshold > 0 && value > j.Threshold {
+			testCase.Failures = append(testCase.Failures, junitFailure{
+				Message: fmt.Sprintf("%s of %s exceeds the threshold of %s", dimension.Title(), dimension.FormatValue(value), dimension.FormatValue(j.Threshold)),
+				Type:    "threshold",
+			})
+		}
+
+		suite.Tests++
+		if len(testCase.Failures) > 0 {
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Time = formatJUnitTime(suiteTime)
+
+	report := junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// junitClassName provides the class name of the benchmark's test case - its top-level benchmark, qualified by its
+// package where known, so that benchmarks of the same name in different packages can be told apart.
+func junitClassName(benchmark parse.Benchmark, configs BenchmarkConfigs) string {
+	base := ParseBenchmarkName(benchmark.Name).Base
+	if pkg := configs[benchmark.Ord]["pkg"]; pkg != "" {
+		return pkg + "." + base
+	}
+	return base
+}
+
+// junitProperties provides the measured metrics of the benchmark as properties.
+func junitProperties(benchmark parse.Benchmark, samples int) []junitProperty {
+	properties := []junitProperty{
+		{Name: "N", Value: strconv.Itoa(benchmark.N)},
+		{Name: "Samples", Value: strconv.Itoa(samples)},
+	}
+	if benchmark.Measured&parse.NsPerOp != 0 {
+		properties = append(properties, junitProperty{Name: "NsPerOp", Value: formatJUnitNumber(benchmark.NsPerOp)})
+	}
+	if benchmark.Measured&parse.AllocedBytesPerOp != 0 {
+		properties = append(properties, junitProperty{Name: "AllocedBytesPerOp", Value: strconv.FormatUint(benchmark.AllocedBytesPerOp, 10)})
+	}
+	if benchmark.Measured&parse.AllocsPerOp != 0 {
+		properties = append(properties, junitProperty{Name: "AllocsPerOp", Value: strconv.FormatUint(benchmark.AllocsPerOp, 10)})
+	}
+	if benchmark.Measured&parse.MBPerS != 0 {
+		properties = append(properties, junitProperty{Name: "MBPerS", Value: formatJUnitNumber(benchmark.MBPerS)})
+	}
+	return properties
+}
+
+// junitSystemOut provides the benchmark result lines of the samples, as output by `go test`.
+func junitSystemOut(samples BenchmarkSamples) string {
+	lines := make([]string, 0, len(samples.Benchmarks))
+	for _, benchmark := range samples.Benchmarks {
+		lines = append(lines, benchmark.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatJUnitTime formats a duration in seconds, to the millisecond precision used by JUnit reports.
+func formatJUnitTime(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
+
+// formatJUnitNumber formats a property value.
+func formatJUnitNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatPercentage formats a fraction as a percentage, to at most two decimal places.
+func formatPercentage(fraction float64) string {
+	if math.IsInf(fraction, 1) {
+		return "an infinite amount"
+	}
+	return formatNumber(fraction*100) + "%"
+}
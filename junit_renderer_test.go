@@ -0,0 +1,217 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"testing"
+)
+
+func TestJUnitRenderer_Render(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Fast-8", N: 1000000, NsPerOp: 100, AllocsPerOp: 1, Measured: parse.NsPerOp | parse.AllocsPerOp},
+		{Name: "BenchmarkOne/Slow-8", N: 1000, NsPerOp: 2000, AllocsPerOp: 4, Measured: parse.NsPerOp | parse.AllocsPerOp},
+	}
+	baseline := []parse.Benchmark{
+		{Name: "BenchmarkOne/Fast-8", NsPerOp: 98},
+		{Name: "BenchmarkOne/Slow-8", NsPerOp: 1000},
+	}
+
+	tests := []struct {
+		name     string
+		renderer JUnitRenderer
+		// wantFailures holds the type of each failure of each test case.
+		wantFailures [][]string
+	}{
+		{
+			name:         "no comparison",
+			renderer:     JUnitRenderer{},
+			wantFailures: [][]string{nil, nil},
+		},
+		{
+			name:         "regression",
+			renderer:     JUnitRenderer{Baseline: baseline, Tolerance: 0.05},
+			wantFailures: [][]string{nil, {"regression"}},
+		},
+		{
+			name:         "threshold",
+			renderer:     JUnitRenderer{Threshold: 50},
+			wantFailures: [][]string{{"threshold"}, {"threshold"}},
+		},
+		{
+			name:         "regression and threshold",
+			renderer:     JUnitRenderer{Baseline: baseline, Tolerance: 0.05, Threshold: 1500},
+			wantFailures: [][]string{nil, {"regression", "threshold"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := test.renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+			if err != nil {
+				t.Fatalf("Error rendering JUnit: %v", err)
+			}
+
+			var report junitTestSuites
+			err = xml.Unmarshal(output.Bytes(), &report)
+			if err != nil {
+				t.Fatalf("Could not decode JUnit report - error: %v", err)
+			}
+
+			if len(report.Suites) != 1 {
+				t.Fatalf("Wanted 1 test suite, got %d", len(report.Suites))
+			}
+			suite := report.Suites[0]
+			if suite.Name != "BenchmarkOne" {
+				t.Errorf("Wanted suite name %q, got %q", "BenchmarkOne", suite.Name)
+			}
+			if report.Tests != len(benchmarks) || suite.Tests != len(benchmarks) {
+				t.Errorf("Wanted %d tests, got %d (suite %d)", len(benchmarks), report.Tests, suite.Tests)
+			}
+
+			var gotFailures [][]string
+			var failed int
+			for _, testCase := range suite.Cases {
+				var types []string
+				for _, failure := range testCase.Failures {
+					types = append(types, failure.Type)
+				}
+				if types != nil {
+					failed++
+				}
+				gotFailures = append(gotFailures, types)
+			}
+			if !reflect.DeepEqual(test.wantFailures, gotFailures) {
+				t.Errorf("Wanted failures %v, got %v", test.wantFailures, gotFailures)
+			}
+			if report.Failures != failed || suite.Failures != failed {
+				t.Errorf("Wanted %d failures, got %d (suite %d)", failed, report.Failures, suite.Failures)
+			}
+		})
+	}
+}
+
+func TestJUnitRenderer_RenderTestCase(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Sub-8", N: 1000, NsPerOp: 2000, AllocsPerOp: 4, Measured: parse.NsPerOp | parse.AllocsPerOp},
+	}
+	baseline := []parse.Benchmark{
+		{Name: "BenchmarkOne/Sub-8", NsPerOp: 1000},
+	}
+
+	renderer := JUnitRenderer{Baseline: baseline, Tolerance: 0.05}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering JUnit: %v", err)
+	}
+
+	var report junitTestSuites
+	err = xml.Unmarshal(output.Bytes(), &report)
+	if err != nil {
+		t.Fatalf("Could not decode JUnit report - error: %v", err)
+	}
+
+	want := junitTestCase{
+		Name:      "Sub-8",
+		ClassName: "BenchmarkOne",
+		Time:      "0.002",
+		Properties: []junitProperty{
+			{Name: "N", Value: "1000"},
+			{Name: "Samples", Value: "1"},
+			{Name: "NsPerOp", Value: "2000"},
+			{Name: "AllocsPerOp", Value: "4"},
+			{Name: "Baseline", Value: "1000"},
+			{Name: "Change", Value: "1"},
+		},
+		Failures: []junitFailure{
+			{
+				Message: "Time per op regressed by 100% from the baseline, beyond the 5% tolerance",
+				Type:    "regression",
+				Text:    "baseline 1µs, current 2µs",
+			},
+		},
+		SystemOut: "BenchmarkOne/Sub-8 1000 2000.00 ns/op 4 allocs/op",
+	}
+	got := report.Suites[0].Cases[0]
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestJUnitRenderer_RenderPackages(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Sub", N: 1000, NsPerOp: 100, Measured: parse.NsPerOp, Ord: 0},
+		{Name: "BenchmarkOne/Sub", N: 1000, NsPerOp: 300, Measured: parse.NsPerOp, Ord: 1},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a"},
+		1: {"pkg": "example.com/b"},
+	}
+	baseline := []parse.Benchmark{
+		{Name: "BenchmarkOne/Sub", NsPerOp: 100, Ord: 0},
+		{Name: "BenchmarkOne/Sub", NsPerOp: 100, Ord: 1},
+	}
+
+	renderer := JUnitRenderer{Configs: configs, Baseline: baseline, BaselineConfigs: configs, Tolerance: 0.05}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering JUnit: %v", err)
+	}
+
+	var report junitTestSuites
+	err = xml.Unmarshal(output.Bytes(), &report)
+	if err != nil {
+		t.Fatalf("Could not decode JUnit report - error: %v", err)
+	}
+
+	cases := report.Suites[0].Cases
+	if len(cases) != 2 {
+		t.Fatalf("Wanted 2 test cases, got %d", len(cases))
+	}
+	wantClassNames := []string{"example.com/a.BenchmarkOne", "example.com/b.BenchmarkOne"}
+	wantFailures := []int{0, 1}
+	for i, testCase := range cases {
+		if testCase.ClassName != wantClassNames[i] {
+			t.Errorf("Wanted class name %q, got %q", wantClassNames[i], testCase.ClassName)
+		}
+		if len(testCase.Failures) != wantFailures[i] {
+			t.Errorf("Wanted %d failures for %s, got %d", wantFailures[i], testCase.ClassName, len(testCase.Failures))
+		}
+	}
+}
+
+func TestJUnitRenderer_RenderErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		dimension  RenderDimension
+		benchmarks []parse.Benchmark
+		wantErr    error
+	}{
+		{
+			name:       "no benchmarks",
+			dimension:  RenderNsPerOp,
+			benchmarks: nil,
+			wantErr:    ErrNoBenchmarksProvided,
+		},
+		{
+			name:       "unknown dimension",
+			dimension:  RenderDimension(1000),
+			benchmarks: []parse.Benchmark{{Name: "BenchmarkOne/Sub", NsPerOp: 100}},
+			wantErr:    ErrUnknownDimensionType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			renderer := JUnitRenderer{}
+			var output bytes.Buffer
+			err := renderer.Render(&output, "BenchmarkOne", test.dimension, test.benchmarks)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Wanted error '%v', got error '%v'", test.wantErr, err)
+			}
+		})
+	}
+}
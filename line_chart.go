@@ -0,0 +1,380 @@
+package go_benchpress
+
+import (
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	"io"
+	"math"
+	"strconv"
+)
+
+const (
+	// lineLegendSwatchWidth is the length of the line drawn beside each series name in the legend.
+	lineLegendSwatchWidth = 20
+	// lineLegendGap is the space between the grid, the legend swatches and their names.
+	lineLegendGap = 8
+	// lineFitSteps is the number of segments drawn between neighbouring X values, for fitted complexity curves.
+	lineFitSteps = 8
+)
+
+// lineSeries is a single series plotted on a line chart, with a value at each X position.
+type lineSeries struct {
+	name string
+	// values holds the value at each X position of the chart - NaN where the series has no point.
+	values []float64
+}
+
+// newLineSeries provides the series of the pivot, with the samples of each point combined into their mean.
+func newLineSeries(pivot Pivot, dimension Dimension) ([]lineSeries, error) {
+	results := make([]lineSeries, 0, len(pivot.Series))
+	for _, series := range pivot.Series {
+		values := make([]float64, 0, len(series.Points))
+		for _, point := range series.Points {
+			if len(point.Benchmarks) == 0 {
+				values = append(values, math.NaN())
+				continue
+			}
+			value, err := dimension.Value(point.Mean())
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		results = append(results, lineSeries{name: series.Name, values: values})
+	}
+	return results, nil
+}
+
+// lineChart plots a line for each series of a pivot, across its X values.
+type lineChart struct {
+	title     string
+	width     int
+	height    int
+	pivot     Pivot
+	series    []lineSeries
+	dimension Dimension
+	palette   chart.ColorPalette
+	logScale  bool
+	// subtitle is drawn beneath the title, if not empty.
+	subtitle string
+	// fits holds the complexity models fitted to the series, keyed by series name - each drawn as a dashed curve, and
+	// noted in the legend.
+	fits map[string]ComplexityFit
+}
+
+// xPositions provides the position of each X value across the grid.  Numeric values are placed by their value (on a
+// logarithmic scale, if used), and others are evenly spaced.
+func (l lineChart) xPositions(grid chart.Box) []int {
+	positions := make([]int, 0, len(l.pivot.X))
+	if !l.pivot.Numeric || len(l.pivot.X) == 1 {
+		step := float64(grid.Width()) / float64(len(l.pivot.X))
+		for index := range l.pivot.X {
+			positions = append(positions, grid.Left+int(step*(float64(index)+0.5)))
+		}
+		return positions
+	}
+
+	values := make([]float64, 0, len(l.pivot.X))
+	for _, x := range l.pivot.X {
+		value, _ := strconv.ParseFloat(x, 64)
+		values = append(values, value)
+	}
+	scale := func(value float64) float64 {
+		return value
+	}
+	if l.logScale && values[0] > 0 {
+		scale = math.Log10
+	}
+	first, last := scale(values[0]), scale(values[len(values)-1])
+
+	// Leave a margin, so the extreme points are not drawn on the edges of the grid.
+	margin := grid.Width() / 20
+	width := float64(grid.Width() - 2*margin)
+	for _, value := range values {
+		offset := 0.0
+		if last > first {
+			offset = (scale(value) - first) / (last - first) * width
+		}
+		positions = append(positions, grid.Left+margin+int(offset))
+	}
+	return positions
+}
+
+// valueRange provides the range of the value axis.  Linear axes start from zero, so that the heights of the points
+// reflect their ratios.
+func (l lineChart) valueRange() chart.Range {
+	var values []float64
+	for _, series := range l.series {
+		for _, value := range series.values {
+			if !math.IsNaN(value) {
+				values = append(values, value)
+			}
+		}
+	}
+	if reference, ok := l.dimension.reference(); ok {
+		values = append(values, reference)
+	}
+	if l.logScale {
+		return newLogarithmicRange(values)
+	}
+
+	max := 0.0
+	for _, value := range values {
+		max = math.Max(max, value)
+	}
+	max *= 1.05
+	if max == 0 {
+		max = 1
+	}
+	return &chart.ContinuousRange{Min: 0, Max: max}
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+func (l lineChart) Render(rp chart.RendererProvider, w io.Writer) error {
+	if len(l.series) == 0 || len(l.pivot.X) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	r, err := rp(l.width, l.height)
+	if err != nil {
+		return err
+	}
+	font, err := chart.GetDefaultFont()
+	if err != nil {
+		return err
+	}
+
+	textStyle := chart.Style{
+		Font:      font,
+		FontSize:  chart.DefaultAxisFontSize,
+		FontColor: l.palette.TextColor(),
+	}
+	lineStyle := chart.Style{
+		StrokeColor: l.palette.AxisStrokeColor(),
+		StrokeWidth: 1,
+	}
+
+	chart.Draw.Box(r, chart.Box{Right: l.width, Bottom: l.height}, chart.Style{
+		FillColor:   l.palette.BackgroundColor(),
+		StrokeColor: l.palette.BackgroundStrokeColor(),
+		StrokeWidth: chart.DefaultStrokeWidth,
+	})
+
+	if l.title != "" {
+		titleStyle := textStyle
+		titleStyle.FontSize = 18
+		titleStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(l.title)
+		r.Text(l.title, l.width>>1-box.Width()>>1, chart.DefaultTitleTop+box.Height())
+	}
+	drawSubtitle(r, textStyle, l.subtitle, l.width)
+
+	// The legend is drawn to the right of the grid, so its width is measured first.
+	textStyle.WriteTextOptionsToRenderer(r)
+	var legendWidth int
+	for _, series := range l.series {
+		legendWidth = max(legendWidth, r.MeasureText(l.seriesName(series)).Width())
+	}
+	legendWidth += lineLegendSwatchWidth + 2*lineLegendGap
+
+	grid := chart.Box{
+		Top:    horizontalTitleHeight,
+		Left:   boxPlotAxisWidth,
+		Right:  max(l.width-horizontalPadding-legendWidth, boxPlotAxisWidth+1),
+		Bottom: l.height - horizontalAxisHeight,
+	}
+
+	yRange := l.valueRange()
+	yRange.SetDomain(grid.Height())
+	xPositions := l.xPositions(grid)
+
+	l.drawAxes(r, grid, xPositions, yRange, textStyle, lineStyle)
+	if reference, ok := l.dimension.reference(); ok {
+		y := grid.Bottom - yRange.Translate(reference)
+		drawReferenceLine(r, lineStyle, grid.Left, y, grid.Right, y)
+	}
+
+	for index, series := range l.series {
+		colour := l.palette.GetSeriesColor(index)
+
+		// Lines are broken where the series has no point.
+		(chart.Style{StrokeColor: colour, StrokeWidth: 2}).WriteDrawingOptionsToRenderer(r)
+		drawing := false
+		for position, value := range series.values {
+			if math.IsNaN(value) {
+				drawing = false
+				continue
+			}
+			x, y := xPositions[position], grid.Bottom-yRange.Translate(value)
+			if drawing {
+				r.LineTo(x, y)
+			} else {
+				r.MoveTo(x, y)
+				drawing = true
+			}
+		}
+		r.Stroke()
+
+		if fit, ok := l.fits[series.name]; ok {
+			l.drawFit(r, grid, xPositions, yRange, series, fit, colour)
+		}
+
+		pointStyle := chart.Style{StrokeColor: colour, StrokeWidth: 1.5, FillColor: colour}
+		for position, value := range series.values {
+			if math.IsNaN(value) {
+				continue
+			}
+			pointStyle.WriteDrawingOptionsToRenderer(r)
+			r.Circle(scatterPointRadius, xPositions[position], grid.Bottom-yRange.Translate(value))
+			r.FillStroke()
+		}
+	}
+
+	l.drawLegend(r, grid, textStyle)
+
+	return r.Save(w)
+}
+
+// seriesName provides the name of the series shown in the legend, along with its fitted complexity, if any.
+func (l lineChart) seriesName(series lineSeries) string {
+	name := series.name
+	if name == "" {
+		name = "(none)"
+	}
+	if fit, ok := l.fits[series.name]; ok {
+		name += " ~ " + fit.Model.Notation()
+	}
+	return name
+}
+
+// drawFit draws the complexity model fitted to the series as a dashed curve, between its first and last points.
+func (l lineChart) drawFit(r chart.Renderer, grid chart.Box, xPositions []int, yRange chart.Range, series lineSeries, fit ComplexityFit, colour drawing.Color) {
+	sizes, err := pivotSizes(l.pivot)
+	if err != nil {
+		return
+	}
+	first, last := -1, -1
+	for position, value := range series.values {
+		if !math.IsNaN(value) {
+			if first < 0 {
+				first = position
+			}
+			last = position
+		}
+	}
+	if first < 0 {
+		return
+	}
+
+	// Sizes are interpolated to match the spacing of the X values - in proportion to their values on linear scales,
+	// and to their logarithms otherwise, as sizes tend to grow geometrically.
+	geometric := !l.pivot.Numeric || (l.logScale && sizes[0] > 0)
+
+	(chart.Style{StrokeColor: colour, StrokeWidth: 1.5, StrokeDashArray: []float64{4, 3}}).WriteDrawingOptionsToRenderer(r)
+	drawing := false
+	for position := first; position <= last; position++ {
+		steps := lineFitSteps
+		if position == last {
+			steps = 1
+		}
+		for step := 0; step < steps; step++ {
+			x, size := float64(xPositions[position]), sizes[position]
+			if step > 0 {
+				t := float64(step) / float64(steps)
+				x += t * float64(xPositions[position+1]-xPositions[position])
+				if geometric {
+					size *= math.Pow(sizes[position+1]/size, t)
+				} else {
+					size += t * (sizes[position+1] - size)
+				}
+			}
+
+			value := fit.Value(size)
+			if l.logScale && value <= 0 {
+				drawing = false
+				continue
+			}
+			y := min(max(grid.Bottom-yRange.Translate(value), grid.Top), grid.Bottom)
+			if drawing {
+				r.LineTo(int(x), y)
+			} else {
+				r.MoveTo(int(x), y)
+				drawing = true
+			}
+		}
+	}
+	r.Stroke()
+}
+
+// drawAxes draws the X axis along the bottom and the value axis along the left of the grid, with their labels and
+// names.  X labels which would overlap the previous label are skipped.
+func (l lineChart) drawAxes(r chart.Renderer, grid chart.Box, xPositions []int, yRange chart.Range, textStyle, lineStyle chart.Style) {
+	lineStyle.WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(grid.Left, grid.Top)
+	r.LineTo(grid.Left, grid.Bottom)
+	r.LineTo(grid.Right, grid.Bottom)
+	r.Stroke()
+
+	var tickHeight int
+	lastRight := math.MinInt32
+	for index, label := range l.pivot.X {
+		x := xPositions[index]
+
+		lineStyle.WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(x, grid.Bottom)
+		r.LineTo(x, grid.Bottom+chart.DefaultVerticalTickHeight)
+		r.Stroke()
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(label)
+		tickHeight = max(tickHeight, box.Height())
+		left := x - box.Width()>>1
+		if left <= lastRight+lineLegendGap {
+			continue
+		}
+		r.Text(label, left, grid.Bottom+chart.DefaultXAxisMargin+box.Height())
+		lastRight = left + box.Width()
+	}
+
+	yName, yFormatter := axisLabels(l.dimension, yRange, l.logScale)
+	for _, tick := range (chart.YAxis{Range: yRange}).GetTicks(r, yRange, textStyle, yFormatter) {
+		tickY := grid.Bottom - yRange.Translate(tick.Value)
+
+		lineStyle.WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(grid.Left-chart.DefaultHorizontalTickWidth, tickY)
+		r.LineTo(grid.Left, tickY)
+		r.Stroke()
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(tick.Label)
+		r.Text(tick.Label, grid.Left-chart.DefaultYAxisMargin-box.Width(), tickY+box.Height()>>1)
+	}
+
+	textStyle.WriteTextOptionsToRenderer(r)
+	xBox := r.MeasureText(l.pivot.XTitle)
+	r.Text(l.pivot.XTitle, grid.Left+grid.Width()>>1-xBox.Width()>>1, grid.Bottom+2*chart.DefaultXAxisMargin+tickHeight+xBox.Height())
+	yBox := r.MeasureText(yName)
+	r.Text(yName, grid.Left-yBox.Width()>>1, grid.Top-chart.DefaultYAxisMargin-yBox.Height()>>1)
+}
+
+// drawLegend lists the series beside the top-right corner of the grid, each with a sample of its line.
+func (l lineChart) drawLegend(r chart.Renderer, grid chart.Box, textStyle chart.Style) {
+	y := grid.Top
+	for index, series := range l.series {
+		colour := l.palette.GetSeriesColor(index)
+		name := l.seriesName(series)
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(name)
+		left := grid.Right + lineLegendGap
+
+		(chart.Style{StrokeColor: colour, StrokeWidth: 2}).WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(left, y)
+		r.LineTo(left+lineLegendSwatchWidth, y)
+		r.Stroke()
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		r.Text(name, left+lineLegendSwatchWidth+lineLegendGap, y+box.Height()>>1)
+		y += box.Height() + lineLegendGap
+	}
+}
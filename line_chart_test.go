@@ -0,0 +1,105 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewLineSeries(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=quick/size=10", NsPerOp: 100},
+		{Name: "BenchmarkSort/algo=quick/size=10", NsPerOp: 300},
+		{Name: "BenchmarkSort/algo=quick/size=100", NsPerOp: 400},
+		{Name: "BenchmarkSort/algo=radix/size=100", NsPerOp: 500},
+	}
+
+	got, err := newLineSeries(NewPivot(benchmarks, nil, nil, nil), RenderNsPerOp)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Wanted 2 series, got %d", len(got))
+	}
+	if got[0].name != "BenchmarkSort/algo=quick" || !reflect.DeepEqual([]float64{200, 400}, got[0].values) {
+		t.Errorf("Wanted quick sort series with the mean of each size, got %v", got[0])
+	}
+	if got[1].name != "BenchmarkSort/algo=radix" || !math.IsNaN(got[1].values[0]) || got[1].values[1] != 500 {
+		t.Errorf("Wanted radix sort series missing its first point, got %v", got[1])
+	}
+
+	_, err = newLineSeries(NewPivot(benchmarks, nil, nil, nil), RenderDimension(1000))
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+}
+
+func TestLineChart_Render(t *testing.T) {
+	palette, err := LightTheme.palette()
+	if err != nil {
+		t.Fatalf("Could not create palette - error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		pivot Pivot
+	}{
+		{
+			name:  "numeric",
+			pivot: Pivot{XTitle: "size", X: []string{"10", "100", "1000"}, Numeric: true},
+		},
+		{
+			name:  "categorical",
+			pivot: Pivot{XTitle: "input", X: []string{"sorted", "reversed", "random"}},
+		},
+	}
+	series := []lineSeries{
+		{name: "quick", values: []float64{100, 1500, 20000}},
+		{name: "radix", values: []float64{300, math.NaN(), 9000}},
+	}
+
+	for _, test := range tests {
+		for _, logScale := range []bool{false, true} {
+			buf := bytes.Buffer{}
+			l := lineChart{
+				title:     "BenchmarkSort",
+				width:     1024,
+				height:    512,
+				pivot:     test.pivot,
+				series:    series,
+				dimension: RenderNsPerOp,
+				palette:   palette,
+				logScale:  logScale,
+			}
+			err = l.Render(chart.SVG, &buf)
+			if err != nil {
+				t.Fatalf("Could not render chart - error: %v", err)
+			}
+
+			xmlData := make([]interface{}, 0)
+			err = xml.Unmarshal(buf.Bytes(), &xmlData)
+			if err != nil {
+				t.Errorf("Error unmarshalling SVG as XML - error: %v", err)
+			}
+
+			wants := append([]string{"BenchmarkSort", "quick", "radix", "Time per op", test.pivot.XTitle}, test.pivot.X...)
+			for _, want := range wants {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Wanted %s SVG output to contain %q", test.name, want)
+				}
+			}
+		}
+	}
+
+	err = lineChart{}.Render(chart.SVG, &bytes.Buffer{})
+	if !errors.Is(err, ErrNoBenchmarksProvided) {
+		t.Errorf("Want error '%v', got error '%v'", ErrNoBenchmarksProvided, err)
+	}
+}
@@ -0,0 +1,331 @@
+package go_benchpress
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/benchmark/parse"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// BenchmarkLocation is the position of a benchmark within the source.
+type BenchmarkLocation struct {
+	// File is the path of the file, relative to the source directory and separated by forward slashes.
+	File string
+	Line int
+}
+
+// BenchmarkLocations holds the positions of benchmarks within the source, keyed by the import path of their package,
+// such as "example.com/cache", and then by name - either the name of a benchmark function, such as "BenchmarkSort",
+// or the full name of a sub-benchmark, such as "BenchmarkSort/quick".  The import path of a package outside any
+// module is its directory, relative to the source directory.
+type BenchmarkLocations map[string]map[string]BenchmarkLocation
+
+// LocateBenchmarks finds the benchmark functions within the test files of every package under the directory, along
+// with the sub-benchmarks they run using string literal names.  Sub-benchmarks whose names are computed, such as using
+// fmt.Sprintf, cannot be found.  The import path of each package is found from the go.mod of its module, at or above
+// its directory.  Vendored code, test data and hidden directories are skipped.
+func LocateBenchmarks(dir string) (BenchmarkLocations, error) {
+	results := make(BenchmarkLocations)
+	importPaths := make(map[string]string)
+	fset := token.NewFileSet()
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		relative = filepath.ToSlash(relative)
+
+		pkgDir := filepath.Dir(path)
+		pkg, ok := importPaths[pkgDir]
+		if !ok {
+			pkg, err = importPath(dir, pkgDir)
+			if err != nil {
+				return err
+			}
+			importPaths[pkgDir] = pkg
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !isBenchmarkFunc(fn) {
+				continue
+			}
+			results.add(pkg, fn.Name.Name, BenchmarkLocation{File: relative, Line: fset.Position(fn.Pos()).Line})
+			if fn.Body != nil {
+				results.addSubBenchmarks(fset, relative, pkg, fn.Name.Name, paramName(fn.Type), fn.Body)
+			}
+		}
+		return nil
+	})
+	return results, err
+}
+
+// Find provides the location of the benchmark result with the given name, such as "BenchmarkSort/quick-8", from the
+// package with the import path pkg - the result's "pkg" configuration.  Its location is that of its most specific
+// sub-benchmark found within the source, or else its benchmark function.  If the package was not located, such as
+// for results without a "pkg", the benchmark is found only if a single package defines it.  Whether the benchmark was
+// found is also provided.
+func (l BenchmarkLocations) Find(pkg, name string) (BenchmarkLocation, bool) {
+	if locations, ok := l[pkg]; ok {
+		return findLocation(locations, name)
+	}
+
+	var result BenchmarkLocation
+	found := 0
+	for _, locations := range l {
+		if location, ok := findLocation(locations, name); ok {
+			result = location
+			found++
+		}
+	}
+	if found != 1 {
+		return BenchmarkLocation{}, false
+	}
+	return result, true
+}
+
+// findLocation provides the location of the most specific sub-benchmark of the named result within the locations,
+// or else its benchmark function, and whether it was found.
+func findLocation(locations map[string]BenchmarkLocation, name string) (BenchmarkLocation, bool) {
+	name = trimProcs(name)
+	for {
+		if location, ok := locations[name]; ok {
+			return location, true
+		}
+		index := strings.LastIndex(name, "/")
+		if index < 0 {
+			return BenchmarkLocation{}, false
+		}
+		name = name[:index]
+	}
+}
+
+// MissingBenchmark is a benchmark found within the source which has no results.
+type MissingBenchmark struct {
+	// Package is the import path of the benchmark's package.
+	Package  string
+	Name     string
+	Location BenchmarkLocation
+}
+
+// Missing provides the benchmarks found within the source which have no results, in order of package and then name.
+// A benchmark has results if any of its sub-benchmarks do, in the package given by their configuration from configs.
+// Results from a package which was not located, such as those without a "pkg", count towards every package.
+func (l BenchmarkLocations) Missing(benchmarks []parse.Benchmark, configs BenchmarkConfigs) []MissingBenchmark {
+	found := make(map[string]map[string]bool)
+	for _, benchmark := range benchmarks {
+		pkg := configs[benchmark.Ord]["pkg"]
+		if _, ok := l[pkg]; !ok {
+			pkg = ""
+		}
+		if found[pkg] == nil {
+			found[pkg] = make(map[string]bool)
+		}
+
+		name := trimProcs(benchmark.Name)
+		for {
+			found[pkg][name] = true
+			index := strings.LastIndex(name, "/")
+			if index < 0 {
+				break
+			}
+			name = name[:index]
+		}
+	}
+
+	var missing []MissingBenchmark
+	for pkg, locations := range l {
+		for name, location := range locations {
+			if !found[pkg][name] && !found[""][name] {
+				missing = append(missing, MissingBenchmark{Package: pkg, Name: name, Location: location})
+			}
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Package != missing[j].Package {
+			return missing[i].Package < missing[j].Package
+		}
+		return missing[i].Name < missing[j].Name
+	})
+	return missing
+}
+
+// add records the location of the benchmark within the package, unless one of the same name has already been found.
+func (l BenchmarkLocations) add(pkg, name string, location BenchmarkLocation) {
+	if l[pkg] == nil {
+		l[pkg] = make(map[string]BenchmarkLocation)
+	}
+	if _, ok := l[pkg][name]; !ok {
+		l[pkg][name] = location
+	}
+}
+
+// addSubBenchmarks records the sub-benchmarks run within the body by calls to Run on the *testing.B named param, and
+// those they run in turn.
+func (l BenchmarkLocations) addSubBenchmarks(fset *token.FileSet, file, pkg, parent, param string, body *ast.BlockStmt) {
+	if param == "" || param == "_" {
+		return
+	}
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || selector.Sel.Name != "Run" {
+			return true
+		}
+		receiver, ok := selector.X.(*ast.Ident)
+		if !ok || receiver.Name != param {
+			return true
+		}
+
+		// The names of sub-benchmarks with computed names are unknown, as are those of anything they run.
+		literal, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return false
+		}
+		subName, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			return false
+		}
+
+		name := parent + "/" + rewriteSubBenchmarkName(subName)
+		l.add(pkg, name, BenchmarkLocation{File: file, Line: fset.Position(call.Pos()).Line})
+		if fn, ok := call.Args[1].(*ast.FuncLit); ok {
+			l.addSubBenchmarks(fset, file, pkg, name, paramName(fn.Type), fn.Body)
+		}
+		return false
+	})
+}
+
+// importPath provides the import path of the package within the directory - its path within the module of the
+// nearest go.mod at or above the directory, or otherwise its path relative to root.
+func importPath(root, dir string) (string, error) {
+	absolute, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for moduleDir := absolute; ; {
+		if module, ok := modulePath(moduleDir); ok {
+			relative, err := filepath.Rel(moduleDir, absolute)
+			if err != nil {
+				return "", err
+			}
+			if relative == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(relative), nil
+		}
+
+		parent := filepath.Dir(moduleDir)
+		if parent == moduleDir {
+			break
+		}
+		moduleDir = parent
+	}
+
+	relative, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(relative), nil
+}
+
+// modulePath provides the module path declared by the go.mod within the directory, and whether there is one.
+func modulePath(dir string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), true
+		}
+	}
+	return "", false
+}
+
+// isBenchmarkFunc reports whether the function is a benchmark - named "Benchmark" followed by a name not starting
+// with a lower case letter, and taking a single *testing.B.
+func isBenchmarkFunc(fn *ast.FuncDecl) bool {
+	name := fn.Name.Name
+	if !strings.HasPrefix(name, "Benchmark") {
+		return false
+	}
+	if rest := name[len("Benchmark"):]; rest != "" && rest[0] >= 'a' && rest[0] <= 'z' {
+		return false
+	}
+
+	params := fn.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 {
+		return false
+	}
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	selector, ok := star.X.(*ast.SelectorExpr)
+	return ok && selector.Sel.Name == "B"
+}
+
+// paramName provides the name of the function's only parameter, or an empty string if it is unnamed.
+func paramName(fn *ast.FuncType) string {
+	params := fn.Params.List
+	if len(params) != 1 || len(params[0].Names) != 1 {
+		return ""
+	}
+	return params[0].Names[0].Name
+}
+
+// rewriteSubBenchmarkName rewrites the name passed to Run as `go test` does when naming the sub-benchmark - replacing
+// spaces with underscores, and escaping unprintable characters.
+func rewriteSubBenchmarkName(name string) string {
+	var builder strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			builder.WriteRune('_')
+		case !strconv.IsPrint(r):
+			quoted := strconv.QuoteRune(r)
+			builder.WriteString(quoted[1 : len(quoted)-1])
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// trimProcs removes the GOMAXPROCS suffix added to the benchmark name by `go test`, if it has one.
+func trimProcs(name string) string {
+	if ParseBenchmarkName(name).Procs > 0 {
+		return name[:strings.LastIndex(name, "-")]
+	}
+	return name
+}
@@ -0,0 +1,280 @@
+package go_benchpress
+
+import (
+	"golang.org/x/tools/benchmark/parse"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeSourceFiles writes the files, keyed by path, within a temporary directory - returning the directory.
+func writeSourceFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatalf("Could not create source directory - error: %v", err)
+		}
+		err = os.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("Could not write source file - error: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestLocateBenchmarks(t *testing.T) {
+	dir := writeSourceFiles(t, map[string]string{
+		"cache/cache_test.go": `package cache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkGet(b *testing.B) {}
+
+func BenchmarkPut(b *testing.B) {
+	b.Run("size=1024", func(b *testing.B) {
+		b.Run("hot cache", func(sub *testing.B) {})
+	})
+	for _, size := range []int{1, 2} {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			b.Run("unknown", func(b *testing.B) {})
+		})
+	}
+}
+
+func BenchmarkDelete(_ *testing.B) {}
+
+func Benchmarkhelper(b *testing.B) {}
+
+func BenchmarkNotABenchmark(t *testing.T) {
+	t.Run("test", func(t *testing.T) {})
+}
+
+func helper() {}
+`,
+		"cache/cache.go": `package cache
+
+import "testing"
+
+func BenchmarkInSource(b *testing.B) {}
+`,
+		"vendor/other/other_test.go": `package other
+
+import "testing"
+
+func BenchmarkVendored(b *testing.B) {}
+`,
+		"root_test.go": `package root
+
+import "testing"
+
+func Benchmark(b *testing.B) {}
+`,
+	})
+
+	got, err := LocateBenchmarks(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Outside any module, packages are identified by their directory.
+	want := BenchmarkLocations{
+		"cache": {
+			"BenchmarkGet":                     {File: "cache/cache_test.go", Line: 8},
+			"BenchmarkPut":                     {File: "cache/cache_test.go", Line: 10},
+			"BenchmarkPut/size=1024":           {File: "cache/cache_test.go", Line: 11},
+			"BenchmarkPut/size=1024/hot_cache": {File: "cache/cache_test.go", Line: 12},
+			"BenchmarkDelete":                  {File: "cache/cache_test.go", Line: 21},
+		},
+		".": {
+			"Benchmark": {File: "root_test.go", Line: 5},
+		},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestLocateBenchmarks_Modules(t *testing.T) {
+	dir := writeSourceFiles(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.24\n",
+		"cache/cache_test.go": `package cache
+
+import "testing"
+
+func BenchmarkGet(b *testing.B) {}
+`,
+		"store/store_test.go": `package store_test
+
+import "testing"
+
+func BenchmarkGet(b *testing.B) {}
+`,
+		"tools/go.mod": "module \"example.com/tools\"\n",
+		"tools/tools_test.go": `package tools
+
+import "testing"
+
+func BenchmarkGet(b *testing.B) {}
+`,
+	})
+
+	got, err := LocateBenchmarks(filepath.Join(dir, "store"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := BenchmarkLocations{
+		"example.com/shop/store": {"BenchmarkGet": {File: "store_test.go", Line: 5}},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	got, err = LocateBenchmarks(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want = BenchmarkLocations{
+		"example.com/shop/cache": {"BenchmarkGet": {File: "cache/cache_test.go", Line: 5}},
+		"example.com/shop/store": {"BenchmarkGet": {File: "store/store_test.go", Line: 5}},
+		"example.com/tools":      {"BenchmarkGet": {File: "tools/tools_test.go", Line: 5}},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestLocateBenchmarks_InvalidSource(t *testing.T) {
+	dir := writeSourceFiles(t, map[string]string{
+		"broken_test.go": "package broken\n\nfunc BenchmarkBroken(",
+	})
+
+	_, err := LocateBenchmarks(dir)
+	if err == nil {
+		t.Error("Wanted error parsing invalid source")
+	}
+}
+
+func TestBenchmarkLocations_Find(t *testing.T) {
+	locations := BenchmarkLocations{
+		"example.com/sort": {
+			"BenchmarkSort":       {File: "sort/sort_test.go", Line: 10},
+			"BenchmarkSort/quick": {File: "sort/sort_test.go", Line: 12},
+		},
+		"example.com/slices": {
+			"BenchmarkSort":  {File: "slices/sort_test.go", Line: 20},
+			"BenchmarkIndex": {File: "slices/index_test.go", Line: 5},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		pkg       string
+		benchmark string
+		want      BenchmarkLocation
+		wantFound bool
+	}{
+		{
+			name:      "function",
+			pkg:       "example.com/sort",
+			benchmark: "BenchmarkSort-8",
+			want:      BenchmarkLocation{File: "sort/sort_test.go", Line: 10},
+			wantFound: true,
+		},
+		{
+			name:      "function of another package",
+			pkg:       "example.com/slices",
+			benchmark: "BenchmarkSort-8",
+			want:      BenchmarkLocation{File: "slices/sort_test.go", Line: 20},
+			wantFound: true,
+		},
+		{
+			name:      "sub-benchmark",
+			pkg:       "example.com/sort",
+			benchmark: "BenchmarkSort/quick-8",
+			want:      BenchmarkLocation{File: "sort/sort_test.go", Line: 12},
+			wantFound: true,
+		},
+		{
+			name:      "nested sub-benchmark of a located sub-benchmark",
+			pkg:       "example.com/sort",
+			benchmark: "BenchmarkSort/quick/size=10",
+			want:      BenchmarkLocation{File: "sort/sort_test.go", Line: 12},
+			wantFound: true,
+		},
+		{
+			name:      "unlocated sub-benchmark",
+			pkg:       "example.com/sort",
+			benchmark: "BenchmarkSort/radix-8",
+			want:      BenchmarkLocation{File: "sort/sort_test.go", Line: 10},
+			wantFound: true,
+		},
+		{
+			name:      "unlocated benchmark",
+			pkg:       "example.com/sort",
+			benchmark: "BenchmarkIndex-8",
+			wantFound: false,
+		},
+		{
+			name:      "unlocated package defined once",
+			benchmark: "BenchmarkIndex-8",
+			want:      BenchmarkLocation{File: "slices/index_test.go", Line: 5},
+			wantFound: true,
+		},
+		{
+			name:      "unlocated package defined several times",
+			pkg:       "example.com/other",
+			benchmark: "BenchmarkSort-8",
+			wantFound: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, found := locations.Find(test.pkg, test.benchmark)
+			if test.wantFound != found || test.want != got {
+				t.Errorf("want %v (found %v), got %v (found %v)", test.want, test.wantFound, got, found)
+			}
+		})
+	}
+}
+
+func TestBenchmarkLocations_Missing(t *testing.T) {
+	locations := BenchmarkLocations{
+		"example.com/sort": {
+			"BenchmarkSort":         {File: "sort_test.go", Line: 10},
+			"BenchmarkSort/quick":   {File: "sort_test.go", Line: 12},
+			"BenchmarkSort/radix":   {File: "sort_test.go", Line: 13},
+			"BenchmarkSearch":       {File: "search_test.go", Line: 5},
+			"BenchmarkSearch/small": {File: "search_test.go", Line: 6},
+			"BenchmarkInsert":       {File: "insert_test.go", Line: 5},
+		},
+		"example.com/slices": {
+			"BenchmarkSort":   {File: "slices/sort_test.go", Line: 20},
+			"BenchmarkInsert": {File: "slices/insert_test.go", Line: 5},
+		},
+	}
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/quick/size=10-8", Ord: 0},
+		{Name: "BenchmarkInsert-8", Ord: 1},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/sort"},
+	}
+
+	got := locations.Missing(benchmarks, configs)
+	// The results without a package count towards every package.
+	want := []MissingBenchmark{
+		{Package: "example.com/slices", Name: "BenchmarkSort", Location: BenchmarkLocation{File: "slices/sort_test.go", Line: 20}},
+		{Package: "example.com/sort", Name: "BenchmarkSearch", Location: BenchmarkLocation{File: "search_test.go", Line: 5}},
+		{Package: "example.com/sort", Name: "BenchmarkSearch/small", Location: BenchmarkLocation{File: "search_test.go", Line: 6}},
+		{Package: "example.com/sort", Name: "BenchmarkSort/radix", Location: BenchmarkLocation{File: "sort_test.go", Line: 13}},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
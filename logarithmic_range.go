@@ -0,0 +1,167 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+)
+
+// logScaleSuggestionRatio is the ratio between the largest and smallest values, above which a logarithmic scale
+// is suggested - three orders of magnitude.
+const logScaleSuggestionRatio = 1000
+
+// SuggestLogScale reports whether the benchmarks span enough orders of magnitude, in the provided dimension, that
+// the smaller values would be difficult to see on a linear scale.  If the dimension is unknown, an error is returned.
+func SuggestLogScale(dimension Dimension, benchmarks []parse.Benchmark) (bool, error) {
+	values := make([]float64, 0, len(benchmarks))
+	for _, benchmark := range benchmarks {
+		value, err := dimension.Value(benchmark)
+		if err != nil {
+			return false, err
+		}
+		values = append(values, value)
+	}
+
+	min, max := positiveBounds(values)
+	if min == 0 {
+		return false, nil
+	}
+	return max/min > logScaleSuggestionRatio, nil
+}
+
+// positiveBounds returns the smallest and largest positive values.  If there are no positive values, both are zero.
+func positiveBounds(values []float64) (min, max float64) {
+	for _, value := range values {
+		if value <= 0 {
+			continue
+		}
+		if min == 0 || value < min {
+			min = value
+		}
+		if value > max {
+			max = value
+		}
+	}
+	return min, max
+}
+
+// logarithmicRange is a go-chart Range which maps values onto a base 10 logarithmic scale.  The bounds are widened
+// to whole powers of ten, so that the smallest value remains visible when drawn as a bar.
+type logarithmicRange struct {
+	Min        float64
+	Max        float64
+	Domain     int
+	Descending bool
+}
+
+// newLogarithmicRange creates a logarithmic range covering the positive values provided.
+func newLogarithmicRange(values []float64) *logarithmicRange {
+	min, max := positiveBounds(values)
+	return &logarithmicRange{
+		Min: min,
+		Max: max,
+	}
+}
+
+// IsDescending returns if the range is descending.
+func (r logarithmicRange) IsDescending() bool {
+	return r.Descending
+}
+
+// IsZero returns if the range has been set or not.
+func (r logarithmicRange) IsZero() bool {
+	return r.Min == 0 && r.Max == 0 && r.Domain == 0
+}
+
+// GetMin returns the lower bound of the range - the power of ten below the smallest value.
+func (r logarithmicRange) GetMin() float64 {
+	if r.Min <= 0 {
+		return 1
+	}
+	return math.Pow(10, math.Ceil(math.Log10(r.Min))-1)
+}
+
+// SetMin sets the smallest value within the range.
+func (r *logarithmicRange) SetMin(min float64) {
+	r.Min = min
+}
+
+// GetMax returns the upper bound of the range - the power of ten at or above the largest value.
+func (r logarithmicRange) GetMax() float64 {
+	min := r.GetMin()
+	if r.Max <= min {
+		return min * 10
+	}
+	return math.Pow(10, math.Ceil(math.Log10(r.Max)))
+}
+
+// SetMax sets the largest value within the range.
+func (r *logarithmicRange) SetMax(max float64) {
+	r.Max = max
+}
+
+// GetDelta returns the difference between the bounds of the range.
+func (r logarithmicRange) GetDelta() float64 {
+	return r.GetMax() - r.GetMin()
+}
+
+// GetDomain returns the range domain.
+func (r logarithmicRange) GetDomain() int {
+	return r.Domain
+}
+
+// SetDomain sets the range domain.
+func (r *logarithmicRange) SetDomain(domain int) {
+	r.Domain = domain
+}
+
+// String returns a simple string for the range.
+func (r logarithmicRange) String() string {
+	return fmt.Sprintf("LogarithmicRange [%.2f,%.2f] => %d", r.GetMin(), r.GetMax(), r.Domain)
+}
+
+// Translate maps a given value into the range's domain.  Values at or below zero map to the bottom of the domain.
+func (r logarithmicRange) Translate(value float64) int {
+	ratio := 0.0
+	if value > 0 {
+		logMin := math.Log10(r.GetMin())
+		ratio = (math.Log10(value) - logMin) / (math.Log10(r.GetMax()) - logMin)
+		ratio = math.Max(0, math.Min(1, ratio))
+	}
+
+	if r.IsDescending() {
+		return r.Domain - int(math.Ceil(ratio*float64(r.Domain)))
+	}
+	return int(math.Ceil(ratio * float64(r.Domain)))
+}
+
+// GetTicks provides a tick for each power of ten within the range.  Where the range covers only a couple of powers
+// of ten, intermediate ticks are added at 2x and 5x each power.
+func (r logarithmicRange) GetTicks(_ chart.Renderer, _ chart.Style, vf chart.ValueFormatter) []chart.Tick {
+	if vf == nil {
+		vf = chart.FloatValueFormatter
+	}
+
+	minExponent := int(math.Round(math.Log10(r.GetMin())))
+	maxExponent := int(math.Round(math.Log10(r.GetMax())))
+	multiples := []float64{1}
+	if maxExponent-minExponent <= 2 {
+		multiples = []float64{1, 2, 5}
+	}
+
+	var ticks []chart.Tick
+	for exponent := minExponent; exponent <= maxExponent; exponent++ {
+		for _, multiple := range multiples {
+			value := multiple * math.Pow(10, float64(exponent))
+			if exponent == maxExponent && multiple > 1 {
+				break
+			}
+			ticks = append(ticks, chart.Tick{
+				Value: value,
+				Label: vf(value),
+			})
+		}
+	}
+	return ticks
+}
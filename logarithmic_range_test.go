@@ -0,0 +1,198 @@
+package go_benchpress
+
+import (
+	"errors"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"testing"
+)
+
+// ===== SuggestLogScale tests =====
+
+func TestSuggestLogScale(t *testing.T) {
+	tests := []struct {
+		name       string
+		dimension  RenderDimension
+		benchmarks []parse.Benchmark
+		want       bool
+		wantErr    error
+	}{
+		{
+			name:      "narrow range",
+			dimension: RenderNsPerOp,
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/10", NsPerOp: 10},
+				{Name: "BenchmarkOne/100", NsPerOp: 900},
+			},
+			want: false,
+		},
+		{
+			name:      "wide range",
+			dimension: RenderNsPerOp,
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/10", NsPerOp: 10},
+				{Name: "BenchmarkOne/10000000", NsPerOp: 10000000},
+			},
+			want: true,
+		},
+		{
+			name:      "zero values ignored",
+			dimension: RenderAllocsPerOp,
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/10", AllocsPerOp: 0},
+				{Name: "BenchmarkOne/100", AllocsPerOp: 2},
+			},
+			want: false,
+		},
+		{
+			name:      "unknown dimension",
+			dimension: RenderDimension(1000),
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/10", NsPerOp: 10},
+			},
+			wantErr: ErrUnknownDimensionType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := SuggestLogScale(test.dimension, test.benchmarks)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+// ===== logarithmicRange tests =====
+
+func TestLogarithmicRange_Bounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []float64
+		wantMin float64
+		wantMax float64
+	}{
+		{
+			name:    "values between powers of ten",
+			values:  []float64{15, 1500},
+			wantMin: 10,
+			wantMax: 10000,
+		},
+		{
+			name:    "values at powers of ten",
+			values:  []float64{10, 1000},
+			wantMin: 1,
+			wantMax: 1000,
+		},
+		{
+			name:    "single value",
+			values:  []float64{5},
+			wantMin: 1,
+			wantMax: 10,
+		},
+		{
+			name:    "non positive values ignored",
+			values:  []float64{0, -5, 200},
+			wantMin: 100,
+			wantMax: 1000,
+		},
+		{
+			name:    "no positive values",
+			values:  []float64{0},
+			wantMin: 1,
+			wantMax: 10,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newLogarithmicRange(test.values)
+			if test.wantMin != r.GetMin() {
+				t.Errorf("Want min %v, got min %v", test.wantMin, r.GetMin())
+			}
+			if test.wantMax != r.GetMax() {
+				t.Errorf("Want max %v, got max %v", test.wantMax, r.GetMax())
+			}
+		})
+	}
+}
+
+func TestLogarithmicRange_Translate(t *testing.T) {
+	r := newLogarithmicRange([]float64{15, 1500})
+	r.SetDomain(300)
+
+	tests := []struct {
+		name  string
+		value float64
+		want  int
+	}{
+		{name: "minimum", value: 10, want: 0},
+		{name: "one decade", value: 100, want: 100},
+		{name: "two decades", value: 1000, want: 200},
+		{name: "maximum", value: 10000, want: 300},
+		{name: "zero", value: 0, want: 0},
+		{name: "above maximum", value: 1000000, want: 300},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := r.Translate(test.value)
+			if test.want != got {
+				t.Errorf("want %d, got %d", test.want, got)
+			}
+		})
+	}
+
+	t.Run("descending", func(t *testing.T) {
+		descending := *r
+		descending.Descending = true
+		got := descending.Translate(100)
+		if got != 200 {
+			t.Errorf("want %d, got %d", 200, got)
+		}
+	})
+}
+
+func TestLogarithmicRange_GetTicks(t *testing.T) {
+	formatter := func(v interface{}) string {
+		return chart.FloatValueFormatterWithFormat(v, "%.0f")
+	}
+
+	tests := []struct {
+		name   string
+		values []float64
+		want   []chart.Tick
+	}{
+		{
+			name:   "wide range",
+			values: []float64{15, 15000},
+			want: []chart.Tick{
+				{Value: 10, Label: "10"},
+				{Value: 100, Label: "100"},
+				{Value: 1000, Label: "1000"},
+				{Value: 10000, Label: "10000"},
+				{Value: 100000, Label: "100000"},
+			},
+		},
+		{
+			name:   "narrow range",
+			values: []float64{15, 50},
+			want: []chart.Tick{
+				{Value: 10, Label: "10"},
+				{Value: 20, Label: "20"},
+				{Value: 50, Label: "50"},
+				{Value: 100, Label: "100"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := newLogarithmicRange(test.values).GetTicks(nil, chart.Style{}, formatter)
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"math"
+	"strings"
+)
+
+// MermaidRenderer outputs a Mermaid xychart definition of the benchmarks, matching the RasterRenderer's bar chart.
+// Mermaid charts are rendered natively within Markdown by GitHub, and diff cleanly as text.
+type MermaidRenderer struct {
+	Title string
+	// Line plots the benchmarks as a line, rather than bars.
+	Line bool
+}
+
+func (m *MermaidRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
+	if len(benchmarks) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	title := m.Title
+	if title == "" {
+		title = parentBenchmark
+	}
+
+	bars, axisTitle, err := newFigureBars(benchmarks, dimension, NoErrorBars)
+	if err != nil {
+		return err
+	}
+
+	labels := make([]string, 0, len(bars))
+	values := make([]string, 0, len(bars))
+	var max float64
+	for _, bar := range bars {
+		labels = append(labels, mermaidString(bar.label))
+		values = append(values, formatFigureNumber(bar.value))
+		max = math.Max(max, bar.value)
+	}
+
+	// Mermaid fits the value axis to the values, so it is fixed to start from zero, as on the RasterRenderer's chart.
+	if max <= 0 {
+		max = 1
+	}
+
+	mark := "bar"
+	if m.Line {
+		mark = "line"
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString("xychart-beta\n")
+	fmt.Fprintf(&buf, "    title %s\n", mermaidString(title))
+	fmt.Fprintf(&buf, "    x-axis [%s]\n", strings.Join(labels, ", "))
+	fmt.Fprintf(&buf, "    y-axis %s 0 --> %s\n", mermaidString(axisTitle), formatNumber(max*1.05))
+	fmt.Fprintf(&buf, "    %s [%s]\n", mark, strings.Join(values, ", "))
+
+	_, err = writer.Write(buf.Bytes())
+	return err
+}
+
+// mermaidString quotes the string for use within a Mermaid definition.  Mermaid strings cannot contain double
+// quotes, so any are replaced with single quotes.
+func mermaidString(str string) string {
+	return `"` + strings.ReplaceAll(str, `"`, `'`) + `"`
+}
@@ -0,0 +1,87 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"testing"
+)
+
+func TestMermaidRenderer_Render(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Small", NsPerOp: 500},
+		{Name: "BenchmarkOne/Large", NsPerOp: 2000},
+	}
+
+	tests := []struct {
+		name     string
+		renderer MermaidRenderer
+		want     string
+	}{
+		{
+			name:     "bar",
+			renderer: MermaidRenderer{},
+			want: `xychart-beta
+    title "BenchmarkOne"
+    x-axis ["Small", "Large"]
+    y-axis "Time per op (µs)" 0 --> 2.1
+    bar [0.5, 2]
+`,
+		},
+		{
+			name:     "line",
+			renderer: MermaidRenderer{Title: `The "Title"`, Line: true},
+			want: `xychart-beta
+    title "The 'Title'"
+    x-axis ["Small", "Large"]
+    y-axis "Time per op (µs)" 0 --> 2.1
+    line [0.5, 2]
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := test.renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+			if err != nil {
+				t.Fatalf("Error rendering Mermaid: %v", err)
+			}
+			got := output.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMermaidRenderer_RenderErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		dimension  RenderDimension
+		benchmarks []parse.Benchmark
+		wantErr    error
+	}{
+		{
+			name:       "no benchmarks",
+			dimension:  RenderNsPerOp,
+			benchmarks: nil,
+			wantErr:    ErrNoBenchmarksProvided,
+		},
+		{
+			name:       "unknown dimension",
+			dimension:  RenderDimension(1000),
+			benchmarks: []parse.Benchmark{{Name: "BenchmarkOne/Sub", NsPerOp: 100}},
+			wantErr:    ErrUnknownDimensionType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			renderer := MermaidRenderer{}
+			var output bytes.Buffer
+			err := renderer.Render(&output, "BenchmarkOne", test.dimension, test.benchmarks)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Wanted error '%v', got error '%v'", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package go_benchpress
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BenchmarkName is a benchmark name split into its parts.  For instance, "BenchmarkCache/workers=4/size=1024-8"
+// has the base "BenchmarkCache", the parameters "workers=4" and "size=1024", and a GOMAXPROCS suffix of 8.
+type BenchmarkName struct {
+	Base       string
+	Parameters []NameParameter
+	// Procs is the GOMAXPROCS suffix added to the name by `go test`, or zero if there was none.
+	Procs int
+}
+
+// NameParameter is a single sub-benchmark part of a benchmark name.  Parts in the "key=value" form are split into
+// their key and value - other parts have an empty key, with the whole part as the value.
+type NameParameter struct {
+	Key   string
+	Value string
+}
+
+// ParseBenchmarkName splits the benchmark name into its base name, sub-benchmark parameters and GOMAXPROCS suffix.
+// A negative number directly following the "=" of a parameter, as in "BenchmarkShift/offset=-5", is the parameter's
+// value rather than a GOMAXPROCS suffix.
+func ParseBenchmarkName(name string) BenchmarkName {
+	var result BenchmarkName
+
+	if index := strings.LastIndex(name, "-"); index > 0 && name[index-1] != '=' {
+		procs, err := strconv.Atoi(name[index+1:])
+		if err == nil && procs > 0 {
+			result.Procs = procs
+			name = name[:index]
+		}
+	}
+
+	parts := strings.Split(name, "/")
+	result.Base = parts[0]
+	for _, part := range parts[1:] {
+		parameter := NameParameter{Value: part}
+		if index := strings.Index(part, "="); index >= 0 {
+			parameter.Key = part[:index]
+			parameter.Value = part[index+1:]
+		}
+		result.Parameters = append(result.Parameters, parameter)
+	}
+	return result
+}
+
+// Parameter provides the value of the parameter with the given key, and whether the name has that parameter.
+func (n BenchmarkName) Parameter(key string) (string, bool) {
+	for _, parameter := range n.Parameters {
+		if parameter.Key == key {
+			return parameter.Value, true
+		}
+	}
+	return "", false
+}
+
+// Keys provides the keys of the name's "key=value" parameters, in the order they appear.
+func (n BenchmarkName) Keys() []string {
+	var keys []string
+	for _, parameter := range n.Parameters {
+		if parameter.Key != "" {
+			keys = append(keys, parameter.Key)
+		}
+	}
+	return keys
+}
+
+// nameLabel is a single label describing a benchmark, for outputs which label their metrics.
+type nameLabel struct {
+	key   string
+	value string
+}
+
+// nameLabels provides the labels describing the benchmark with the given name - its base name, sub-benchmark and
+// GOMAXPROCS, followed by its configuration (in key order) and the "key=value" parameters of its name.  The keys of
+// the configuration and parameters are passed through keyFunc, to make them valid for the output - parameters are
+// prefixed with "param_" where they would clash with an earlier label.
+func nameLabels(name string, config Config, keyFunc func(string) string) []nameLabel {
+	parsed := ParseBenchmarkName(name)
+
+	parts := make([]string, 0, len(parsed.Parameters))
+	for _, parameter := range parsed.Parameters {
+		if parameter.Key == "" {
+			parts = append(parts, parameter.Value)
+		} else {
+			parts = append(parts, parameter.Key+"="+parameter.Value)
+		}
+	}
+
+	labels := []nameLabel{
+		{key: "benchmark", value: parsed.Base},
+		{key: "sub", value: strings.Join(parts, "/")},
+	}
+	used := map[string]bool{"benchmark": true, "sub": true, "procs": true}
+	if parsed.Procs > 0 {
+		labels = append(labels, nameLabel{key: "procs", value: strconv.Itoa(parsed.Procs)})
+	}
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		labelKey := keyFunc(key)
+		if used[labelKey] {
+			continue
+		}
+		used[labelKey] = true
+		labels = append(labels, nameLabel{key: labelKey, value: config[key]})
+	}
+
+	for _, parameter := range parsed.Parameters {
+		if parameter.Key == "" {
+			continue
+		}
+		labelKey := keyFunc(parameter.Key)
+		if used[labelKey] {
+			labelKey = "param_" + labelKey
+		}
+		if used[labelKey] {
+			continue
+		}
+		used[labelKey] = true
+		labels = append(labels, nameLabel{key: labelKey, value: parameter.Value})
+	}
+	return labels
+}
@@ -0,0 +1,119 @@
+package go_benchpress
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ===== ParseBenchmarkName tests =====
+
+func TestParseBenchmarkName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  BenchmarkName
+	}{
+		{
+			input: "BenchmarkOne",
+			want:  BenchmarkName{Base: "BenchmarkOne"},
+		},
+		{
+			input: "BenchmarkOne-8",
+			want:  BenchmarkName{Base: "BenchmarkOne", Procs: 8},
+		},
+		{
+			input: "BenchmarkCache/workers=4/size=1024-8",
+			want: BenchmarkName{
+				Base: "BenchmarkCache",
+				Parameters: []NameParameter{
+					{Key: "workers", Value: "4"},
+					{Key: "size", Value: "1024"},
+				},
+				Procs: 8,
+			},
+		},
+		{
+			input: "BenchmarkSort/radix/size=10",
+			want: BenchmarkName{
+				Base: "BenchmarkSort",
+				Parameters: []NameParameter{
+					{Value: "radix"},
+					{Key: "size", Value: "10"},
+				},
+			},
+		},
+		{
+			input: "BenchmarkShift/offset=-5",
+			want: BenchmarkName{
+				Base:       "BenchmarkShift",
+				Parameters: []NameParameter{{Key: "offset", Value: "-5"}},
+			},
+		},
+		{
+			input: "BenchmarkShift/offset=-5-8",
+			want: BenchmarkName{
+				Base:       "BenchmarkShift",
+				Parameters: []NameParameter{{Key: "offset", Value: "-5"}},
+				Procs:      8,
+			},
+		},
+		{
+			input: "BenchmarkSort/in-place",
+			want: BenchmarkName{
+				Base:       "BenchmarkSort",
+				Parameters: []NameParameter{{Value: "in-place"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got := ParseBenchmarkName(test.input)
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+// ===== BenchmarkName tests =====
+
+func TestBenchmarkName_Parameter(t *testing.T) {
+	name := ParseBenchmarkName("BenchmarkCache/workers=4/size=1024-8")
+
+	got, ok := name.Parameter("size")
+	if !ok || got != "1024" {
+		t.Errorf("Want parameter %q, got parameter %q (found %v)", "1024", got, ok)
+	}
+
+	_, ok = name.Parameter("missing")
+	if ok {
+		t.Error("Want missing parameter not found")
+	}
+}
+
+func TestBenchmarkName_Keys(t *testing.T) {
+	got := ParseBenchmarkName("BenchmarkCache/lru/workers=4/size=1024").Keys()
+	want := []string{"workers", "size"}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+// ===== nameLabels tests =====
+
+func TestNameLabels(t *testing.T) {
+	config := Config{"pkg": "example.com/cache", "goos": "linux"}
+	got := nameLabels("BenchmarkCache/lru/size=1024/goos=x-8", config, strings.ToUpper)
+	want := []nameLabel{
+		{key: "benchmark", value: "BenchmarkCache"},
+		{key: "sub", value: "lru/size=1024/goos=x"},
+		{key: "procs", value: "8"},
+		{key: "GOOS", value: "linux"},
+		{key: "PKG", value: "example.com/cache"},
+		{key: "SIZE", value: "1024"},
+		{key: "param_GOOS", value: "x"},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
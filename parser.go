@@ -11,21 +11,113 @@ import (
 // ReadBenchmarks uses the provided reader, and reads the benchmarks from the read lines.
 // If lines cannot be read, or parsed, an error is returned.
 func ReadBenchmarks(reader io.Reader) ([]parse.Benchmark, error) {
+	results, _, err := ReadBenchmarksWithConfig(reader)
+	// Without their configurations, the results need no numbering.
+	for index := range results {
+		results[index].Ord = 0
+	}
+	return results, err
+}
+
+// Config is the configuration reported by `go test` alongside benchmark results, such as "goos", "goarch", "pkg"
+// and "cpu".
+type Config map[string]string
+
+// BenchmarkConfigs holds the Config each benchmark result was run with, keyed by the Ord of the result - its position
+// among the results read, as numbered by ReadBenchmarksWithConfig.  Results of the same name from different packages
+// therefore keep their own configuration.
+type BenchmarkConfigs map[int]Config
+
+// ReadBenchmarksWithConfig reads the benchmarks as ReadBenchmarks does, along with the configuration lines (in the
+// "key: value" form) preceding them.  The Ord of each benchmark is set to its position among the results, identifying
+// its configuration within the BenchmarkConfigs.
+func ReadBenchmarksWithConfig(reader io.Reader) ([]parse.Benchmark, BenchmarkConfigs, error) {
 	var results []parse.Benchmark
+	configs := make(BenchmarkConfigs)
+	config := make(Config)
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		line := scanner.Text()
+		if key, value, ok := parseConfigLine(line); ok {
+			// Benchmarks already read keep the configuration they were run with.
+			updated := make(Config, len(config)+1)
+			for k, v := range config {
+				updated[k] = v
+			}
+			updated[key] = value
+			config = updated
+			continue
+		}
 		if !strings.HasPrefix(line, "Benchmark") {
 			continue
 		}
 
 		benchmark, err := parse.ParseLine(line)
 		if err != nil {
-			return nil, fmt.Errorf("parsing error: %w", ErrCouldNotParseLine)
+			return nil, nil, fmt.Errorf("parsing error: %w", ErrCouldNotParseLine)
 		}
+		benchmark.Ord = len(results)
 		results = append(results, *benchmark)
+		configs[benchmark.Ord] = config
+	}
+	return results, configs, scanner.Err()
+}
+
+// AppendBenchmarks appends the benchmarks read from another input, with their configuration, to those already read.
+// The appended benchmarks are renumbered to follow the existing ones, so that every result keeps its own
+// configuration.
+func AppendBenchmarks(benchmarks []parse.Benchmark, configs BenchmarkConfigs, more []parse.Benchmark, moreConfigs BenchmarkConfigs) ([]parse.Benchmark, BenchmarkConfigs) {
+	offset := 0
+	for _, benchmark := range benchmarks {
+		offset = max(offset, benchmark.Ord+1)
+	}
+
+	results := append([]parse.Benchmark(nil), benchmarks...)
+	resultConfigs := make(BenchmarkConfigs, len(configs)+len(moreConfigs))
+	for ord, config := range configs {
+		resultConfigs[ord] = config
 	}
-	return results, scanner.Err()
+	for _, benchmark := range more {
+		if config, ok := moreConfigs[benchmark.Ord]; ok {
+			resultConfigs[benchmark.Ord+offset] = config
+		}
+		benchmark.Ord += offset
+		results = append(results, benchmark)
+	}
+	return results, resultConfigs
+}
+
+// AddConfigKey provides the configurations with the key set to the value for each of the benchmarks - for instance,
+// to keep apart benchmarks of the same name read from different inputs, with a "file" key.  The provided
+// configurations are left unchanged.
+func AddConfigKey(benchmarks []parse.Benchmark, configs BenchmarkConfigs, key, value string) BenchmarkConfigs {
+	results := make(BenchmarkConfigs, len(configs))
+	for ord, config := range configs {
+		results[ord] = config
+	}
+	for _, benchmark := range benchmarks {
+		config := configs[benchmark.Ord]
+		updated := make(Config, len(config)+1)
+		for k, v := range config {
+			updated[k] = v
+		}
+		updated[key] = value
+		results[benchmark.Ord] = updated
+	}
+	return results
+}
+
+// parseConfigLine splits a configuration line into its key and value.  Keys begin with a lower case letter and
+// contain no spaces, and are followed by a colon and either a space or the end of the line.
+func parseConfigLine(line string) (key, value string, ok bool) {
+	index := strings.Index(line, ":")
+	if index < 1 || line[0] < 'a' || line[0] > 'z' || strings.ContainsAny(line[:index], " \t") {
+		return "", "", false
+	}
+	if index+1 < len(line) && line[index+1] != ' ' {
+		return "", "", false
+	}
+	return line[:index], strings.TrimSpace(line[index+1:]), true
 }
 
 // BenchmarkSets represents a number of benchmarks, grouped by the parent benchmark.
@@ -38,7 +130,11 @@ func ReadAndSeparateBenchmarks(reader io.Reader) (BenchmarkSets, error) {
 	if err != nil {
 		return nil, err
 	}
+	return SeparateBenchmarks(benchmarks), nil
+}
 
+// SeparateBenchmarks groups the benchmarks by benchmark name (which is split from sub-benchmark names).
+func SeparateBenchmarks(benchmarks []parse.Benchmark) BenchmarkSets {
 	results := make(map[string][]parse.Benchmark)
 	for _, val := range benchmarks {
 		parts := strings.Split(val.Name, "/")
@@ -51,5 +147,5 @@ func ReadAndSeparateBenchmarks(reader io.Reader) (BenchmarkSets, error) {
 		s = append(s, val)
 		results[benchName] = s
 	}
-	return results, nil
+	return results
 }
@@ -82,6 +82,130 @@ BenchmarkSomething/SubBenchmark3-12   	   10000	     10000 ns/op`),
 	}
 }
 
+func TestReadBenchmarksWithConfig(t *testing.T) {
+	input := strings.NewReader(`goos: linux
+goarch: amd64
+pkg: example.com/one
+BenchmarkOne-8   	   10000	     10000 ns/op
+PASS
+pkg: example.com/two
+cpu: Example CPU
+BenchmarkTwo-8   	   10000	     20000 ns/op
+BenchmarkOne-8   	   10000	     30000 ns/op
+ok  	example.com/two	1.000s`)
+
+	benchmarks, configs, err := ReadBenchmarksWithConfig(input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantLen := 3
+	if len(benchmarks) != wantLen {
+		t.Fatalf("Wanted %d benchmarks, got %d", wantLen, len(benchmarks))
+	}
+	for index, benchmark := range benchmarks {
+		if benchmark.Ord != index {
+			t.Errorf("Wanted benchmark %d to have ord %d, got %d", index, index, benchmark.Ord)
+		}
+	}
+
+	// The same benchmark name in another package keeps its own configuration.
+	want := BenchmarkConfigs{
+		0: {"goos": "linux", "goarch": "amd64", "pkg": "example.com/one"},
+		1: {"goos": "linux", "goarch": "amd64", "pkg": "example.com/two", "cpu": "Example CPU"},
+		2: {"goos": "linux", "goarch": "amd64", "pkg": "example.com/two", "cpu": "Example CPU"},
+	}
+	if !reflect.DeepEqual(want, configs) {
+		t.Errorf("want %v, got %v", want, configs)
+	}
+}
+
+func TestAppendBenchmarks(t *testing.T) {
+	benchmarks := []parse.Benchmark{{Name: "BenchmarkOne", Ord: 0}, {Name: "BenchmarkTwo", Ord: 1}}
+	configs := BenchmarkConfigs{0: {"pkg": "example.com/one"}, 1: {"pkg": "example.com/two"}}
+	more := []parse.Benchmark{{Name: "BenchmarkOne", Ord: 0}}
+	moreConfigs := BenchmarkConfigs{0: {"pkg": "example.com/three"}}
+
+	gotBenchmarks, gotConfigs := AppendBenchmarks(benchmarks, configs, more, moreConfigs)
+
+	wantBenchmarks := []parse.Benchmark{{Name: "BenchmarkOne", Ord: 0}, {Name: "BenchmarkTwo", Ord: 1}, {Name: "BenchmarkOne", Ord: 2}}
+	if !reflect.DeepEqual(wantBenchmarks, gotBenchmarks) {
+		t.Errorf("want %v, got %v", wantBenchmarks, gotBenchmarks)
+	}
+	wantConfigs := BenchmarkConfigs{0: {"pkg": "example.com/one"}, 1: {"pkg": "example.com/two"}, 2: {"pkg": "example.com/three"}}
+	if !reflect.DeepEqual(wantConfigs, gotConfigs) {
+		t.Errorf("want %v, got %v", wantConfigs, gotConfigs)
+	}
+	if more[0].Ord != 0 {
+		t.Errorf("Wanted appended benchmarks to be unchanged, got %v", more)
+	}
+}
+
+func TestAddConfigKey(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/quick-8", NsPerOp: 100, Ord: 0},
+		{Name: "BenchmarkSearch", NsPerOp: 200, Ord: 1},
+	}
+	configs := BenchmarkConfigs{0: {"pkg": "example.com/sort"}}
+
+	got := AddConfigKey(benchmarks, configs, "file", "new")
+
+	want := BenchmarkConfigs{
+		0: {"pkg": "example.com/sort", "file": "new"},
+		1: {"file": "new"},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if _, ok := configs[0]["file"]; ok || len(configs) != 1 {
+		t.Errorf("Wanted original configs to be unchanged, got %v", configs)
+	}
+}
+
+func TestParseConfigLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantKey   string
+		wantValue string
+		wantOk    bool
+	}{
+		{
+			name:      "config line",
+			input:     "pkg: example.com/one",
+			wantKey:   "pkg",
+			wantValue: "example.com/one",
+			wantOk:    true,
+		},
+		{
+			name:    "empty value",
+			input:   "note:",
+			wantKey: "note",
+			wantOk:  true,
+		},
+		{
+			name:  "benchmark line",
+			input: "BenchmarkOne-8   	   10000	     10000 ns/op",
+		},
+		{
+			name:  "key with space",
+			input: "not a: config",
+		},
+		{
+			name:  "no space after colon",
+			input: "http://example.com",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, value, ok := parseConfigLine(test.input)
+			if test.wantKey != key || test.wantValue != value || test.wantOk != ok {
+				t.Errorf("want (%q, %q, %t), got (%q, %q, %t)", test.wantKey, test.wantValue, test.wantOk, key, value, ok)
+			}
+		})
+	}
+}
+
 var benchmarksRead []parse.Benchmark
 
 func BenchmarkReadBenchmarks(b *testing.B) {
@@ -0,0 +1,105 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"strings"
+)
+
+// PGFPlotsRenderer outputs a LaTeX figure drawing a bar chart of the benchmarks with pgfplots, matching the
+// RasterRenderer's, with the data inlined.  The figure requires the pgfplots package.
+type PGFPlotsRenderer struct {
+	Title string
+	// LogScale renders the value axis on a logarithmic scale.
+	LogScale bool
+	// ErrorBars combines repeated samples of each benchmark into a single bar, showing their variance.
+	ErrorBars ErrorBars
+}
+
+func (p *PGFPlotsRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
+	if len(benchmarks) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	title := p.Title
+	if title == "" {
+		title = parentBenchmark
+	}
+
+	bars, axisTitle, err := newFigureBars(benchmarks, dimension, p.ErrorBars)
+	if err != nil {
+		return err
+	}
+
+	// Benchmark labels are not valid symbolic coordinates, so bars are placed by index and labelled explicitly.
+	labels := make([]string, 0, len(bars))
+	for _, bar := range bars {
+		labels = append(labels, "{"+latexString(bar.label)+"}")
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString("% Bar chart of benchmarks generated by gobenchpress - requires \\usepackage{pgfplots}.\n")
+	buf.WriteString("\\begin{figure}\n")
+	buf.WriteString("\\centering\n")
+	buf.WriteString("\\begin{tikzpicture}\n")
+	buf.WriteString("\\begin{axis}[\n")
+	buf.WriteString("    width=\\linewidth,\n")
+	buf.WriteString("    height=0.5\\linewidth,\n")
+	buf.WriteString("    ybar,\n")
+	fmt.Fprintf(&buf, "    ylabel={%s},\n", latexString(axisTitle))
+	if p.LogScale {
+		buf.WriteString("    ymode=log,\n")
+		buf.WriteString("    log origin=infty,\n")
+	} else {
+		buf.WriteString("    ymin=0,\n")
+	}
+	buf.WriteString("    ymajorgrids,\n")
+	buf.WriteString("    enlarge x limits=0.1,\n")
+	buf.WriteString("    xtick=data,\n")
+	fmt.Fprintf(&buf, "    xticklabels={%s},\n", strings.Join(labels, ","))
+	buf.WriteString("    x tick label style={rotate=45, anchor=north east},\n")
+	buf.WriteString("]\n")
+
+	if p.ErrorBars != NoErrorBars {
+		buf.WriteString("\\addplot+[error bars/.cd, y dir=both, y explicit] table[x=index, y=value, y error=error] {\n")
+		buf.WriteString("index value error\n")
+	} else {
+		buf.WriteString("\\addplot table[x=index, y=value] {\n")
+		buf.WriteString("index value\n")
+	}
+	for index, bar := range bars {
+		fmt.Fprintf(&buf, "%d %s", index, formatFigureNumber(bar.value))
+		if p.ErrorBars != NoErrorBars {
+			fmt.Fprintf(&buf, " %s", formatFigureNumber(bar.err))
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("};\n")
+
+	buf.WriteString("\\end{axis}\n")
+	buf.WriteString("\\end{tikzpicture}\n")
+	fmt.Fprintf(&buf, "\\caption{%s}\n", latexString(title))
+	buf.WriteString("\\end{figure}\n")
+
+	_, err = writer.Write(buf.Bytes())
+	return err
+}
+
+// latexString escapes the characters of the string which are special to LaTeX.
+func latexString(str string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\textbackslash{}`,
+		`{`, `\{`,
+		`}`, `\}`,
+		`$`, `\$`,
+		`&`, `\&`,
+		`#`, `\#`,
+		`%`, `\%`,
+		`_`, `\_`,
+		`^`, `\textasciicircum{}`,
+		`~`, `\textasciitilde{}`,
+	)
+	return replacer.Replace(str)
+}
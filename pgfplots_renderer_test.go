@@ -0,0 +1,107 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"strings"
+	"testing"
+)
+
+func TestPGFPlotsRenderer_Render(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/Small_Input", NsPerOp: 500},
+		{Name: "BenchmarkOne/Large_Input", NsPerOp: 2500},
+	}
+
+	tests := []struct {
+		name     string
+		renderer PGFPlotsRenderer
+		// wantLines are lines wanted within the figure.
+		wantLines []string
+	}{
+		{
+			name:     "default",
+			renderer: PGFPlotsRenderer{},
+			wantLines: []string{
+				`\begin{figure}`,
+				`    ylabel={Time per op (µs)},`,
+				`    ymin=0,`,
+				`    xticklabels={{Small\_Input},{Large\_Input}},`,
+				`\addplot table[x=index, y=value] {`,
+				`0 0.5`,
+				`1 2.5`,
+				`\caption{BenchmarkOne}`,
+				`\end{figure}`,
+			},
+		},
+		{
+			name:     "title and log scale",
+			renderer: PGFPlotsRenderer{Title: "100% & more", LogScale: true},
+			wantLines: []string{
+				`    ymode=log,`,
+				`\caption{100\% \& more}`,
+			},
+		},
+		{
+			name:     "error bars",
+			renderer: PGFPlotsRenderer{ErrorBars: StdDevErrorBars},
+			wantLines: []string{
+				`\addplot+[error bars/.cd, y dir=both, y explicit] table[x=index, y=value, y error=error] {`,
+				`0 0.5 0`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := test.renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, benchmarks)
+			if err != nil {
+				t.Fatalf("Error rendering pgfplots: %v", err)
+			}
+
+			lines := strings.Split(output.String(), "\n")
+			for _, want := range test.wantLines {
+				if !containsLine(lines, want) {
+					t.Errorf("Wanted line %q within figure %q", want, output.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPGFPlotsRenderer_RenderNoBenchmarks(t *testing.T) {
+	renderer := PGFPlotsRenderer{}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, nil)
+	if !errors.Is(err, ErrNoBenchmarksProvided) {
+		t.Errorf("Wanted error '%v', got error '%v'", ErrNoBenchmarksProvided, err)
+	}
+}
+
+func TestLatexString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain",
+			input: "size=1024/workers=4",
+			want:  "size=1024/workers=4",
+		},
+		{
+			name:  "special characters",
+			input: `a_b 50% $1 #2 & {c} ^~\`,
+			want:  `a\_b 50\% \$1 \#2 \& \{c\} \textasciicircum{}\textasciitilde{}\textbackslash{}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := latexString(test.input)
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
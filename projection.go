@@ -0,0 +1,224 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseProjectionKeys splits a comma separated list of keys, used to group and pivot benchmarks - such as
+// "pkg,benchmark".  Keys are "name" for the full benchmark name, "name.N" for its first N parts (split at "/"), or the
+// labels of the benchmark - its "benchmark", "sub" and "procs", its configuration (such as "pkg" or "goos"), and the
+// parameters of its name (such as "size").  If a key is empty, or has an invalid depth, an ErrInvalidProjectionKey is
+// returned.
+func ParseProjectionKeys(str string) ([]string, error) {
+	var keys []string
+	for _, key := range strings.Split(str, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("keys %q contain an empty key: %w", str, ErrInvalidProjectionKey)
+		}
+		if strings.HasPrefix(key, "name.") {
+			if _, ok := nameDepth(key); !ok {
+				return nil, fmt.Errorf("key %q does not have a valid depth: %w", key, ErrInvalidProjectionKey)
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// labelValue provides the value of the key for the benchmark, and whether the benchmark has that value.  See
+// ParseProjectionKeys for the supported keys.
+func labelValue(key string, benchmark parse.Benchmark, config Config) (string, bool) {
+	if key == "name" {
+		return benchmark.Name, true
+	}
+	if depth, ok := nameDepth(key); ok {
+		// Names with fewer parts than the depth are used in full.
+		parts := strings.Split(trimProcs(benchmark.Name), "/")
+		if depth > len(parts) {
+			depth = len(parts)
+		}
+		return strings.Join(parts[:depth], "/"), true
+	}
+
+	for _, label := range nameLabels(benchmark.Name, config, func(key string) string {
+		return key
+	}) {
+		if label.key == key {
+			return label.value, true
+		}
+	}
+	return "", false
+}
+
+// nameDepth provides the depth of a "name.N" key, and whether the key is of that form with a positive depth.
+func nameDepth(key string) (int, bool) {
+	if !strings.HasPrefix(key, "name.") {
+		return 0, false
+	}
+	depth, err := strconv.Atoi(key[len("name."):])
+	if err != nil || depth < 1 {
+		return 0, false
+	}
+	return depth, true
+}
+
+// projectionValue provides the values of the keys for the benchmark, joined by "/".  Keys the benchmark does not have
+// are given empty values.
+func projectionValue(keys []string, benchmark parse.Benchmark, config Config) string {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, _ := labelValue(key, benchmark, config)
+		values = append(values, value)
+	}
+	return strings.Join(values, "/")
+}
+
+// GroupBenchmarks groups the benchmarks by the values of the keys, joined by "/" - for instance, grouping by "pkg"
+// and "size" puts "BenchmarkSort/size=10" from "example.com/sort" in the group "example.com/sort/10".  See
+// ParseProjectionKeys for the supported keys.
+func GroupBenchmarks(benchmarks []parse.Benchmark, configs BenchmarkConfigs, keys []string) BenchmarkSets {
+	results := make(BenchmarkSets)
+	for _, benchmark := range benchmarks {
+		name := projectionValue(keys, benchmark, configs[benchmark.Ord])
+		results[name] = append(results[name], benchmark)
+	}
+	return results
+}
+
+// Pivot arranges benchmarks as series of points along an X axis - such as a line for each algorithm, across the
+// sizes of input.
+type Pivot struct {
+	// XTitle describes the values along the X axis.
+	XTitle string
+	X      []string
+	// Numeric reports whether every X value is a number, in which case they are in ascending order.  Otherwise, they
+	// are in order of first appearance.
+	Numeric bool
+	Series  []PivotSeries
+}
+
+// PivotSeries is a single series of a Pivot.
+type PivotSeries struct {
+	Name string
+	// Points holds the samples of the series at each X value of the Pivot - with no benchmarks where the series has
+	// no point.
+	Points []BenchmarkSamples
+}
+
+// NewPivot arranges the benchmarks with the values of the xKeys along the X axis, and a series for each of the values
+// of the seriesKeys.  Without xKeys, the last parameter of each benchmark name is used - and without seriesKeys, each
+// series is the benchmark name without the parameters on the X axis, following its package (its "pkg" configuration)
+// where the benchmarks span more than one.  Samples of benchmarks sharing both a series and an X value are combined
+// into the same point.  See ParseProjectionKeys for the supported keys.
+func NewPivot(benchmarks []parse.Benchmark, configs BenchmarkConfigs, xKeys, seriesKeys []string) Pivot {
+	result := Pivot{XTitle: strings.Join(xKeys, ", ")}
+	if len(xKeys) == 0 {
+		result.XTitle = lastParameterKey(benchmarks)
+	}
+
+	type cell struct {
+		series, x string
+	}
+	cells := make(map[cell][]parse.Benchmark)
+	names := make(map[cell]string)
+	var seriesNames []string
+	packages := spansPackages(benchmarks, configs)
+	for _, benchmark := range benchmarks {
+		config := configs[benchmark.Ord]
+		x, series := pivotPosition(benchmark, config, xKeys)
+		if len(seriesKeys) > 0 {
+			series = projectionValue(seriesKeys, benchmark, config)
+		} else if packages {
+			// Benchmarks of the same name from different packages are kept apart, rather than combined as samples.
+			series = projectionValue([]string{"pkg"}, benchmark, config) + "/" + series
+		}
+
+		c := cell{series: series, x: x}
+		if _, ok := names[c]; !ok {
+			names[c] = benchmark.Name
+		}
+		cells[c] = append(cells[c], benchmark)
+		result.X = appendUnique(result.X, x)
+		seriesNames = appendUnique(seriesNames, series)
+	}
+
+	result.Numeric = true
+	for _, x := range result.X {
+		if _, err := strconv.ParseFloat(x, 64); err != nil {
+			result.Numeric = false
+			break
+		}
+	}
+	if result.Numeric {
+		sort.SliceStable(result.X, func(i, j int) bool {
+			a, _ := strconv.ParseFloat(result.X[i], 64)
+			b, _ := strconv.ParseFloat(result.X[j], 64)
+			return a < b
+		})
+	}
+
+	for _, series := range seriesNames {
+		points := make([]BenchmarkSamples, 0, len(result.X))
+		for _, x := range result.X {
+			c := cell{series: series, x: x}
+			points = append(points, BenchmarkSamples{Name: names[c], Benchmarks: cells[c]})
+		}
+		result.Series = append(result.Series, PivotSeries{Name: series, Points: points})
+	}
+	return result
+}
+
+// spansPackages reports whether the benchmarks are from more than one package, by their "pkg" configuration.
+func spansPackages(benchmarks []parse.Benchmark, configs BenchmarkConfigs) bool {
+	var first string
+	for index, benchmark := range benchmarks {
+		pkg := configs[benchmark.Ord]["pkg"]
+		if index == 0 {
+			first = pkg
+		} else if pkg != first {
+			return true
+		}
+	}
+	return false
+}
+
+// pivotPosition provides the X value of the benchmark, and its default series - the benchmark name without the
+// parameters providing the X value.  Without xKeys, the last parameter of the name provides the X value.
+func pivotPosition(benchmark parse.Benchmark, config Config, xKeys []string) (x, series string) {
+	parsed := ParseBenchmarkName(benchmark.Name)
+
+	onX := make(map[string]bool, len(xKeys))
+	for _, key := range xKeys {
+		onX[key] = true
+	}
+
+	parts := []string{parsed.Base}
+	for index, parameter := range parsed.Parameters {
+		if len(xKeys) == 0 && index == len(parsed.Parameters)-1 {
+			x = parameter.Value
+			continue
+		}
+		if parameter.Key != "" && onX[parameter.Key] {
+			continue
+		}
+		if parameter.Key == "" {
+			parts = append(parts, parameter.Value)
+		} else {
+			parts = append(parts, parameter.Key+"="+parameter.Value)
+		}
+	}
+	series = strings.Join(parts, "/")
+	if parsed.Procs > 0 && !onX["procs"] {
+		series += "-" + strconv.Itoa(parsed.Procs)
+	}
+
+	if len(xKeys) > 0 {
+		x = projectionValue(xKeys, benchmark, config)
+	}
+	return x, series
+}
@@ -0,0 +1,214 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"testing"
+)
+
+func TestParseProjectionKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr error
+	}{
+		{name: "single", input: "size", want: []string{"size"}},
+		{name: "several", input: "pkg, name.2,procs", want: []string{"pkg", "name.2", "procs"}},
+		{name: "empty", input: "", wantErr: ErrInvalidProjectionKey},
+		{name: "empty key", input: "pkg,,size", wantErr: ErrInvalidProjectionKey},
+		{name: "invalid depth", input: "name.0", wantErr: ErrInvalidProjectionKey},
+		{name: "non-numeric depth", input: "name.abc", wantErr: ErrInvalidProjectionKey},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseProjectionKeys(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestGroupBenchmarks(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=quick/size=10-8", Ord: 0},
+		{Name: "BenchmarkSort/algo=radix/size=10-8", Ord: 1},
+		{Name: "BenchmarkSort/algo=quick/size=100-8", Ord: 2},
+		{Name: "BenchmarkSearch-8", Ord: 3},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/sort"},
+		1: {"pkg": "example.com/sort"},
+		2: {"pkg": "example.com/sort"},
+		3: {"pkg": "example.com/search"},
+	}
+
+	tests := []struct {
+		name string
+		keys []string
+		want BenchmarkSets
+	}{
+		{
+			name: "benchmark",
+			keys: []string{"benchmark"},
+			want: BenchmarkSets{
+				"BenchmarkSort":   benchmarks[:3],
+				"BenchmarkSearch": benchmarks[3:],
+			},
+		},
+		{
+			name: "name depth",
+			keys: []string{"name.2"},
+			want: BenchmarkSets{
+				"BenchmarkSort/algo=quick": {benchmarks[0], benchmarks[2]},
+				"BenchmarkSort/algo=radix": {benchmarks[1]},
+				"BenchmarkSearch":          {benchmarks[3]},
+			},
+		},
+		{
+			name: "package and parameter",
+			keys: []string{"pkg", "size"},
+			want: BenchmarkSets{
+				"example.com/sort/10":  {benchmarks[0], benchmarks[1]},
+				"example.com/sort/100": {benchmarks[2]},
+				"example.com/search/":  {benchmarks[3]},
+			},
+		},
+		{
+			name: "procs",
+			keys: []string{"procs"},
+			want: BenchmarkSets{
+				"8": benchmarks,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := GroupBenchmarks(benchmarks, configs, test.keys)
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestNewPivot(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=quick/size=100/file=old-8", NsPerOp: 1},
+		{Name: "BenchmarkSort/algo=quick/size=10/file=old-8", NsPerOp: 2},
+		{Name: "BenchmarkSort/algo=radix/size=10/file=old-8", NsPerOp: 3},
+		{Name: "BenchmarkSort/algo=quick/size=10/file=new-8", NsPerOp: 4},
+	}
+
+	// pivotShape summarises a pivot as the benchmark names of each series at each X value.
+	type pivotShape struct {
+		XTitle  string
+		X       []string
+		Numeric bool
+		Series  map[string][]string
+	}
+	shape := func(pivot Pivot) pivotShape {
+		result := pivotShape{XTitle: pivot.XTitle, X: pivot.X, Numeric: pivot.Numeric, Series: map[string][]string{}}
+		for _, series := range pivot.Series {
+			for _, point := range series.Points {
+				result.Series[series.Name] = append(result.Series[series.Name], point.Name)
+			}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name       string
+		xKeys      []string
+		seriesKeys []string
+		want       pivotShape
+	}{
+		{
+			name: "last parameter",
+			want: pivotShape{
+				XTitle: "file",
+				X:      []string{"old", "new"},
+				Series: map[string][]string{
+					"BenchmarkSort/algo=quick/size=100-8": {benchmarks[0].Name, ""},
+					"BenchmarkSort/algo=quick/size=10-8":  {benchmarks[1].Name, benchmarks[3].Name},
+					"BenchmarkSort/algo=radix/size=10-8":  {benchmarks[2].Name, ""},
+				},
+			},
+		},
+		{
+			name:  "numeric x key",
+			xKeys: []string{"size"},
+			want: pivotShape{
+				XTitle:  "size",
+				X:       []string{"10", "100"},
+				Numeric: true,
+				Series: map[string][]string{
+					"BenchmarkSort/algo=quick/file=old-8": {benchmarks[1].Name, benchmarks[0].Name},
+					"BenchmarkSort/algo=radix/file=old-8": {benchmarks[2].Name, ""},
+					"BenchmarkSort/algo=quick/file=new-8": {benchmarks[3].Name, ""},
+				},
+			},
+		},
+		{
+			name:       "x and series keys",
+			xKeys:      []string{"algo"},
+			seriesKeys: []string{"file"},
+			want: pivotShape{
+				XTitle: "algo",
+				X:      []string{"quick", "radix"},
+				Series: map[string][]string{
+					"old": {benchmarks[0].Name, benchmarks[2].Name},
+					"new": {benchmarks[3].Name, ""},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := shape(NewPivot(benchmarks, nil, test.xKeys, test.seriesKeys))
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+
+	// Samples of benchmarks sharing a point are combined.
+	pivot := NewPivot(benchmarks, nil, []string{"algo"}, []string{"file"})
+	if got := len(pivot.Series[0].Points[0].Benchmarks); got != 2 {
+		t.Errorf("Wanted 2 samples combined into the first point, got %d", got)
+	}
+}
+
+func TestNewPivot_Packages(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/size=10-8", NsPerOp: 1, Ord: 0},
+		{Name: "BenchmarkSort/size=10-8", NsPerOp: 2, Ord: 1},
+		{Name: "BenchmarkSort/size=100-8", NsPerOp: 3, Ord: 2},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a"},
+		1: {"pkg": "example.com/b"},
+		2: {"pkg": "example.com/a"},
+	}
+
+	pivot := NewPivot(benchmarks, configs, []string{"size"}, nil)
+	var got []string
+	for _, series := range pivot.Series {
+		got = append(got, series.Name)
+	}
+	want := []string{"example.com/a/BenchmarkSort-8", "example.com/b/BenchmarkSort-8"}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Want series %v, got %v", want, got)
+	}
+
+	// Benchmarks from a single package are not named after it.
+	pivot = NewPivot(benchmarks[:1], configs, []string{"size"}, nil)
+	if name := pivot.Series[0].Name; name != "BenchmarkSort-8" {
+		t.Errorf("Want series %q, got %q", "BenchmarkSort-8", name)
+	}
+}
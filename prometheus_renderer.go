@@ -0,0 +1,143 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// prometheusMetric is a metric exported for each benchmark which measured it.
+type prometheusMetric struct {
+	name     string
+	help     string
+	measured int
+	value    func(benchmark parse.Benchmark) float64
+}
+
+var prometheusMetrics = []prometheusMetric{
+	{
+		name: "go_benchmark_iterations",
+		help: "Number of iterations run by the benchmark.",
+		value: func(benchmark parse.Benchmark) float64 {
+			return float64(benchmark.N)
+		},
+	},
+	{
+		name:     "go_benchmark_ns_per_op",
+		help:     "Time per operation, in nanoseconds.",
+		measured: parse.NsPerOp,
+		value: func(benchmark parse.Benchmark) float64 {
+			return benchmark.NsPerOp
+		},
+	},
+	{
+		name:     "go_benchmark_bytes_per_op",
+		help:     "Bytes allocated per operation.",
+		measured: parse.AllocedBytesPerOp,
+		value: func(benchmark parse.Benchmark) float64 {
+			return float64(benchmark.AllocedBytesPerOp)
+		},
+	},
+	{
+		name:     "go_benchmark_allocs_per_op",
+		help:     "Allocations per operation.",
+		measured: parse.AllocsPerOp,
+		value: func(benchmark parse.Benchmark) float64 {
+			return float64(benchmark.AllocsPerOp)
+		},
+	},
+	{
+		name:     "go_benchmark_mb_per_s",
+		help:     "Throughput, in megabytes per second.",
+		measured: parse.MBPerS,
+		value: func(benchmark parse.Benchmark) float64 {
+			return benchmark.MBPerS
+		},
+	},
+}
+
+// PrometheusRenderer outputs the benchmarks in the Prometheus text exposition format, suitable for the node_exporter
+// textfile collector.  Every measured metric is output, whatever the rendered dimension, labelled with the parts of
+// the benchmark name and its configuration.  Repeated samples of a benchmark, run with the same configuration, are
+// combined into their mean, as Prometheus does not allow duplicate series.
+type PrometheusRenderer struct {
+	// Configs holds the configuration of each benchmark, such as its "pkg" - each is added to the labels.
+	Configs BenchmarkConfigs
+}
+
+func (p *PrometheusRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
+	if len(benchmarks) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	samples := GroupSamplesByConfig(benchmarks, p.Configs)
+	means := make([]parse.Benchmark, 0, len(samples))
+	labels := make([]string, 0, len(samples))
+	for _, s := range samples {
+		mean := s.Mean()
+		means = append(means, mean)
+		labels = append(labels, p.labels(mean))
+	}
+
+	buf := bytes.Buffer{}
+	for _, metric := range prometheusMetrics {
+		var lines []string
+		for index, benchmark := range means {
+			if benchmark.Measured&metric.measured != metric.measured {
+				continue
+			}
+			value := strconv.FormatFloat(metric.value(benchmark), 'f', -1, 64)
+			lines = append(lines, fmt.Sprintf("%s{%s} %s\n", metric.name, labels[index], value))
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&buf, "# HELP %s %s\n", metric.name, metric.help)
+		fmt.Fprintf(&buf, "# TYPE %s gauge\n", metric.name)
+		for _, line := range lines {
+			buf.WriteString(line)
+		}
+	}
+
+	_, err := writer.Write(buf.Bytes())
+	return err
+}
+
+// labels provides the formatted labels of the benchmark.
+func (p *PrometheusRenderer) labels(benchmark parse.Benchmark) string {
+	labels := make([]string, 0)
+	for _, label := range nameLabels(benchmark.Name, p.Configs[benchmark.Ord], prometheusLabelName) {
+		labels = append(labels, prometheusLabel(label.key, label.value))
+	}
+	return strings.Join(labels, ",")
+}
+
+// prometheusLabel formats a label, escaping its value.
+func prometheusLabel(name, value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return name + `="` + replacer.Replace(value) + `"`
+}
+
+// prometheusLabelName provides a valid label name for the string, replacing any invalid characters with
+// underscores.
+func prometheusLabelName(str string) string {
+	var builder strings.Builder
+	for index, r := range str {
+		valid := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (index > 0 && r >= '0' && r <= '9')
+		if valid {
+			builder.WriteRune(r)
+		} else {
+			builder.WriteRune('_')
+		}
+	}
+	name := builder.String()
+	// Names beginning with two underscores are reserved for internal use.
+	if name == "" || strings.HasPrefix(name, "__") {
+		name = "param" + name
+	}
+	return name
+}
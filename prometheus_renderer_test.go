@@ -0,0 +1,160 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"testing"
+)
+
+func TestPrometheusRenderer_Render(t *testing.T) {
+	tests := []struct {
+		name       string
+		renderer   PrometheusRenderer
+		benchmarks []parse.Benchmark
+		want       string
+	}{
+		{
+			name:     "measured metrics",
+			renderer: PrometheusRenderer{},
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/Sub-8", N: 1000, NsPerOp: 123.5, AllocedBytesPerOp: 64, AllocsPerOp: 2, Measured: parse.NsPerOp | parse.AllocedBytesPerOp | parse.AllocsPerOp},
+			},
+			want: `# HELP go_benchmark_iterations Number of iterations run by the benchmark.
+# TYPE go_benchmark_iterations gauge
+go_benchmark_iterations{benchmark="BenchmarkOne",sub="Sub",procs="8"} 1000
+# HELP go_benchmark_ns_per_op Time per operation, in nanoseconds.
+# TYPE go_benchmark_ns_per_op gauge
+go_benchmark_ns_per_op{benchmark="BenchmarkOne",sub="Sub",procs="8"} 123.5
+# HELP go_benchmark_bytes_per_op Bytes allocated per operation.
+# TYPE go_benchmark_bytes_per_op gauge
+go_benchmark_bytes_per_op{benchmark="BenchmarkOne",sub="Sub",procs="8"} 64
+# HELP go_benchmark_allocs_per_op Allocations per operation.
+# TYPE go_benchmark_allocs_per_op gauge
+go_benchmark_allocs_per_op{benchmark="BenchmarkOne",sub="Sub",procs="8"} 2
+`,
+		},
+		{
+			name: "config and parameter labels",
+			renderer: PrometheusRenderer{
+				Configs: BenchmarkConfigs{
+					0: {"pkg": "example.com/cache", "goos": "linux"},
+				},
+			},
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkCache/size=1024/pkg=x-4", N: 10, NsPerOp: 5, Measured: parse.NsPerOp},
+			},
+			want: `# HELP go_benchmark_iterations Number of iterations run by the benchmark.
+# TYPE go_benchmark_iterations gauge
+go_benchmark_iterations{benchmark="BenchmarkCache",sub="size=1024/pkg=x",procs="4",goos="linux",pkg="example.com/cache",size="1024",param_pkg="x"} 10
+# HELP go_benchmark_ns_per_op Time per operation, in nanoseconds.
+# TYPE go_benchmark_ns_per_op gauge
+go_benchmark_ns_per_op{benchmark="BenchmarkCache",sub="size=1024/pkg=x",procs="4",goos="linux",pkg="example.com/cache",size="1024",param_pkg="x"} 5
+`,
+		},
+		{
+			name:     "samples combined",
+			renderer: PrometheusRenderer{},
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne", N: 10, NsPerOp: 100, Measured: parse.NsPerOp},
+				{Name: "BenchmarkOne", N: 30, NsPerOp: 200, Measured: parse.NsPerOp},
+			},
+			want: `# HELP go_benchmark_iterations Number of iterations run by the benchmark.
+# TYPE go_benchmark_iterations gauge
+go_benchmark_iterations{benchmark="BenchmarkOne",sub=""} 20
+# HELP go_benchmark_ns_per_op Time per operation, in nanoseconds.
+# TYPE go_benchmark_ns_per_op gauge
+go_benchmark_ns_per_op{benchmark="BenchmarkOne",sub=""} 150
+`,
+		},
+		{
+			name: "packages kept apart",
+			renderer: PrometheusRenderer{
+				Configs: BenchmarkConfigs{
+					0: {"pkg": "example.com/a"},
+					1: {"pkg": "example.com/b"},
+				},
+			},
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkParse", N: 10, NsPerOp: 100, Measured: parse.NsPerOp, Ord: 0},
+				{Name: "BenchmarkParse", N: 10, NsPerOp: 900, Measured: parse.NsPerOp, Ord: 1},
+			},
+			want: `# HELP go_benchmark_iterations Number of iterations run by the benchmark.
+# TYPE go_benchmark_iterations gauge
+go_benchmark_iterations{benchmark="BenchmarkParse",sub="",pkg="example.com/a"} 10
+go_benchmark_iterations{benchmark="BenchmarkParse",sub="",pkg="example.com/b"} 10
+# HELP go_benchmark_ns_per_op Time per operation, in nanoseconds.
+# TYPE go_benchmark_ns_per_op gauge
+go_benchmark_ns_per_op{benchmark="BenchmarkParse",sub="",pkg="example.com/a"} 100
+go_benchmark_ns_per_op{benchmark="BenchmarkParse",sub="",pkg="example.com/b"} 900
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := test.renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, test.benchmarks)
+			if err != nil {
+				t.Fatalf("Error rendering Prometheus: %v", err)
+			}
+			got := output.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestPrometheusRenderer_RenderNoBenchmarks(t *testing.T) {
+	renderer := PrometheusRenderer{}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, nil)
+	if !errors.Is(err, ErrNoBenchmarksProvided) {
+		t.Errorf("Wanted error '%v', got error '%v'", ErrNoBenchmarksProvided, err)
+	}
+}
+
+func TestPrometheusLabel(t *testing.T) {
+	want := `name="a\\b\"c\nd"`
+	got := prometheusLabel("name", "a\\b\"c\nd")
+	if want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestPrometheusLabelName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "valid",
+			input: "workers",
+			want:  "workers",
+		},
+		{
+			name:  "invalid characters",
+			input: "cache-size.kb",
+			want:  "cache_size_kb",
+		},
+		{
+			name:  "leading digit",
+			input: "2d",
+			want:  "_d",
+		},
+		{
+			name:  "reserved",
+			input: "__name",
+			want:  "param__name",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := prometheusLabelName(test.input)
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -3,19 +3,60 @@ package go_benchpress
 import (
 	"fmt"
 	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
 	"golang.org/x/tools/benchmark/parse"
 	"io"
+	"math"
 )
 
 // RasterRenderer outputs a raster graphic based representation of the benchmarks, compared against one another.
 type RasterRenderer struct {
 	Title      string
 	Height     int
+	// Width is the width of the chart - if zero, the charting library's default width is used.
+	Width      int
 	BarWidth   int
 	RenderType RenderType
+	// Theme colours the chart - any colours left unset, as in the zero Theme, are taken from the LightTheme.
+	Theme      Theme
+	// LogScale renders the value axis on a logarithmic scale, for results spanning several orders of magnitude.
+	LogScale bool
+	// ValueLabels annotates each bar with its value.
+	ValueLabels bool
+	// ErrorBars combines repeated samples of each benchmark into a single bar, showing their variance.
+	ErrorBars ErrorBars
+	// Orientation determines whether the bars are drawn vertically or horizontally.
+	Orientation Orientation
+	// ChartType determines the kind of chart drawn - a bar chart, unless otherwise specified.
+	ChartType ChartType
+	// HeatmapX and HeatmapY are the name parameter keys placed on each axis of a heatmap.  If empty, they are
+	// chosen from the benchmark names.
+	HeatmapX string
+	HeatmapY string
+	// ShowSamples draws each sample over its box, on box plots.
+	ShowSamples bool
+	// SecondaryDimension is plotted on the Y axis of scatter charts, against the rendered dimension on the X axis.
+	SecondaryDimension Dimension
+	// PivotX and PivotSeries are the keys arranging the benchmarks of line charts along the X axis and into series.
+	// If empty, they are chosen from the benchmark names - see NewPivot.
+	PivotX      []string
+	PivotSeries []string
+	// Configs holds the configuration of each benchmark, such as its "pkg" - for use as pivot keys.
+	Configs BenchmarkConfigs
+	// Subtitle is drawn beneath the title.
+	Subtitle string
+	// Top keeps only some of the benchmarks on crowded charts, noting those left out in the subtitle.  If its Count is
+	// zero, every benchmark is drawn.  If its Configs are nil, the renderer's Configs are used.
+	Top TopN
+	// Complexity fits complexity models to each series of line charts, across the sizes along the X axis, and draws
+	// the best fit of each as a dashed curve.  Series which cannot be fit, such as those with too few sizes, are drawn
+	// without a curve - see FitPivotComplexity.
+	Complexity bool
 
 	// barChartRenderFunc is used to isolate unit testing - in non-testing usage, points to `renderGraphicalBarChart`.
 	barChartRenderFunc barChartBenchmarkRenderer
+	// onCanvas, if set, is given the size of each chart as its canvas is created - so that grids can size their cells.
+	onCanvas func(width, height int)
 }
 
 func NewRasterRenderer(title string, renderType RenderType) *RasterRenderer {
@@ -24,35 +65,360 @@ func NewRasterRenderer(title string, renderType RenderType) *RasterRenderer {
 		Height:   512,
 		BarWidth: 60,
 		RenderType: renderType,
+		Theme:    LightTheme,
+		Orientation: AutoOrientation,
+		SecondaryDimension: RenderBytesPerOp,
 		barChartRenderFunc: renderGraphicalBarChart,
 	}
 }
 
-func (r *RasterRenderer) Render(writer io.Writer, parentBenchmark string, renderDimension RenderDimension, benchmarks []parse.Benchmark) error {
+func (r *RasterRenderer) Render(writer io.Writer, parentBenchmark string, renderDimension Dimension, benchmarks []parse.Benchmark) error {
 
 	if len(benchmarks) == 0 {
 		return ErrNoBenchmarksProvided
 	}
 
+	if r.Top.Count > 0 {
+		top := r.Top
+		if top.Configs == nil {
+			top.Configs = r.Configs
+		}
+		kept, omitted, err := top.Apply(benchmarks)
+		if err != nil {
+			return err
+		}
+		if omitted > 0 {
+			limited := *r
+			limited.Top = TopN{}
+			limited.Subtitle = r.Top.Subtitle(omitted)
+			if r.Subtitle != "" {
+				limited.Subtitle = r.Subtitle + " - " + limited.Subtitle
+			}
+			return limited.Render(writer, parentBenchmark, renderDimension, kept)
+		}
+	}
+
 	title := r.Title
 	if title == "" {
 		title = parentBenchmark
 	}
 
-	graph, err := r.barChartRenderFunc(title, r.Height, r.BarWidth, renderDimension, benchmarks)
+	switch r.ChartType {
+	case BarChartType:
+		return r.renderBarChart(writer, title, renderDimension, benchmarks)
+	case HeatmapChartType:
+		return r.renderHeatmap(writer, title, renderDimension, benchmarks)
+	case BoxPlotChartType:
+		return r.renderBoxPlot(writer, title, renderDimension, benchmarks)
+	case ScatterChartType:
+		return r.renderScatter(writer, title, renderDimension, benchmarks)
+	case LineChartType:
+		return r.renderLine(writer, title, renderDimension, benchmarks)
+	default:
+		return fmt.Errorf("chart type %q not supported: %w", r.ChartType, ErrUnknownChartType)
+	}
+}
+
+// renderBarChart renders the benchmarks as a bar chart, drawn either vertically or horizontally.
+func (r *RasterRenderer) renderBarChart(writer io.Writer, title string, renderDimension Dimension, benchmarks []parse.Benchmark) error {
+	bars := benchmarks
+	var errs []float64
+	if r.ErrorBars != NoErrorBars {
+		var err error
+		bars, errs, err = summariseSamples(benchmarks, r.Configs, renderDimension, r.ErrorBars)
+		if err != nil {
+			return err
+		}
+	}
+
+	renderBarChart := r.barChartRenderFunc
+	if renderBarChart == nil {
+		renderBarChart = renderGraphicalBarChart
+	}
+	graph, err := renderBarChart(title, r.Height, r.BarWidth, renderDimension, bars)
+	if err != nil {
+		return err
+	}
+
+	graph.Width = r.Width
+
+	err = r.Theme.applyToBarChart(graph)
+	if err != nil {
+		return err
+	}
+
+	r.applyValueRange(graph, renderDimension, errs)
+
+	renderer, err := r.rendererProvider()
+	if err != nil {
+		return err
+	}
+
+	horizontal, err := r.horizontal(graph)
+	if err != nil {
+		return err
+	}
+	if horizontal {
+		return horizontalBarChart{
+			graph:       graph,
+			dimension:   renderDimension,
+			valueLabels: r.ValueLabels,
+			errs:        errs,
+			subtitle:    r.Subtitle,
+		}.Render(renderer, writer)
+	}
+
+	// go-chart does not draw the Y axis name on bar charts, so it is drawn above the axis instead.
+	textColour := graph.GetColorPalette().TextColor()
+	graph.Elements = append(graph.Elements, renderAxisName(graph.YAxis.Name, textColour))
+	if r.Subtitle != "" {
+		graph.Background.Padding.Top += subtitlePadding
+		graph.Elements = append(graph.Elements, renderSubtitle(r.Subtitle, graph.GetWidth(), textColour))
+	}
+	if errs != nil {
+		graph.Elements = append(graph.Elements, renderErrorBars(graph, errs, textColour))
+	}
+	if r.ValueLabels {
+		graph.Elements = append(graph.Elements, renderValueLabels(graph, renderDimension, errs, textColour))
+	}
+	if reference, ok := renderDimension.reference(); ok {
+		graph.Elements = append(graph.Elements, renderReferenceLine(graph, reference))
+	}
+
+	return graph.Render(renderer, writer)
+}
+
+// renderHeatmap renders the benchmarks as a heatmap, arranged by two of their name parameters.
+func (r *RasterRenderer) renderHeatmap(writer io.Writer, title string, renderDimension Dimension, benchmarks []parse.Benchmark) error {
+	heatmap, err := NewHeatmap(benchmarks, renderDimension, r.HeatmapX, r.HeatmapY)
+	if err != nil {
+		return err
+	}
+
+	palette, err := r.Theme.palette()
+	if err != nil {
+		return err
+	}
+
+	renderer, err := r.rendererProvider()
+	if err != nil {
+		return err
+	}
+
+	return heatmapChart{
+		title:     title,
+		width:     r.width(),
+		height:    r.Height,
+		heatmap:   heatmap,
+		dimension: renderDimension,
+		palette:   palette,
+		logScale:  r.LogScale,
+		subtitle:  r.Subtitle,
+	}.Render(renderer, writer)
+}
+
+// renderBoxPlot renders the distribution of each benchmark's samples, run with the same configuration, as a box plot.
+func (r *RasterRenderer) renderBoxPlot(writer io.Writer, title string, renderDimension Dimension, benchmarks []parse.Benchmark) error {
+	series, err := newBoxPlotSeries(GroupSamplesByConfig(benchmarks, r.Configs), renderDimension)
+	if err != nil {
+		return err
+	}
+
+	palette, err := r.Theme.palette()
+	if err != nil {
+		return err
+	}
+
+	renderer, err := r.rendererProvider()
 	if err != nil {
 		return err
 	}
 
-	var renderer chart.RendererProvider
+	return boxPlotChart{
+		title:       title,
+		width:       r.width(),
+		height:      r.Height,
+		boxWidth:    r.BarWidth,
+		series:      series,
+		dimension:   renderDimension,
+		palette:     palette,
+		logScale:    r.LogScale,
+		showSamples: r.ShowSamples,
+		subtitle:    r.Subtitle,
+	}.Render(renderer, writer)
+}
+
+// renderScatter renders the benchmarks as a scatter chart of the rendered dimension against the secondary dimension.
+func (r *RasterRenderer) renderScatter(writer io.Writer, title string, renderDimension Dimension, benchmarks []parse.Benchmark) error {
+	points, err := newScatterPoints(benchmarks, r.Configs, renderDimension, r.SecondaryDimension)
+	if err != nil {
+		return err
+	}
+
+	palette, err := r.Theme.palette()
+	if err != nil {
+		return err
+	}
+
+	renderer, err := r.rendererProvider()
+	if err != nil {
+		return err
+	}
+
+	return scatterChart{
+		title:      title,
+		width:      r.width(),
+		height:     r.Height,
+		points:     points,
+		xDimension: renderDimension,
+		yDimension: r.SecondaryDimension,
+		palette:    palette,
+		logScale:   r.LogScale,
+		subtitle:   r.Subtitle,
+	}.Render(renderer, writer)
+}
+
+// renderLine renders the benchmarks as a line chart, arranged into series along the X axis by the pivot keys.
+func (r *RasterRenderer) renderLine(writer io.Writer, title string, renderDimension Dimension, benchmarks []parse.Benchmark) error {
+	pivot := NewPivot(benchmarks, r.Configs, r.PivotX, r.PivotSeries)
+	series, err := newLineSeries(pivot, renderDimension)
+	if err != nil {
+		return err
+	}
+
+	var fits map[string]ComplexityFit
+	if r.Complexity {
+		// X values which are not sizes leave the chart without curves, rather than failing it.
+		fits, _ = FitPivotComplexity(pivot, renderDimension)
+	}
+
+	palette, err := r.Theme.palette()
+	if err != nil {
+		return err
+	}
+
+	renderer, err := r.rendererProvider()
+	if err != nil {
+		return err
+	}
+
+	return lineChart{
+		title:     title,
+		width:     r.width(),
+		height:    r.Height,
+		pivot:     pivot,
+		series:    series,
+		dimension: renderDimension,
+		palette:   palette,
+		logScale:  r.LogScale,
+		subtitle:  r.Subtitle,
+		fits:      fits,
+	}.Render(renderer, writer)
+}
+
+// width provides the width of the chart.
+func (r *RasterRenderer) width() int {
+	if r.Width > 0 {
+		return r.Width
+	}
+	return chart.DefaultChartWidth
+}
+
+// rendererProvider provides the go-chart renderer for the render type.
+func (r *RasterRenderer) rendererProvider() (chart.RendererProvider, error) {
+	var provider chart.RendererProvider
 	switch r.RenderType {
 	case PNG:
-		renderer = chart.PNG
+		provider = chart.PNG
 	case SVG:
-		renderer = chart.SVG
+		provider = chart.SVG
 	default:
-		return fmt.Errorf("render type %q not supported: %w", r.RenderType, ErrUnknownRenderType)
+		return nil, fmt.Errorf("render type %q not supported: %w", r.RenderType, ErrUnknownRenderType)
 	}
 
-	return graph.Render(renderer, writer)
+	if r.onCanvas == nil {
+		return provider, nil
+	}
+	return func(width, height int) (chart.Renderer, error) {
+		r.onCanvas(width, height)
+		return provider(width, height)
+	}, nil
+}
+
+// horizontal determines whether the graph should be drawn with horizontal bars.
+func (r *RasterRenderer) horizontal(graph *chart.BarChart) (bool, error) {
+	switch r.Orientation {
+	case VerticalOrientation:
+		return false, nil
+	case HorizontalOrientation:
+		return true, nil
+	case AutoOrientation:
+		return labelsOverflow(graph)
+	default:
+		return false, fmt.Errorf("orientation %q not supported: %w", r.Orientation, ErrUnknownOrientation)
+	}
+}
+
+// applyValueRange sets the value axis range of the graph, so that it covers any error bars and value labels.  Where
+// none are displayed, go-chart's default linear range is left in place.
+func (r *RasterRenderer) applyValueRange(graph *chart.BarChart, dimension Dimension, errs []float64) {
+	values := make([]float64, 0, len(graph.Bars)*3)
+	for index, bar := range graph.Bars {
+		values = append(values, bar.Value)
+		if index < len(errs) {
+			values = append(values, bar.Value-errs[index], bar.Value+errs[index])
+		}
+	}
+
+	reference, hasReference := dimension.reference()
+	if hasReference {
+		values = append(values, reference)
+	}
+
+	if r.LogScale {
+		graph.YAxis.Range = newLogarithmicRange(values)
+		// Logarithmic ticks span several units, so each is labelled with its own.
+		graph.YAxis.Name = dimension.Title()
+		graph.YAxis.ValueFormatter = func(v interface{}) string {
+			value, _ := v.(float64)
+			return dimension.FormatValue(value)
+		}
+		return
+	}
+
+	if errs == nil && !r.ValueLabels && !hasReference {
+		return
+	}
+
+	min, max := math.MaxFloat64, -math.MaxFloat64
+	if hasReference {
+		// Relative values are drawn from zero, so that the lengths of the bars reflect their ratios.
+		min = 0
+	}
+	for _, value := range values {
+		min = math.Min(min, value)
+		max = math.Max(max, value)
+	}
+	if r.ValueLabels {
+		// Leave room above the tallest bar for its label.
+		max += (max - min) * 0.1
+	}
+	graph.YAxis.Range = &chart.ContinuousRange{Min: min, Max: max}
+}
+
+// renderAxisName provides a chart element drawing the axis name above the top-right corner of the canvas.
+func renderAxisName(name string, colour drawing.Color) chart.Renderable {
+	return func(r chart.Renderer, canvasBox chart.Box, defaults chart.Style) {
+		if name == "" {
+			return
+		}
+		style := chart.Style{
+			FontColor: colour,
+			FontSize:  chart.DefaultAxisFontSize,
+		}.InheritFrom(defaults)
+		style.WriteTextOptionsToRenderer(r)
+
+		box := r.MeasureText(name)
+		r.Text(name, canvasBox.Right-box.Width(), canvasBox.Top-box.Height())
+	}
 }
@@ -3,8 +3,10 @@ package go_benchpress
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"github.com/wcharczuk/go-chart"
 	"golang.org/x/tools/benchmark/parse"
+	"math"
 	"reflect"
 	"testing"
 )
@@ -170,6 +172,461 @@ func TestRasterRenderer_Render(t *testing.T) {
 	}
 }
 
+func TestRasterRenderer_RenderTheme(t *testing.T) {
+	benchmark := parse.Benchmark{
+		Name:     "Benchmark1",
+		N:        100,
+		NsPerOp:  100,
+		Measured: 1,
+	}
+
+	tests := []struct {
+		name        string
+		theme       Theme
+		wantPalette bool
+		wantError   error
+	}{
+		{
+			name:        "built-in theme",
+			theme:       DarkTheme,
+			wantPalette: true,
+		},
+		{
+			name:        "zero theme",
+			theme:       Theme{},
+			wantPalette: true,
+		},
+		{
+			name:        "partial theme",
+			theme:       Theme{Name: "Partial", Background: "#000"},
+			wantPalette: true,
+		},
+		{
+			name:      "invalid theme",
+			theme:     Theme{Name: "Invalid", Background: "#zzz"},
+			wantError: ErrInvalidThemeColour,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rasterRenderer := NewRasterRenderer("Title", SVG)
+			rasterRenderer.Theme = test.theme
+			fakeBarRenderer := newDefaultFakeBarChartRenderer()
+			rasterRenderer.barChartRenderFunc = fakeBarRenderer.fakeRenderGraphicalBarChart
+
+			err := rasterRenderer.Render(&bytes.Buffer{}, "ParentBenchmark", RenderNsPerOp, []parse.Benchmark{benchmark})
+			if !errors.Is(err, test.wantError) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantError, err)
+			}
+
+			gotPalette := fakeBarRenderer.replyWithChart.ColorPalette != nil
+			if test.wantPalette != gotPalette {
+				t.Errorf("Want palette applied %v, got palette applied %v", test.wantPalette, gotPalette)
+			}
+		})
+	}
+}
+
+func TestRasterRenderer_RenderStructLiteral(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "Benchmark1", N: 100, NsPerOp: 100, Measured: 1},
+		{Name: "Benchmark2", N: 100, NsPerOp: 200, Measured: 1},
+	}
+
+	rasterRenderer := RasterRenderer{Title: "Title", Height: 512, BarWidth: 60, RenderType: SVG}
+	var buf bytes.Buffer
+	err := rasterRenderer.Render(&buf, "ParentBenchmark", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Could not render chart - error: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("Benchmark2")) {
+		t.Errorf("Want chart to contain benchmark %q", "Benchmark2")
+	}
+}
+
+func TestRasterRenderer_RenderLogScale(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/size=10", N: 100, NsPerOp: 15, Measured: 1},
+		{Name: "BenchmarkOne/size=10000000", N: 100, NsPerOp: 15000000, Measured: 1},
+	}
+
+	rasterRenderer := NewRasterRenderer("Title", SVG)
+	rasterRenderer.LogScale = true
+	var graph *chart.BarChart
+	rasterRenderer.barChartRenderFunc = func(title string, height, barWidth int, dimension Dimension, benchmarks []parse.Benchmark) (*chart.BarChart, error) {
+		var err error
+		graph, err = renderGraphicalBarChart(title, height, barWidth, dimension, benchmarks)
+		return graph, err
+	}
+
+	buf := bytes.Buffer{}
+	err := rasterRenderer.Render(&buf, "BenchmarkOne", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Could not render log scale chart - error: %v", err)
+	}
+
+	r, ok := graph.YAxis.Range.(*logarithmicRange)
+	if !ok {
+		t.Fatalf("Want logarithmic range, got %T", graph.YAxis.Range)
+	}
+	if r.GetMin() != 10 || r.GetMax() != 100000000 {
+		t.Errorf("Want range [10, 100000000], got %v", r)
+	}
+	if buf.Len() == 0 {
+		t.Error("Want rendered output, got none")
+	}
+}
+
+func TestRasterRenderer_RenderAnnotations(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/A", N: 100, NsPerOp: 100, Measured: 1},
+		{Name: "BenchmarkOne/B", N: 100, NsPerOp: 300, Measured: 1},
+		{Name: "BenchmarkOne/A", N: 100, NsPerOp: 120, Measured: 1},
+	}
+
+	tests := []struct {
+		name           string
+		valueLabels    bool
+		errorBars      ErrorBars
+		wantBenchmarks []parse.Benchmark
+		wantElements   int
+		wantRange      *chart.ContinuousRange
+	}{
+		{
+			name:           "no annotations",
+			wantBenchmarks: benchmarks,
+			wantElements:   1,
+		},
+		{
+			name:           "value labels",
+			valueLabels:    true,
+			wantBenchmarks: benchmarks,
+			wantElements:   2,
+			wantRange:      &chart.ContinuousRange{Min: 100, Max: 320},
+		},
+		{
+			name:      "error bars",
+			errorBars: StdDevErrorBars,
+			wantBenchmarks: []parse.Benchmark{
+				{Name: "BenchmarkOne/A", N: 100, NsPerOp: 110, Measured: 1},
+				{Name: "BenchmarkOne/B", N: 100, NsPerOp: 300, Measured: 1},
+			},
+			wantElements: 2,
+			wantRange:    &chart.ContinuousRange{Min: 110 - math.Sqrt(200), Max: 300},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rasterRenderer := NewRasterRenderer("Title", SVG)
+			rasterRenderer.ValueLabels = test.valueLabels
+			rasterRenderer.ErrorBars = test.errorBars
+			var graph *chart.BarChart
+			var gotBenchmarks []parse.Benchmark
+			rasterRenderer.barChartRenderFunc = func(title string, height, barWidth int, dimension Dimension, benchmarks []parse.Benchmark) (*chart.BarChart, error) {
+				var err error
+				gotBenchmarks = benchmarks
+				graph, err = renderGraphicalBarChart(title, height, barWidth, dimension, benchmarks)
+				return graph, err
+			}
+
+			buf := bytes.Buffer{}
+			err := rasterRenderer.Render(&buf, "BenchmarkOne", RenderNsPerOp, benchmarks)
+			if err != nil {
+				t.Fatalf("Could not render chart - error: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.wantBenchmarks, gotBenchmarks) {
+				t.Errorf("Want render benchmarks %v, got render benchmarks %v", test.wantBenchmarks, gotBenchmarks)
+			}
+
+			if test.wantElements != len(graph.Elements) {
+				t.Errorf("Want %d chart elements, got %d", test.wantElements, len(graph.Elements))
+			}
+
+			if test.wantRange != nil {
+				gotRange, ok := graph.YAxis.Range.(*chart.ContinuousRange)
+				if !ok {
+					t.Fatalf("Want continuous range, got %T", graph.YAxis.Range)
+				}
+				if test.wantRange.Min != gotRange.Min || test.wantRange.Max != gotRange.Max {
+					t.Errorf("Want range [%v, %v], got [%v, %v]", test.wantRange.Min, test.wantRange.Max, gotRange.Min, gotRange.Max)
+				}
+			}
+		})
+	}
+}
+
+func TestRasterRenderer_RenderOrientation(t *testing.T) {
+	short := []parse.Benchmark{
+		{Name: "BenchmarkOne/A", N: 100, NsPerOp: 100, Measured: 1},
+		{Name: "BenchmarkOne/B", N: 100, NsPerOp: 300, Measured: 1},
+	}
+	long := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=radix/input=sorted/size=1048576", N: 100, NsPerOp: 100, Measured: 1},
+		{Name: "BenchmarkSort/algo=quick/input=reversed/size=1048576", N: 100, NsPerOp: 300, Measured: 1},
+	}
+
+	tests := []struct {
+		name           string
+		orientation    Orientation
+		benchmarks     []parse.Benchmark
+		wantHorizontal bool
+		wantErr        error
+	}{
+		{
+			name:        "vertical",
+			orientation: VerticalOrientation,
+			benchmarks:  long,
+		},
+		{
+			name:           "horizontal",
+			orientation:    HorizontalOrientation,
+			benchmarks:     short,
+			wantHorizontal: true,
+		},
+		{
+			name:        "auto with short labels",
+			orientation: AutoOrientation,
+			benchmarks:  short,
+		},
+		{
+			name:           "auto with long labels",
+			orientation:    AutoOrientation,
+			benchmarks:     long,
+			wantHorizontal: true,
+		},
+		{
+			name:        "unknown",
+			orientation: Orientation(1000),
+			benchmarks:  short,
+			wantErr:     ErrUnknownOrientation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rasterRenderer := NewRasterRenderer("Title", SVG)
+			rasterRenderer.Orientation = test.orientation
+			var graph *chart.BarChart
+			rasterRenderer.barChartRenderFunc = func(title string, height, barWidth int, dimension Dimension, benchmarks []parse.Benchmark) (*chart.BarChart, error) {
+				var err error
+				graph, err = renderGraphicalBarChart(title, height, barWidth, dimension, benchmarks)
+				return graph, err
+			}
+
+			buf := bytes.Buffer{}
+			err := rasterRenderer.Render(&buf, "BenchmarkOne", RenderNsPerOp, test.benchmarks)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			// Vertical charts are drawn by go-chart, with the axis name added as an element.
+			gotHorizontal := len(graph.Elements) == 0
+			if test.wantHorizontal != gotHorizontal {
+				t.Errorf("Want horizontal %v, got horizontal %v", test.wantHorizontal, gotHorizontal)
+			}
+			if buf.Len() == 0 {
+				t.Error("Want chart output, got none")
+			}
+		})
+	}
+}
+
+func TestRasterRenderer_RenderTop(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/A", N: 100, NsPerOp: 100, Measured: 1},
+		{Name: "BenchmarkOne/B", N: 100, NsPerOp: 300, Measured: 1},
+		{Name: "BenchmarkOne/C", N: 100, NsPerOp: 200, Measured: 1},
+		{Name: "BenchmarkOne/D", N: 100, NsPerOp: 50, Measured: 1},
+	}
+
+	for _, orientation := range []Orientation{VerticalOrientation, HorizontalOrientation} {
+		t.Run(orientation.String(), func(t *testing.T) {
+			rasterRenderer := NewRasterRenderer("Title", SVG)
+			rasterRenderer.Orientation = orientation
+			rasterRenderer.Top = TopN{Count: 2, Others: AggregateOthers, Dimension: RenderNsPerOp}
+			var graph *chart.BarChart
+			rasterRenderer.barChartRenderFunc = func(title string, height, barWidth int, dimension Dimension, benchmarks []parse.Benchmark) (*chart.BarChart, error) {
+				var err error
+				graph, err = renderGraphicalBarChart(title, height, barWidth, dimension, benchmarks)
+				return graph, err
+			}
+
+			buf := bytes.Buffer{}
+			err := rasterRenderer.Render(&buf, "BenchmarkOne", RenderNsPerOp, benchmarks)
+			if err != nil {
+				t.Fatalf("Could not render chart - error: %v", err)
+			}
+
+			var got []string
+			for _, bar := range graph.Bars {
+				got = append(got, bar.Label)
+			}
+			want := []string{"B", "C", "Others (2)"}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("Want bars %v, got bars %v", want, got)
+			}
+
+			wantSubtitle := "Largest 2 of 4 by time per op, 2 others averaged"
+			if !bytes.Contains(buf.Bytes(), []byte(wantSubtitle)) {
+				t.Errorf("Want chart to contain subtitle %q", wantSubtitle)
+			}
+			if rasterRenderer.Subtitle != "" {
+				t.Errorf("Want renderer subtitle left unchanged, got %q", rasterRenderer.Subtitle)
+			}
+		})
+	}
+}
+
+func TestRasterRenderer_RenderReferenceLine(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=std/size=10", N: 100, NsPerOp: 100, Measured: 1},
+		{Name: "BenchmarkSort/algo=quick/size=10", N: 100, NsPerOp: 50, Measured: 1},
+		{Name: "BenchmarkSort/algo=std/size=100", N: 100, NsPerOp: 1000, Measured: 1},
+		{Name: "BenchmarkSort/algo=quick/size=100", N: 100, NsPerOp: 400, Measured: 1},
+	}
+	references, err := ReferenceValues(benchmarks, nil, RenderNsPerOp, "algo", "std")
+	if err != nil {
+		t.Fatalf("Could not determine reference values - error: %v", err)
+	}
+	dimension, err := RelativeDimension(RenderNsPerOp, RatioRelative, references, "algo=std")
+	if err != nil {
+		t.Fatalf("Could not create relative dimension - error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		chartType   ChartType
+		orientation Orientation
+	}{
+		{name: "vertical bar chart", chartType: BarChartType, orientation: VerticalOrientation},
+		{name: "horizontal bar chart", chartType: BarChartType, orientation: HorizontalOrientation},
+		{name: "line chart", chartType: LineChartType},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rasterRenderer := NewRasterRenderer("Title", SVG)
+			rasterRenderer.ChartType = test.chartType
+			rasterRenderer.Orientation = test.orientation
+
+			buf := bytes.Buffer{}
+			err := rasterRenderer.Render(&buf, "BenchmarkSort", dimension, benchmarks)
+			if err != nil {
+				t.Fatalf("Could not render chart - error: %v", err)
+			}
+			for _, want := range []string{"stroke-dasharray", "Time per op relative to algo=std"} {
+				if !bytes.Contains(buf.Bytes(), []byte(want)) {
+					t.Errorf("Want chart to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestRasterRenderer_RenderComplexity(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkParse/size=10", N: 100, NsPerOp: 100, Measured: 1},
+		{Name: "BenchmarkParse/size=20", N: 100, NsPerOp: 400, Measured: 1},
+		{Name: "BenchmarkParse/size=40", N: 100, NsPerOp: 1600, Measured: 1},
+		{Name: "BenchmarkParse/size=80", N: 100, NsPerOp: 6400, Measured: 1},
+	}
+
+	for _, complexity := range []bool{false, true} {
+		t.Run(fmt.Sprintf("complexity %v", complexity), func(t *testing.T) {
+			rasterRenderer := NewRasterRenderer("Title", SVG)
+			rasterRenderer.ChartType = LineChartType
+			rasterRenderer.Complexity = complexity
+
+			buf := bytes.Buffer{}
+			err := rasterRenderer.Render(&buf, "BenchmarkParse", RenderNsPerOp, benchmarks)
+			if err != nil {
+				t.Fatalf("Could not render chart - error: %v", err)
+			}
+			for _, want := range []string{"stroke-dasharray", "BenchmarkParse ~ O(n²)"} {
+				if complexity != bytes.Contains(buf.Bytes(), []byte(want)) {
+					t.Errorf("Want chart to contain %q: %v", want, complexity)
+				}
+			}
+		})
+	}
+}
+
+func TestRasterRenderer_RenderChartType(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkCache/workers=1/size=64-8", N: 100, NsPerOp: 100, Measured: 1},
+		{Name: "BenchmarkCache/workers=4/size=64-8", N: 100, NsPerOp: 300, Measured: 1},
+	}
+
+	tests := []struct {
+		name             string
+		chartType        ChartType
+		heatmapX         string
+		wantRenderCalled bool
+		wantErr          error
+	}{
+		{
+			name:             "bar chart",
+			chartType:        BarChartType,
+			wantRenderCalled: true,
+		},
+		{
+			name:      "heatmap",
+			chartType: HeatmapChartType,
+		},
+		{
+			name:      "box plot",
+			chartType: BoxPlotChartType,
+		},
+		{
+			name:      "scatter",
+			chartType: ScatterChartType,
+		},
+		{
+			name:      "line",
+			chartType: LineChartType,
+		},
+		{
+			name:      "heatmap with missing parameter",
+			chartType: HeatmapChartType,
+			heatmapX:  "missing",
+			wantErr:   ErrMissingNameParameter,
+		},
+		{
+			name:      "unknown",
+			chartType: ChartType(1000),
+			wantErr:   ErrUnknownChartType,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rasterRenderer := NewRasterRenderer("Title", SVG)
+			rasterRenderer.ChartType = test.chartType
+			rasterRenderer.HeatmapX = test.heatmapX
+			fakeBarRenderer := newDefaultFakeBarChartRenderer()
+			rasterRenderer.barChartRenderFunc = fakeBarRenderer.fakeRenderGraphicalBarChart
+
+			buf := bytes.Buffer{}
+			err := rasterRenderer.Render(&buf, "BenchmarkCache", RenderNsPerOp, benchmarks)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+
+			if test.wantRenderCalled != fakeBarRenderer.called {
+				t.Errorf("Want bar chart render called %v, got render called %v", test.wantRenderCalled, fakeBarRenderer.called)
+			}
+			if err == nil && buf.Len() == 0 {
+				t.Error("Want chart output, got none")
+			}
+		})
+	}
+}
+
 // ===== fakeBarChartBenchmarkRenderer =====
 
 type fakeBarChartBenchmarkRenderer struct {
@@ -195,12 +652,12 @@ func newDefaultFakeBarChartRenderer() fakeBarChartBenchmarkRenderer {
 	}
 }
 
-func (f *fakeBarChartBenchmarkRenderer) fakeRenderGraphicalBarChart(title string, height int, barWidth int, dimension RenderDimension, benchmarks []parse.Benchmark) (*chart.BarChart, error) {
+func (f *fakeBarChartBenchmarkRenderer) fakeRenderGraphicalBarChart(title string, height int, barWidth int, dimension Dimension, benchmarks []parse.Benchmark) (*chart.BarChart, error) {
 	f.called = true
 	f.title = title
 	f.height = height
 	f.barWidth = barWidth
-	f.dimension = dimension
+	f.dimension, _ = dimension.(RenderDimension)
 	f.benchmarks = benchmarks
 	return f.replyWithChart, f.replyWithError
 }
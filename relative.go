@@ -0,0 +1,265 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/tools/benchmark/parse"
+	"strings"
+)
+
+// ===== RelativeMode =====
+
+// RelativeMode determines how the values of a relative dimension compare benchmarks with their references.
+type RelativeMode int
+
+const (
+	// RatioRelative divides each value by its reference - so 2 is twice the reference.
+	RatioRelative RelativeMode = iota
+	// PercentageRelative gives each value as a percentage of its reference - so 200 is twice the reference.
+	PercentageRelative
+	// SpeedupRelative divides the reference by each value - so 2 is half the reference, or twice as fast.
+	SpeedupRelative
+)
+
+func (m RelativeMode) String() string {
+	switch m {
+	case RatioRelative:
+		return "RATIO"
+	case PercentageRelative:
+		return "PERCENTAGE"
+	case SpeedupRelative:
+		return "SPEEDUP"
+	default:
+		return fmt.Sprintf("Unknown (%d)", m)
+	}
+}
+
+func RelativeModeFromString(str string) (RelativeMode, error) {
+	switch str {
+	case "RATIO":
+		return RatioRelative, nil
+	case "PERCENTAGE":
+		return PercentageRelative, nil
+	case "SPEEDUP":
+		return SpeedupRelative, nil
+	default:
+		return -1, fmt.Errorf("relative mode %q not supported: %w", str, ErrUnknownRelativeMode)
+	}
+}
+
+// ===== References =====
+
+// References holds the value of the reference of each benchmark, as provided by ReferenceValues or BaselineValues.
+type References struct {
+	values  map[string]float64
+	configs BenchmarkConfigs
+	// keys are the configuration keys identifying a benchmark, along with its name - all of them, if nil.
+	keys []string
+}
+
+// Value provides the value of the benchmark's reference, and whether it has one.
+func (r References) Value(benchmark parse.Benchmark) (float64, bool) {
+	value, ok := r.values[referenceKey(benchmark.Name, r.configs[benchmark.Ord], r.keys)]
+	return value, ok
+}
+
+// ReferenceValues provides the value of the reference for each benchmark - the benchmark whose label key has the
+// value, such as "algo" and "std".  Where the key is a parameter of a benchmark's name, its reference is the benchmark
+// of the same name and configuration with the parameter set to the value - so "BenchmarkSort/algo=quick/size=10" is
+// compared with "BenchmarkSort/algo=std/size=10" from the same package.  Where the key is in the benchmark's
+// configuration, such as "file", its reference is the benchmark of the same name with the configuration key set to the
+// value.  Otherwise, its reference is the first benchmark with the label.  Repeated samples are combined into their
+// mean, and benchmarks without a reference are omitted.  If the dimension is unknown, an ErrUnknownDimensionType is
+// returned.
+func ReferenceValues(benchmarks []parse.Benchmark, configs BenchmarkConfigs, dimension Dimension, key, value string) (References, error) {
+	values, err := meanValues(benchmarks, configs, dimension, nil)
+	if err != nil {
+		return References{}, err
+	}
+
+	var fallback string
+	for _, benchmark := range benchmarks {
+		if label, ok := labelValue(key, benchmark, configs[benchmark.Ord]); ok && label == value {
+			fallback = referenceKey(benchmark.Name, configs[benchmark.Ord], nil)
+			break
+		}
+	}
+
+	results := References{values: make(map[string]float64, len(values)), configs: configs}
+	for _, benchmark := range benchmarks {
+		config := configs[benchmark.Ord]
+		reference := fallback
+		if _, ok := config[key]; ok {
+			referenceConfig := make(Config, len(config))
+			for configKey, configValue := range config {
+				referenceConfig[configKey] = configValue
+			}
+			referenceConfig[key] = value
+			reference = referenceKey(benchmark.Name, referenceConfig, nil)
+		}
+		if hasParameter(ParseBenchmarkName(benchmark.Name), key) {
+			reference = referenceKey(referenceName(benchmark.Name, key, value), config, nil)
+		}
+		if referenceValue, ok := values[reference]; ok && reference != "" {
+			results.values[referenceKey(benchmark.Name, config, nil)] = referenceValue
+		}
+	}
+	return results, nil
+}
+
+// BaselineValues provides the value of the reference for each benchmark with its configuration from configs - the
+// baseline benchmark of the same name and package (its "pkg" configuration), with its configuration from
+// baselineConfigs.  Repeated samples are combined into their mean.  If the dimension is unknown, an
+// ErrUnknownDimensionType is returned.
+func BaselineValues(baseline []parse.Benchmark, baselineConfigs, configs BenchmarkConfigs, dimension Dimension) (References, error) {
+	values, err := meanValues(baseline, baselineConfigs, dimension, baselineKeys)
+	if err != nil {
+		return References{}, err
+	}
+	return References{values: values, configs: configs, keys: baselineKeys}, nil
+}
+
+// baselineKeys are the configuration keys identifying a baseline benchmark, along with its name - so that it is
+// matched with results from the same package, however else their configurations differ.
+var baselineKeys = []string{"pkg"}
+
+// meanValues provides the mean value of each benchmark's samples in the dimension, keyed by name and the keys of
+// its configuration - see referenceKey.
+func meanValues(benchmarks []parse.Benchmark, configs BenchmarkConfigs, dimension Dimension, keys []string) (map[string]float64, error) {
+	grouped := make(map[string][]parse.Benchmark)
+	var order []string
+	for _, benchmark := range benchmarks {
+		key := referenceKey(benchmark.Name, configs[benchmark.Ord], keys)
+		if _, ok := grouped[key]; !ok {
+			order = append(order, key)
+		}
+		grouped[key] = append(grouped[key], benchmark)
+	}
+
+	values := make(map[string]float64, len(order))
+	for _, key := range order {
+		value, err := dimension.Value(BenchmarkSamples{Name: grouped[key][0].Name, Benchmarks: grouped[key]}.Mean())
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// referenceKey provides a key identifying a benchmark by its name and the keys of its configuration - all of them, if
+// keys is nil.
+func referenceKey(name string, config Config, keys []string) string {
+	if keys != nil {
+		selected := make(Config, len(keys))
+		for _, key := range keys {
+			if value, ok := config[key]; ok {
+				selected[key] = value
+			}
+		}
+		config = selected
+	}
+	return name + "\n" + configKey(config)
+}
+
+// hasParameter reports whether the name has a parameter with the key.
+func hasParameter(name BenchmarkName, key string) bool {
+	_, ok := name.Parameter(key)
+	return ok
+}
+
+// referenceName provides the name with the value of the parameter replaced.
+func referenceName(name, key, value string) string {
+	trimmed := trimProcs(name)
+	parts := strings.Split(trimmed, "/")
+	for index, part := range parts[1:] {
+		if strings.HasPrefix(part, key+"=") {
+			parts[index+1] = key + "=" + value
+		}
+	}
+	return strings.Join(parts, "/") + name[len(trimmed):]
+}
+
+// ===== Relative dimensions =====
+
+var (
+	ratioUnits      = []unit{{"×", 1}}
+	percentageUnits = []unit{{"%", 1}}
+)
+
+// RelativeDimension creates a dimension comparing the values of the dimension with those of their references, such
+// as those from ReferenceValues or BaselineValues.  The reference is described in the dimension's title - for
+// instance, "algo=std" or "baseline".  Charts of the dimension draw a line where values equal their reference.  The
+// value of a benchmark without a reference, or with a reference (or value, for speedups) of zero, is an
+// ErrMissingReference.  The dimension is named after the dimension and mode, such as "NS_PER_OP_RATIO".  If the mode is
+// unknown, an ErrUnknownRelativeMode is returned.
+func RelativeDimension(dimension Dimension, mode RelativeMode, references References, reference string) (Dimension, error) {
+	derived := &derivedDimension{
+		name:           dimension.String() + "_" + mode.String(),
+		hasReference:   true,
+		referenceValue: 1,
+		scales:         ratioUnits,
+	}
+	switch mode {
+	case RatioRelative:
+		derived.title = fmt.Sprintf("%s relative to %s", dimension.Title(), reference)
+	case PercentageRelative:
+		derived.title = fmt.Sprintf("%s relative to %s", dimension.Title(), reference)
+		derived.referenceValue = 100
+		derived.scales = percentageUnits
+	case SpeedupRelative:
+		derived.title = fmt.Sprintf("Speedup over %s in %s", reference, lowerFirst(dimension.Title()))
+	default:
+		return nil, fmt.Errorf("relative mode %q not supported: %w", mode, ErrUnknownRelativeMode)
+	}
+
+	derived.value = func(benchmark parse.Benchmark) (float64, error) {
+		value, err := dimension.Value(benchmark)
+		if err != nil {
+			return 0, err
+		}
+		referenceValue, ok := references.Value(benchmark)
+		if !ok || referenceValue == 0 || (mode == SpeedupRelative && value == 0) {
+			return 0, fmt.Errorf("benchmark %q has no reference to compare with %s: %w", benchmark.Name, reference, ErrMissingReference)
+		}
+
+		switch mode {
+		case PercentageRelative:
+			return value / referenceValue * 100, nil
+		case SpeedupRelative:
+			return referenceValue / value, nil
+		default:
+			return value / referenceValue, nil
+		}
+	}
+	return derived, nil
+}
+
+// ===== Reference lines =====
+
+// referenceLineStyle provides the style of the line drawn where values equal their reference.
+func referenceLineStyle(style chart.Style) chart.Style {
+	return chart.Style{
+		StrokeColor:     style.StrokeColor,
+		StrokeWidth:     1.5,
+		StrokeDashArray: []float64{6, 4},
+	}
+}
+
+// drawReferenceLine draws a dashed line between the points.
+func drawReferenceLine(r chart.Renderer, lineStyle chart.Style, x1, y1, x2, y2 int) {
+	referenceLineStyle(lineStyle).WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(x1, y1)
+	r.LineTo(x2, y2)
+	r.Stroke()
+}
+
+// renderReferenceLine provides a chart element drawing a line across a vertical bar chart, where values equal their
+// reference.
+func renderReferenceLine(graph *chart.BarChart, reference float64) chart.Renderable {
+	return func(r chart.Renderer, canvasBox chart.Box, defaults chart.Style) {
+		y := canvasBox.Bottom - graph.YAxis.Range.Translate(reference)
+		lineStyle := chart.Style{StrokeColor: graph.GetColorPalette().AxisStrokeColor()}
+		drawReferenceLine(r, lineStyle, canvasBox.Left, y, canvasBox.Right, y)
+	}
+}
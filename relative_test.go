@@ -0,0 +1,273 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"testing"
+)
+
+func TestRelativeMode_String(t *testing.T) {
+	tests := []struct {
+		input RelativeMode
+		want  string
+	}{
+		{RatioRelative, "RATIO"},
+		{PercentageRelative, "PERCENTAGE"},
+		{SpeedupRelative, "SPEEDUP"},
+		{RelativeMode(1000), "Unknown (1000)"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			got := test.input.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRelativeModeFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RelativeMode
+		wantErr error
+	}{
+		{input: "RATIO", want: RatioRelative},
+		{input: "PERCENTAGE", want: PercentageRelative},
+		{input: "SPEEDUP", want: SpeedupRelative},
+		{input: "abc123", want: RelativeMode(-1), wantErr: ErrUnknownRelativeMode},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := RelativeModeFromString(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestReferenceValues(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=std/size=10-8", NsPerOp: 100, Ord: 0},
+		{Name: "BenchmarkSort/algo=quick/size=10-8", NsPerOp: 80, Ord: 1},
+		{Name: "BenchmarkSort/algo=std/size=100-8", NsPerOp: 1000, Ord: 2},
+		{Name: "BenchmarkSort/algo=std/size=100-8", NsPerOp: 2000, Ord: 3},
+		{Name: "BenchmarkSort/algo=quick/size=100-8", NsPerOp: 600, Ord: 4},
+		{Name: "BenchmarkSort/algo=quick/size=1000-8", NsPerOp: 9000, Ord: 5},
+		{Name: "BenchmarkSearch-8", NsPerOp: 50, Ord: 6},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/sort"},
+	}
+
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  []float64
+	}{
+		{
+			name:  "name parameter",
+			key:   "algo",
+			value: "std",
+			// The first benchmark is in another package, so the second has no reference of the same size.  Benchmarks
+			// without the parameter are compared with the first benchmark having it.
+			want: []float64{100, 0, 1500, 1500, 1500, 0, 100},
+		},
+		{
+			name:  "other label",
+			key:   "pkg",
+			value: "example.com/sort",
+			want:  []float64{100, 100, 100, 100, 100, 100, 100},
+		},
+		{
+			name:  "missing reference",
+			key:   "name",
+			value: "BenchmarkMissing",
+			want:  []float64{0, 0, 0, 0, 0, 0, 0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			references, err := ReferenceValues(benchmarks, configs, RenderNsPerOp, test.key, test.value)
+			if err != nil {
+				t.Fatalf("Could not determine reference values - error: %v", err)
+			}
+
+			got := make([]float64, len(benchmarks))
+			for index, benchmark := range benchmarks {
+				got[index], _ = references.Value(benchmark)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestReferenceValuesConfiguration(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=std-8", NsPerOp: 100, Ord: 0},
+		{Name: "BenchmarkSort/algo=quick-8", NsPerOp: 50, Ord: 1},
+		{Name: "BenchmarkSort/algo=std-8", NsPerOp: 1000, Ord: 2},
+		{Name: "BenchmarkSort/algo=quick-8", NsPerOp: 2000, Ord: 3},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a", "file": "old"},
+		1: {"pkg": "example.com/a", "file": "old"},
+		2: {"pkg": "example.com/b", "file": "new"},
+		3: {"pkg": "example.com/b", "file": "new"},
+	}
+
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  []float64
+	}{
+		{
+			// Benchmarks are compared with the reference from their own package.
+			name:  "name parameter",
+			key:   "algo",
+			value: "std",
+			want:  []float64{100, 100, 1000, 1000},
+		},
+		{
+			name:  "configuration key",
+			key:   "pkg",
+			value: "example.com/a",
+			// The configurations also differ by file, so only those of the reference package have one.
+			want: []float64{100, 50, 0, 0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			references, err := ReferenceValues(benchmarks, configs, RenderNsPerOp, test.key, test.value)
+			if err != nil {
+				t.Fatalf("Could not determine reference values - error: %v", err)
+			}
+
+			got := make([]float64, len(benchmarks))
+			for index, benchmark := range benchmarks {
+				got[index], _ = references.Value(benchmark)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBaselineValues(t *testing.T) {
+	baseline := []parse.Benchmark{
+		{Name: "BenchmarkOne", NsPerOp: 100, Ord: 0},
+		{Name: "BenchmarkOne", NsPerOp: 300, Ord: 1},
+		{Name: "BenchmarkTwo", NsPerOp: 50, Ord: 2},
+		{Name: "BenchmarkOne", NsPerOp: 1000, Ord: 3},
+	}
+	baselineConfigs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a", "commit": "abc"},
+		1: {"pkg": "example.com/a", "commit": "abc"},
+		2: {"pkg": "example.com/a", "commit": "abc"},
+		3: {"pkg": "example.com/b", "commit": "abc"},
+	}
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne", Ord: 0},
+		{Name: "BenchmarkTwo", Ord: 1},
+		{Name: "BenchmarkOne", Ord: 2},
+		{Name: "BenchmarkThree", Ord: 3},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a", "commit": "def"},
+		1: {"pkg": "example.com/a", "commit": "def"},
+		2: {"pkg": "example.com/b", "commit": "def"},
+		3: {"pkg": "example.com/a", "commit": "def"},
+	}
+
+	references, err := BaselineValues(baseline, baselineConfigs, configs, RenderNsPerOp)
+	if err != nil {
+		t.Fatalf("Could not determine baseline values - error: %v", err)
+	}
+	got := make([]float64, len(benchmarks))
+	for index, benchmark := range benchmarks {
+		got[index], _ = references.Value(benchmark)
+	}
+	want := []float64{200, 50, 1000, 0}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+
+	_, err = BaselineValues(baseline, baselineConfigs, configs, RenderDimension(1000))
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+}
+
+func TestRelativeDimension(t *testing.T) {
+	references := References{values: map[string]float64{
+		referenceKey("BenchmarkOne", nil, nil):  200,
+		referenceKey("BenchmarkZero", nil, nil): 0,
+	}}
+	benchmark := parse.Benchmark{Name: "BenchmarkOne", NsPerOp: 100}
+
+	tests := []struct {
+		mode          RelativeMode
+		want          float64
+		wantTitle     string
+		wantFormatted string
+		wantReference float64
+	}{
+		{mode: RatioRelative, want: 0.5, wantTitle: "Time per op relative to algo=std", wantFormatted: "0.5×", wantReference: 1},
+		{mode: PercentageRelative, want: 50, wantTitle: "Time per op relative to algo=std", wantFormatted: "50%", wantReference: 100},
+		{mode: SpeedupRelative, want: 2, wantTitle: "Speedup over algo=std in time per op", wantFormatted: "2×", wantReference: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.mode.String(), func(t *testing.T) {
+			dimension, err := RelativeDimension(RenderNsPerOp, test.mode, references, "algo=std")
+			if err != nil {
+				t.Fatalf("Could not create relative dimension - error: %v", err)
+			}
+
+			got, err := dimension.Value(benchmark)
+			if err != nil {
+				t.Fatalf("Could not determine value - error: %v", err)
+			}
+			if test.want != got {
+				t.Errorf("Want value %v, got value %v", test.want, got)
+			}
+			if want := "NS_PER_OP_" + test.mode.String(); want != dimension.String() {
+				t.Errorf("Want name %q, got name %q", want, dimension.String())
+			}
+			if test.wantTitle != dimension.Title() {
+				t.Errorf("Want title %q, got title %q", test.wantTitle, dimension.Title())
+			}
+			if formatted := dimension.FormatValue(got); test.wantFormatted != formatted {
+				t.Errorf("Want formatted value %q, got %q", test.wantFormatted, formatted)
+			}
+			if reference, ok := dimension.reference(); !ok || test.wantReference != reference {
+				t.Errorf("Want reference line at %v, got %v (drawn: %v)", test.wantReference, reference, ok)
+			}
+
+			for _, missing := range []parse.Benchmark{{Name: "BenchmarkMissing", NsPerOp: 100}, {Name: "BenchmarkZero", NsPerOp: 100}} {
+				_, err = dimension.Value(missing)
+				if !errors.Is(err, ErrMissingReference) {
+					t.Errorf("Want error '%v' for %q, got error '%v'", ErrMissingReference, missing.Name, err)
+				}
+			}
+		})
+	}
+
+	_, err := RelativeDimension(RenderNsPerOp, RelativeMode(1000), references, "algo=std")
+	if !errors.Is(err, ErrUnknownRelativeMode) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownRelativeMode, err)
+	}
+
+	if _, ok := RenderNsPerOp.reference(); ok {
+		t.Error("Want no reference line for absolute dimensions")
+	}
+}
@@ -16,6 +16,15 @@ const (
 	JSON
 	CSV
 	XML
+	VEGALITE
+	GNUPLOT
+	PGFPLOTS
+	MERMAID
+	PROMETHEUS
+	INFLUX
+	JUNIT
+	SARIF
+	BENCHFMT
 )
 
 func (r RenderType) String() string {
@@ -30,6 +39,24 @@ func (r RenderType) String() string {
 		return "CSV"
 	case XML:
 		return "XML"
+	case VEGALITE:
+		return "VEGALITE"
+	case GNUPLOT:
+		return "GNUPLOT"
+	case PGFPLOTS:
+		return "PGFPLOTS"
+	case MERMAID:
+		return "MERMAID"
+	case PROMETHEUS:
+		return "PROMETHEUS"
+	case INFLUX:
+		return "INFLUX"
+	case JUNIT:
+		return "JUNIT"
+	case SARIF:
+		return "SARIF"
+	case BENCHFMT:
+		return "BENCHFMT"
 	default:
 		return fmt.Sprintf("Unknown (%d)", r)
 	}
@@ -42,11 +69,29 @@ func (r RenderType) Renderer(title string) (Renderer, error) {
 	case PNG, SVG:
 		return NewRasterRenderer(title, r), nil
 	case JSON:
-		return &JSONRenderer{}, nil
+		return &JSONRenderer{SecondaryDimension: RenderBytesPerOp}, nil
 	case CSV:
-		return &CSVRenderer{}, nil
+		return &CSVRenderer{SecondaryDimension: RenderBytesPerOp}, nil
 	case XML:
 		return &XMLRenderer{}, nil
+	case VEGALITE:
+		return &VegaLiteRenderer{Title: title}, nil
+	case GNUPLOT:
+		return &GnuplotRenderer{Title: title}, nil
+	case PGFPLOTS:
+		return &PGFPlotsRenderer{Title: title}, nil
+	case MERMAID:
+		return &MermaidRenderer{Title: title}, nil
+	case PROMETHEUS:
+		return &PrometheusRenderer{}, nil
+	case INFLUX:
+		return &InfluxRenderer{}, nil
+	case JUNIT:
+		return &JUnitRenderer{}, nil
+	case SARIF:
+		return &SARIFRenderer{}, nil
+	case BENCHFMT:
+		return &BenchfmtRenderer{}, nil
 	default:
 		return nil, ErrUnknownRenderType
 	}
@@ -64,6 +109,24 @@ func (r RenderType) FileExtension() string {
 		return ".csv"
 	case XML:
 		return ".xml"
+	case VEGALITE:
+		return ".json"
+	case GNUPLOT:
+		return ".gp"
+	case PGFPLOTS:
+		return ".tex"
+	case MERMAID:
+		return ".mmd"
+	case PROMETHEUS:
+		return ".prom"
+	case INFLUX:
+		return ".lp"
+	case JUNIT:
+		return ".xml"
+	case SARIF:
+		return ".sarif"
+	case BENCHFMT:
+		return ".txt"
 	default:
 		return ""
 	}
@@ -81,11 +144,60 @@ func RenderTypeFromString(str string) (RenderType, error) {
 		return CSV, nil
 	case "XML":
 		return XML, nil
+	case "VEGALITE":
+		return VEGALITE, nil
+	case "GNUPLOT":
+		return GNUPLOT, nil
+	case "PGFPLOTS":
+		return PGFPLOTS, nil
+	case "MERMAID":
+		return MERMAID, nil
+	case "PROMETHEUS":
+		return PROMETHEUS, nil
+	case "INFLUX":
+		return INFLUX, nil
+	case "JUNIT":
+		return JUNIT, nil
+	case "SARIF":
+		return SARIF, nil
+	case "BENCHFMT":
+		return BENCHFMT, nil
 	default:
 		return -1, fmt.Errorf("render type %q not supported: %w", str, ErrUnknownRenderType)
 	}
 }
 
+// ===== Dimension =====
+
+// Dimension is a measure of the benchmarks which can be rendered - either a built-in RenderDimension, or one derived
+// from the benchmarks, such as by DimensionDefinition.Define or RelativeDimension.
+type Dimension interface {
+	// String provides the name of the dimension, by which it is found with DimensionFromString.
+	String() string
+	// Title provides a human-readable name for the dimension, suitable for use in axis titles and table headings.
+	Title() string
+	// Value provides the value of the benchmark in the dimension.  If it has none, an error is returned.
+	Value(benchmark parse.Benchmark) (float64, error)
+	// FormatValue formats a value of the dimension using the most readable unit.
+	FormatValue(value float64) string
+	// units provides the units the dimension can be scaled to, from smallest to largest.
+	units() []unit
+	// reference provides the value at which charts of the dimension draw a reference line, and whether they do - for
+	// instance, 1 for ratios to a reference benchmark.
+	reference() (float64, bool)
+}
+
+// DimensionFromString provides the dimension with the name - either a built-in RenderDimension, or otherwise one of
+// the defined dimensions.  If there is none, an ErrUnknownDimensionType is returned.
+func DimensionFromString(str string, defined []Dimension) (Dimension, error) {
+	for _, dimension := range defined {
+		if dimension.String() == str {
+			return dimension, nil
+		}
+	}
+	return RenderDimensionFromString(str)
+}
+
 // ===== RenderDimension =====
 
 type RenderDimension int
@@ -109,6 +221,26 @@ func (r RenderDimension) String() string {
 	}
 }
 
+// Value provides the value of the benchmark in this dimension - for instance, NsPerOp for RenderNsPerOp.
+// If the dimension is unknown, an ErrUnknownDimensionType is returned.
+func (r RenderDimension) Value(benchmark parse.Benchmark) (float64, error) {
+	switch r {
+	case RenderNsPerOp:
+		return benchmark.NsPerOp, nil
+	case RenderBytesPerOp:
+		return float64(benchmark.AllocedBytesPerOp), nil
+	case RenderAllocsPerOp:
+		return float64(benchmark.AllocsPerOp), nil
+	default:
+		return 0, fmt.Errorf("render dimension type %q not supported: %w", r, ErrUnknownDimensionType)
+	}
+}
+
+// reference reports that charts of built-in dimensions draw no reference line.
+func (r RenderDimension) reference() (float64, bool) {
+	return 0, false
+}
+
 func RenderDimensionFromString(str string) (RenderDimension, error) {
 	switch str {
 	case "NS_PER_OP":
@@ -125,5 +257,5 @@ func RenderDimensionFromString(str string) (RenderDimension, error) {
 // ===== Renderer =====
 
 type Renderer interface {
-	Render(writer io.Writer, parentBenchmark string, dimension RenderDimension, benchmarks []parse.Benchmark) error
+	Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error
 }
@@ -2,6 +2,7 @@ package go_benchpress
 
 import (
 	"errors"
+	"golang.org/x/tools/benchmark/parse"
 	"reflect"
 	"testing"
 )
@@ -39,6 +40,51 @@ func TestRenderType_String(t *testing.T) {
 			input: XML,
 			want:  "XML",
 		},
+		{
+			name:  "vegalite",
+			input: VEGALITE,
+			want:  "VEGALITE",
+		},
+		{
+			name:  "gnuplot",
+			input: GNUPLOT,
+			want:  "GNUPLOT",
+		},
+		{
+			name:  "pgfplots",
+			input: PGFPLOTS,
+			want:  "PGFPLOTS",
+		},
+		{
+			name:  "mermaid",
+			input: MERMAID,
+			want:  "MERMAID",
+		},
+		{
+			name:  "prometheus",
+			input: PROMETHEUS,
+			want:  "PROMETHEUS",
+		},
+		{
+			name:  "influx",
+			input: INFLUX,
+			want:  "INFLUX",
+		},
+		{
+			name:  "junit",
+			input: JUNIT,
+			want:  "JUNIT",
+		},
+		{
+			name:  "sarif",
+			input: SARIF,
+			want:  "SARIF",
+		},
+		{
+			name:  "benchfmt",
+			input: BENCHFMT,
+			want:  "BENCHFMT",
+		},
 		{
 			name:  "unknown",
 			input: RenderType(1000),
@@ -87,6 +133,51 @@ func TestRenderTypeFromString(t *testing.T) {
 			input: "XML",
 			want:  XML,
 		},
+		{
+			name:  "vegalite",
+			input: "VEGALITE",
+			want:  VEGALITE,
+		},
+		{
+			name:  "gnuplot",
+			input: "GNUPLOT",
+			want:  GNUPLOT,
+		},
+		{
+			name:  "pgfplots",
+			input: "PGFPLOTS",
+			want:  PGFPLOTS,
+		},
+		{
+			name:  "mermaid",
+			input: "MERMAID",
+			want:  MERMAID,
+		},
+		{
+			name:  "prometheus",
+			input: "PROMETHEUS",
+			want:  PROMETHEUS,
+		},
+		{
+			name:  "influx",
+			input: "INFLUX",
+			want:  INFLUX,
+		},
+		{
+			name:  "junit",
+			input: "JUNIT",
+			want:  JUNIT,
+		},
+		{
+			name:  "sarif",
+			input: "SARIF",
+			want:  SARIF,
+		},
+		{
+			name:  "benchfmt",
+			input: "BENCHFMT",
+			want:  BENCHFMT,
+		},
 		{
 			name:    "unknown",
 			input:   "abc123",
@@ -155,7 +246,7 @@ func TestRenderType_Renderer(t *testing.T) {
 				if !ok {
 					t.Fatal("Could not convert renderer to JSONRenderer")
 				}
-				want := JSONRenderer{}
+				want := JSONRenderer{SecondaryDimension: RenderBytesPerOp}
 
 				if !reflect.DeepEqual(want, *raster) {
 					t.Errorf("Wanted %v, got %v", want, *raster)
@@ -170,7 +261,7 @@ func TestRenderType_Renderer(t *testing.T) {
 				if !ok {
 					t.Fatal("Could not convert renderer to CSVRenderer")
 				}
-				want := CSVRenderer{}
+				want := CSVRenderer{SecondaryDimension: RenderBytesPerOp}
 
 				if !reflect.DeepEqual(want, *raster) {
 					t.Errorf("Wanted %v, got %v", want, *raster)
@@ -192,6 +283,141 @@ func TestRenderType_Renderer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "vegalite",
+			input: VEGALITE,
+			wantCmp: func(t *testing.T, got Renderer) {
+				raster, ok := got.(*VegaLiteRenderer)
+				if !ok {
+					t.Fatal("Could not convert renderer to VegaLiteRenderer")
+				}
+				want := VegaLiteRenderer{Title: "title"}
+
+				if !reflect.DeepEqual(want, *raster) {
+					t.Errorf("Wanted %v, got %v", want, *raster)
+				}
+			},
+		},
+		{
+			name:  "gnuplot",
+			input: GNUPLOT,
+			wantCmp: func(t *testing.T, got Renderer) {
+				raster, ok := got.(*GnuplotRenderer)
+				if !ok {
+					t.Fatal("Could not convert renderer to GnuplotRenderer")
+				}
+				want := GnuplotRenderer{Title: "title"}
+
+				if !reflect.DeepEqual(want, *raster) {
+					t.Errorf("Wanted %v, got %v", want, *raster)
+				}
+			},
+		},
+		{
+			name:  "pgfplots",
+			input: PGFPLOTS,
+			wantCmp: func(t *testing.T, got Renderer) {
+				raster, ok := got.(*PGFPlotsRenderer)
+				if !ok {
+					t.Fatal("Could not convert renderer to PGFPlotsRenderer")
+				}
+				want := PGFPlotsRenderer{Title: "title"}
+
+				if !reflect.DeepEqual(want, *raster) {
+					t.Errorf("Wanted %v, got %v", want, *raster)
+				}
+			},
+		},
+		{
+			name:  "mermaid",
+			input: MERMAID,
+			wantCmp: func(t *testing.T, got Renderer) {
+				raster, ok := got.(*MermaidRenderer)
+				if !ok {
+					t.Fatal("Could not convert renderer to MermaidRenderer")
+				}
+				want := MermaidRenderer{Title: "title"}
+
+				if !reflect.DeepEqual(want, *raster) {
+					t.Errorf("Wanted %v, got %v", want, *raster)
+				}
+			},
+		},
+		{
+			name:  "prometheus",
+			input: PROMETHEUS,
+			wantCmp: func(t *testing.T, got Renderer) {
+				raster, ok := got.(*PrometheusRenderer)
+				if !ok {
+					t.Fatal("Could not convert renderer to PrometheusRenderer")
+				}
+				want := PrometheusRenderer{}
+
+				if !reflect.DeepEqual(want, *raster) {
+					t.Errorf("Wanted %v, got %v", want, *raster)
+				}
+			},
+		},
+		{
+			name:  "influx",
+			input: INFLUX,
+			wantCmp: func(t *testing.T, got Renderer) {
+				raster, ok := got.(*InfluxRenderer)
+				if !ok {
+					t.Fatal("Could not convert renderer to InfluxRenderer")
+				}
+				want := InfluxRenderer{}
+
+				if !reflect.DeepEqual(want, *raster) {
+					t.Errorf("Wanted %v, got %v", want, *raster)
+				}
+			},
+		},
+		{
+			name:  "junit",
+			input: JUNIT,
+			wantCmp: func(t *testing.T, got Renderer) {
+				raster, ok := got.(*JUnitRenderer)
+				if !ok {
+					t.Fatal("Could not convert renderer to JUnitRenderer")
+				}
+				want := JUnitRenderer{}
+
+				if !reflect.DeepEqual(want, *raster) {
+					t.Errorf("Wanted %v, got %v", want, *raster)
+				}
+			},
+		},
+		{
+			name:  "sarif",
+			input: SARIF,
+			wantCmp: func(t *testing.T, got Renderer) {
+				raster, ok := got.(*SARIFRenderer)
+				if !ok {
+					t.Fatal("Could not convert renderer to SARIFRenderer")
+				}
+				want := SARIFRenderer{}
+
+				if !reflect.DeepEqual(want, *raster) {
+					t.Errorf("Wanted %v, got %v", want, *raster)
+				}
+			},
+		},
+		{
+			name:  "benchfmt",
+			input: BENCHFMT,
+			wantCmp: func(t *testing.T, got Renderer) {
+				raster, ok := got.(*BenchfmtRenderer)
+				if !ok {
+					t.Fatal("Could not convert renderer to BenchfmtRenderer")
+				}
+				want := BenchfmtRenderer{}
+
+				if !reflect.DeepEqual(want, *raster) {
+					t.Errorf("Wanted %v, got %v", want, *raster)
+				}
+			},
+		},
 		{
 			name: "unknown",
 			input: RenderType(1000),
@@ -244,6 +470,51 @@ func TestRenderType_FileExtension(t *testing.T) {
 			input: XML,
 			want: ".xml",
 		},
+		{
+			name: "vegalite",
+			input: VEGALITE,
+			want: ".json",
+		},
+		{
+			name: "gnuplot",
+			input: GNUPLOT,
+			want: ".gp",
+		},
+		{
+			name: "pgfplots",
+			input: PGFPLOTS,
+			want: ".tex",
+		},
+		{
+			name: "mermaid",
+			input: MERMAID,
+			want: ".mmd",
+		},
+		{
+			name: "prometheus",
+			input: PROMETHEUS,
+			want: ".prom",
+		},
+		{
+			name: "influx",
+			input: INFLUX,
+			want: ".lp",
+		},
+		{
+			name: "junit",
+			input: JUNIT,
+			want: ".xml",
+		},
+		{
+			name: "sarif",
+			input: SARIF,
+			want: ".sarif",
+		},
+		{
+			name: "benchfmt",
+			input: BENCHFMT,
+			want: ".txt",
+		},
 		{
 			name: "unknown",
 			input: RenderType(1000),
@@ -300,6 +571,55 @@ func TestRenderDimension_String(t *testing.T) {
 	}
 }
 
+func TestRenderDimension_Value(t *testing.T) {
+	benchmark := parse.Benchmark{
+		Name:              "BenchmarkOne/SubBenchmark",
+		N:                 100,
+		NsPerOp:           1000,
+		AllocedBytesPerOp: 10000,
+		AllocsPerOp:       100000,
+	}
+
+	tests := []struct {
+		name    string
+		input   RenderDimension
+		want    float64
+		wantErr error
+	}{
+		{
+			name:  "ns per op",
+			input: RenderNsPerOp,
+			want:  1000,
+		},
+		{
+			name:  "bytes per op",
+			input: RenderBytesPerOp,
+			want:  10000,
+		},
+		{
+			name:  "allocs per op",
+			input: RenderAllocsPerOp,
+			want:  100000,
+		},
+		{
+			name:    "unknown",
+			input:   RenderDimension(1000),
+			wantErr: ErrUnknownDimensionType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.input.Value(benchmark)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
 func TestRenderDimensionFromString(t *testing.T) {
 	tests := []struct {
 		name    string
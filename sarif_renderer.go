@@ -0,0 +1,184 @@
+package go_benchpress
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	// sarifRegressionRule is the ID of the rule reported for each regressed benchmark.
+	sarifRegressionRule = "benchmark-regression"
+)
+
+// SARIFRenderer outputs a SARIF 2.1.0 log of the benchmarks which regressed from the Baseline, in the rendered
+// dimension, for code scanning tools.  Each result is located at the benchmark's function or sub-benchmark, found
+// within the Go source.
+type SARIFRenderer struct {
+	// Baseline holds the benchmarks to compare against.  If empty, no comparison is made, and the log has no results.
+	Baseline []parse.Benchmark
+	// Tolerance is the fraction by which benchmarks may regress from the Baseline before being reported - for
+	// instance, 0.05 allows a 5% regression.
+	Tolerance float64
+	// SourceDir is the root of the source holding the benchmark functions, to which the result locations are
+	// relative.  If empty, the current directory is used.
+	SourceDir string
+	// Locations holds the benchmarks already located under SourceDir.  If nil, SourceDir is searched on each render.
+	Locations BenchmarkLocations
+	// Configs holds the configuration of each benchmark, whose "pkg" identifies the package it is located in.  Each
+	// benchmark is compared with the baseline benchmark from its own package.
+	Configs BenchmarkConfigs
+	// BaselineConfigs holds the configuration of each Baseline result, by its Ord.
+	BaselineConfigs BenchmarkConfigs
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string             `json:"ruleId"`
+	Level      string             `json:"level"`
+	Message    sarifMessage       `json:"message"`
+	Locations  []sarifLocation    `json:"locations,omitempty"`
+	Properties map[string]float64 `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+func (s *SARIFRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
+	if len(benchmarks) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	comparisons, err := Compare(s.Baseline, s.BaselineConfigs, benchmarks, s.Configs, dimension)
+	if err != nil {
+		return err
+	}
+
+	locations := s.Locations
+	if locations == nil {
+		sourceDir := s.SourceDir
+		if sourceDir == "" {
+			sourceDir = "."
+		}
+		locations, err = LocateBenchmarks(sourceDir)
+		if err != nil {
+			return fmt.Errorf("could not locate benchmarks: %w", err)
+		}
+	}
+
+	// Results must be an empty list, rather than null, where nothing regressed.
+	results := make([]sarifResult, 0)
+	for _, comparison := range comparisons {
+		if !comparison.Regressed(s.Tolerance) {
+			continue
+		}
+
+		result := sarifResult{
+			RuleID: sarifRegressionRule,
+			Level:  "warning",
+			Message: sarifMessage{
+				Text: fmt.Sprintf("%s: %s regressed by %s from the baseline (%s to %s), beyond the %s tolerance",
+					comparison.Name, dimension.Title(), formatPercentage(comparison.Change),
+					dimension.FormatValue(comparison.Baseline), dimension.FormatValue(comparison.Current),
+					formatPercentage(s.Tolerance)),
+			},
+			Properties: map[string]float64{
+				"baseline": comparison.Baseline,
+				"current":  comparison.Current,
+			},
+		}
+		// An infinite change cannot be represented in JSON, so is left out.
+		if comparison.Baseline != 0 {
+			result.Properties["change"] = comparison.Change
+		}
+
+		if location, ok := locations.Find(comparison.Package, comparison.Name); ok {
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: location.File, URIBaseID: "%SRCROOT%"},
+					Region:           sarifRegion{StartLine: location.Line},
+				},
+			}}
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "gobenchpress",
+				InformationURI: "https://github.com/rpickz/go-benchpress",
+				Rules: []sarifRule{{
+					ID:                   sarifRegressionRule,
+					Name:                 "BenchmarkRegression",
+					ShortDescription:     sarifMessage{Text: "Benchmark regressed from its baseline"},
+					DefaultConfiguration: sarifConfiguration{Level: "warning"},
+				}},
+			}},
+			Results: results,
+		}},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+	return err
+}
@@ -0,0 +1,198 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestSARIFRenderer_Render(t *testing.T) {
+	dir := writeSourceFiles(t, map[string]string{
+		"sort/sort_test.go": `package sort
+
+import "testing"
+
+func BenchmarkSort(b *testing.B) {
+	b.Run("radix", func(b *testing.B) {})
+}
+`,
+	})
+
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/quick-8", NsPerOp: 100},
+		{Name: "BenchmarkSort/radix-8", NsPerOp: 300},
+		{Name: "BenchmarkMissing-8", NsPerOp: 300},
+	}
+	baseline := []parse.Benchmark{
+		{Name: "BenchmarkSort/quick-8", NsPerOp: 100},
+		{Name: "BenchmarkSort/radix-8", NsPerOp: 200},
+		{Name: "BenchmarkMissing-8", NsPerOp: 200},
+	}
+
+	renderer := SARIFRenderer{Baseline: baseline, Tolerance: 0.05, SourceDir: dir}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkSort", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering SARIF: %v", err)
+	}
+
+	var log sarifLog
+	err = json.Unmarshal(output.Bytes(), &log)
+	if err != nil {
+		t.Fatalf("Could not decode SARIF log - error: %v", err)
+	}
+
+	if log.Version != sarifVersion || log.Schema != sarifSchema {
+		t.Errorf("Wanted version %q and schema %q, got %q and %q", sarifVersion, sarifSchema, log.Version, log.Schema)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("Wanted 1 run, got %d", len(log.Runs))
+	}
+
+	want := []sarifResult{
+		{
+			RuleID:  sarifRegressionRule,
+			Level:   "warning",
+			Message: sarifMessage{Text: "BenchmarkSort/radix-8: Time per op regressed by 50% from the baseline (200ns to 300ns), beyond the 5% tolerance"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: "sort/sort_test.go", URIBaseID: "%SRCROOT%"},
+					Region:           sarifRegion{StartLine: 6},
+				},
+			}},
+			Properties: map[string]float64{"baseline": 200, "current": 300, "change": 0.5},
+		},
+		{
+			RuleID:     sarifRegressionRule,
+			Level:      "warning",
+			Message:    sarifMessage{Text: "BenchmarkMissing-8: Time per op regressed by 50% from the baseline (200ns to 300ns), beyond the 5% tolerance"},
+			Properties: map[string]float64{"baseline": 200, "current": 300, "change": 0.5},
+		},
+	}
+	if !reflect.DeepEqual(want, log.Runs[0].Results) {
+		t.Errorf("want %+v, got %+v", want, log.Runs[0].Results)
+	}
+}
+
+func TestSARIFRenderer_RenderPackages(t *testing.T) {
+	dir := writeSourceFiles(t, map[string]string{
+		"go.mod": "module example.com/shop\n",
+		"cache/cache_test.go": `package cache
+
+import "testing"
+
+func BenchmarkGet(b *testing.B) {}
+`,
+		"store/store_test.go": `package store
+
+import "testing"
+
+
+func BenchmarkGet(b *testing.B) {}
+`,
+	})
+
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkGet-8", NsPerOp: 300, Ord: 0},
+		{Name: "BenchmarkGet-8", NsPerOp: 300, Ord: 1},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/shop/store"},
+		1: {"pkg": "example.com/shop/cache"},
+	}
+	baseline := []parse.Benchmark{
+		{Name: "BenchmarkGet-8", NsPerOp: 200, Ord: 0},
+		{Name: "BenchmarkGet-8", NsPerOp: 100, Ord: 1},
+	}
+
+	renderer := SARIFRenderer{Baseline: baseline, BaselineConfigs: configs, Tolerance: 0.05, SourceDir: dir, Configs: configs}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkGet", RenderNsPerOp, benchmarks)
+	if err != nil {
+		t.Fatalf("Error rendering SARIF: %v", err)
+	}
+
+	var log sarifLog
+	err = json.Unmarshal(output.Bytes(), &log)
+	if err != nil {
+		t.Fatalf("Could not decode SARIF log - error: %v", err)
+	}
+
+	want := []sarifResult{
+		{
+			RuleID:  sarifRegressionRule,
+			Level:   "warning",
+			Message: sarifMessage{Text: "BenchmarkGet-8: Time per op regressed by 50% from the baseline (200ns to 300ns), beyond the 5% tolerance"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: "store/store_test.go", URIBaseID: "%SRCROOT%"},
+					Region:           sarifRegion{StartLine: 6},
+				},
+			}},
+			Properties: map[string]float64{"baseline": 200, "current": 300, "change": 0.5},
+		},
+		{
+			RuleID:  sarifRegressionRule,
+			Level:   "warning",
+			Message: sarifMessage{Text: "BenchmarkGet-8: Time per op regressed by 200% from the baseline (100ns to 300ns), beyond the 5% tolerance"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: "cache/cache_test.go", URIBaseID: "%SRCROOT%"},
+					Region:           sarifRegion{StartLine: 5},
+				},
+			}},
+			Properties: map[string]float64{"baseline": 100, "current": 300, "change": 2},
+		},
+	}
+	if !reflect.DeepEqual(want, log.Runs[0].Results) {
+		t.Errorf("want %+v, got %+v", want, log.Runs[0].Results)
+	}
+}
+
+func TestSARIFRenderer_RenderNoBaseline(t *testing.T) {
+	renderer := SARIFRenderer{SourceDir: t.TempDir()}
+	var output bytes.Buffer
+	err := renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, []parse.Benchmark{{Name: "BenchmarkOne", NsPerOp: 100}})
+	if err != nil {
+		t.Fatalf("Error rendering SARIF: %v", err)
+	}
+
+	if !bytes.Contains(output.Bytes(), []byte(`"results": []`)) {
+		t.Errorf("Wanted an empty list of results, got %q", output.String())
+	}
+}
+
+func TestSARIFRenderer_RenderErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		renderer   SARIFRenderer
+		benchmarks []parse.Benchmark
+		wantErr    error
+	}{
+		{
+			name:       "no benchmarks",
+			renderer:   SARIFRenderer{},
+			benchmarks: nil,
+			wantErr:    ErrNoBenchmarksProvided,
+		},
+		{
+			name:       "missing source directory",
+			renderer:   SARIFRenderer{SourceDir: "does-not-exist"},
+			benchmarks: []parse.Benchmark{{Name: "BenchmarkOne", NsPerOp: 100}},
+			wantErr:    os.ErrNotExist,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := test.renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, test.benchmarks)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Wanted error '%v', got error '%v'", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"math"
+	"sort"
+)
+
+// ParetoFrontier provides the benchmarks which are Pareto optimal in the two dimensions - those for which no other
+// benchmark is at least as good in both dimensions, and better in one.  Lower values are better in every dimension.
+// Repeated samples run with the same configuration from configs are combined into their mean, as on scatter charts,
+// so the frontier holds the mean of each optimal benchmark, ordered by the first dimension.  If either dimension is
+// unknown, an error is returned, and if the dimensions are the same, an ErrIdenticalDimensions.
+func ParetoFrontier(benchmarks []parse.Benchmark, configs BenchmarkConfigs, x, y Dimension) ([]parse.Benchmark, error) {
+	means := sampleMeans(benchmarks, configs)
+	optimal, err := paretoOptimal(means, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	var frontier []parse.Benchmark
+	for index, benchmark := range means {
+		if optimal[index] {
+			frontier = append(frontier, benchmark)
+		}
+	}
+
+	sort.SliceStable(frontier, func(i, j int) bool {
+		a, _ := x.Value(frontier[i])
+		b, _ := x.Value(frontier[j])
+		return a < b
+	})
+	return frontier, nil
+}
+
+// sampleMeans provides the mean of the samples of each benchmark run with each configuration, in order of their first
+// appearance.
+func sampleMeans(benchmarks []parse.Benchmark, configs BenchmarkConfigs) []parse.Benchmark {
+	samples := GroupSamplesByConfig(benchmarks, configs)
+	means := make([]parse.Benchmark, 0, len(samples))
+	for _, s := range samples {
+		means = append(means, s.Mean())
+	}
+	return means
+}
+
+// paretoOptimal reports whether each of the benchmarks is Pareto optimal in the two dimensions.
+func paretoOptimal(benchmarks []parse.Benchmark, x, y Dimension) ([]bool, error) {
+	if x == y {
+		return nil, fmt.Errorf("dimension %s cannot be compared with itself: %w", x, ErrIdenticalDimensions)
+	}
+
+	type point struct {
+		x, y float64
+	}
+	points := make([]point, 0, len(benchmarks))
+	for _, benchmark := range benchmarks {
+		xValue, err := x.Value(benchmark)
+		if err != nil {
+			return nil, err
+		}
+		yValue, err := y.Value(benchmark)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point{xValue, yValue})
+	}
+
+	optimal := make([]bool, len(points))
+	for i, p := range points {
+		optimal[i] = true
+		for _, q := range points {
+			if q.x <= p.x && q.y <= p.y && (q.x < p.x || q.y < p.y) {
+				optimal[i] = false
+				break
+			}
+		}
+	}
+	return optimal, nil
+}
+
+// ===== Scatter chart =====
+
+const (
+	// scatterPointRadius is the radius of each benchmark's point.
+	scatterPointRadius = 4
+	// scatterLabelGap is the space between a point and its label.
+	scatterLabelGap = 6
+)
+
+// scatterPoint is a single benchmark plotted on a scatter chart.
+type scatterPoint struct {
+	label   string
+	x, y    float64
+	optimal bool
+}
+
+// newScatterPoints provides a point for each benchmark and configuration, with repeated samples combined into their
+// mean.
+func newScatterPoints(benchmarks []parse.Benchmark, configs BenchmarkConfigs, x, y Dimension) ([]scatterPoint, error) {
+	means := sampleMeans(benchmarks, configs)
+	optimal, err := paretoOptimal(means, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]scatterPoint, 0, len(means))
+	for index, benchmark := range means {
+		xValue, _ := x.Value(benchmark)
+		yValue, _ := y.Value(benchmark)
+		points = append(points, scatterPoint{
+			label:   subBenchmarkLabel(benchmark.Name),
+			x:       xValue,
+			y:       yValue,
+			optimal: optimal[index],
+		})
+	}
+	return points, nil
+}
+
+// scatterChart plots the benchmarks against two dimensions, highlighting the Pareto frontier.
+type scatterChart struct {
+	title      string
+	width      int
+	height     int
+	points     []scatterPoint
+	xDimension Dimension
+	yDimension Dimension
+	palette    chart.ColorPalette
+	logScale   bool
+	// subtitle is drawn beneath the title, if not empty.
+	subtitle string
+}
+
+// axisRange provides the range of an axis covering the values.  Linear axes start from zero, so that the distances
+// between the points reflect their ratios.
+func (s scatterChart) axisRange(values []float64) chart.Range {
+	if s.logScale {
+		return newLogarithmicRange(values)
+	}
+
+	min, max := 0.0, 0.0
+	for _, value := range values {
+		min = math.Min(min, value)
+		max = math.Max(max, value)
+	}
+	// Leave a margin, so the extreme points are not drawn on the edges of the chart.
+	max += (max - min) * 0.05
+	if max == min {
+		max = min + 1
+	}
+	return &chart.ContinuousRange{Min: min, Max: max}
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+func (s scatterChart) Render(rp chart.RendererProvider, w io.Writer) error {
+	if len(s.points) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	r, err := rp(s.width, s.height)
+	if err != nil {
+		return err
+	}
+	font, err := chart.GetDefaultFont()
+	if err != nil {
+		return err
+	}
+
+	textStyle := chart.Style{
+		Font:      font,
+		FontSize:  chart.DefaultAxisFontSize,
+		FontColor: s.palette.TextColor(),
+	}
+	lineStyle := chart.Style{
+		StrokeColor: s.palette.AxisStrokeColor(),
+		StrokeWidth: 1,
+	}
+
+	chart.Draw.Box(r, chart.Box{Right: s.width, Bottom: s.height}, chart.Style{
+		FillColor:   s.palette.BackgroundColor(),
+		StrokeColor: s.palette.BackgroundStrokeColor(),
+		StrokeWidth: chart.DefaultStrokeWidth,
+	})
+
+	if s.title != "" {
+		titleStyle := textStyle
+		titleStyle.FontSize = 18
+		titleStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(s.title)
+		r.Text(s.title, s.width>>1-box.Width()>>1, chart.DefaultTitleTop+box.Height())
+	}
+	drawSubtitle(r, textStyle, s.subtitle, s.width)
+
+	grid := chart.Box{
+		Top:    horizontalTitleHeight,
+		Left:   boxPlotAxisWidth,
+		Right:  s.width - horizontalPadding - horizontalValueLabelWidth,
+		Bottom: s.height - horizontalAxisHeight,
+	}
+
+	xValues := make([]float64, 0, len(s.points))
+	yValues := make([]float64, 0, len(s.points))
+	for _, p := range s.points {
+		xValues = append(xValues, p.x)
+		yValues = append(yValues, p.y)
+	}
+	xRange := s.axisRange(xValues)
+	xRange.SetDomain(grid.Width())
+	yRange := s.axisRange(yValues)
+	yRange.SetDomain(grid.Height())
+	position := func(p scatterPoint) (int, int) {
+		return grid.Left + xRange.Translate(p.x), grid.Bottom - yRange.Translate(p.y)
+	}
+
+	s.drawAxes(r, grid, xRange, yRange, textStyle, lineStyle)
+
+	// The frontier is drawn as a line through the optimal points, in order along the X axis.
+	var frontier []scatterPoint
+	for _, p := range s.points {
+		if p.optimal {
+			frontier = append(frontier, p)
+		}
+	}
+	sort.SliceStable(frontier, func(i, j int) bool {
+		return frontier[i].x < frontier[j].x
+	})
+	optimalColour := s.palette.GetSeriesColor(0)
+	frontierStyle := chart.Style{StrokeColor: optimalColour, StrokeWidth: 1.5, StrokeDashArray: []float64{5, 3}}
+	frontierStyle.WriteDrawingOptionsToRenderer(r)
+	for index, p := range frontier {
+		x, y := position(p)
+		if index == 0 {
+			r.MoveTo(x, y)
+		} else {
+			r.LineTo(x, y)
+		}
+	}
+	r.Stroke()
+	r.SetStrokeDashArray(nil)
+
+	for _, p := range s.points {
+		x, y := position(p)
+		pointStyle := chart.Style{StrokeColor: s.palette.AxisStrokeColor(), StrokeWidth: 1.5, FillColor: s.palette.BackgroundColor()}
+		if p.optimal {
+			pointStyle = chart.Style{StrokeColor: optimalColour, StrokeWidth: 1.5, FillColor: optimalColour}
+		}
+		pointStyle.WriteDrawingOptionsToRenderer(r)
+		r.Circle(scatterPointRadius, x, y)
+		r.FillStroke()
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(p.label)
+		r.Text(p.label, x+scatterLabelGap, y+box.Height()>>1)
+	}
+
+	s.drawLegend(r, grid, textStyle)
+
+	return r.Save(w)
+}
+
+// drawAxes draws the X axis along the bottom and the Y axis along the left of the grid, with their ticks and names.
+func (s scatterChart) drawAxes(r chart.Renderer, grid chart.Box, xRange, yRange chart.Range, textStyle, lineStyle chart.Style) {
+	lineStyle.WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(grid.Left, grid.Top)
+	r.LineTo(grid.Left, grid.Bottom)
+	r.LineTo(grid.Right, grid.Bottom)
+	r.Stroke()
+
+	xName, xFormatter := axisLabels(s.xDimension, xRange, s.logScale)
+	var tickHeight int
+	for _, tick := range (chart.XAxis{Range: xRange}).GetTicks(r, xRange, textStyle, xFormatter) {
+		tickX := grid.Left + xRange.Translate(tick.Value)
+
+		lineStyle.WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(tickX, grid.Bottom)
+		r.LineTo(tickX, grid.Bottom+chart.DefaultVerticalTickHeight)
+		r.Stroke()
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(tick.Label)
+		tickHeight = box.Height()
+		r.Text(tick.Label, tickX-box.Width()>>1, grid.Bottom+chart.DefaultXAxisMargin+box.Height())
+	}
+
+	yName, yFormatter := axisLabels(s.yDimension, yRange, s.logScale)
+	for _, tick := range (chart.YAxis{Range: yRange}).GetTicks(r, yRange, textStyle, yFormatter) {
+		tickY := grid.Bottom - yRange.Translate(tick.Value)
+
+		lineStyle.WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(grid.Left-chart.DefaultHorizontalTickWidth, tickY)
+		r.LineTo(grid.Left, tickY)
+		r.Stroke()
+
+		textStyle.WriteTextOptionsToRenderer(r)
+		box := r.MeasureText(tick.Label)
+		r.Text(tick.Label, grid.Left-chart.DefaultYAxisMargin-box.Width(), tickY+box.Height()>>1)
+	}
+
+	textStyle.WriteTextOptionsToRenderer(r)
+	xBox := r.MeasureText(xName)
+	r.Text(xName, grid.Left+grid.Width()>>1-xBox.Width()>>1, grid.Bottom+2*chart.DefaultXAxisMargin+tickHeight+xBox.Height())
+	yBox := r.MeasureText(yName)
+	r.Text(yName, grid.Left-yBox.Width()>>1, grid.Top-chart.DefaultYAxisMargin-yBox.Height()>>1)
+}
+
+// drawLegend explains the point styles, above the top-right corner of the grid.
+func (s scatterChart) drawLegend(r chart.Renderer, grid chart.Box, textStyle chart.Style) {
+	const label = "Pareto optimal"
+	colour := s.palette.GetSeriesColor(0)
+
+	textStyle.WriteTextOptionsToRenderer(r)
+	box := r.MeasureText(label)
+	y := grid.Top - chart.DefaultYAxisMargin - box.Height()>>1
+	r.Text(label, grid.Right-box.Width(), y+box.Height()>>1)
+
+	pointStyle := chart.Style{StrokeColor: colour, StrokeWidth: 1.5, FillColor: colour}
+	pointStyle.WriteDrawingOptionsToRenderer(r)
+	r.Circle(scatterPointRadius, grid.Right-box.Width()-scatterLabelGap-scatterPointRadius, y)
+	r.FillStroke()
+}
@@ -0,0 +1,190 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ===== ParetoFrontier tests =====
+
+func TestParetoFrontier(t *testing.T) {
+	fast := parse.Benchmark{Name: "BenchmarkMap/fast", NsPerOp: 70, AllocedBytesPerOp: 6000}
+	balanced := parse.Benchmark{Name: "BenchmarkMap/balanced", NsPerOp: 100, AllocedBytesPerOp: 4000}
+	small := parse.Benchmark{Name: "BenchmarkMap/small", NsPerOp: 300, AllocedBytesPerOp: 1500}
+	dominated := parse.Benchmark{Name: "BenchmarkMap/dominated", NsPerOp: 150, AllocedBytesPerOp: 5000}
+	tied := parse.Benchmark{Name: "BenchmarkMap/tied", NsPerOp: 100, AllocedBytesPerOp: 4000}
+
+	tests := []struct {
+		name       string
+		benchmarks []parse.Benchmark
+		y          RenderDimension
+		want       []parse.Benchmark
+		wantErr    error
+	}{
+		{
+			name:       "frontier ordered by first dimension",
+			benchmarks: []parse.Benchmark{small, dominated, fast, balanced},
+			y:          RenderBytesPerOp,
+			want:       []parse.Benchmark{fast, balanced, small},
+		},
+		{
+			name:       "equal benchmarks are both optimal",
+			benchmarks: []parse.Benchmark{balanced, tied, dominated},
+			y:          RenderBytesPerOp,
+			want:       []parse.Benchmark{balanced, tied},
+		},
+		{
+			name:       "single benchmark",
+			benchmarks: []parse.Benchmark{dominated},
+			y:          RenderBytesPerOp,
+			want:       []parse.Benchmark{dominated},
+		},
+		{
+			name: "samples combined into their mean",
+			benchmarks: []parse.Benchmark{
+				{Name: "BenchmarkMap/noisy", NsPerOp: 50, AllocedBytesPerOp: 4000},
+				balanced,
+				{Name: "BenchmarkMap/noisy", NsPerOp: 250, AllocedBytesPerOp: 4000},
+			},
+			y:    RenderBytesPerOp,
+			want: []parse.Benchmark{balanced},
+		},
+		{
+			name:       "unknown dimension",
+			benchmarks: []parse.Benchmark{dominated},
+			y:          RenderDimension(1000),
+			wantErr:    ErrUnknownDimensionType,
+		},
+		{
+			name:       "identical dimensions",
+			benchmarks: []parse.Benchmark{dominated},
+			y:          RenderNsPerOp,
+			wantErr:    ErrIdenticalDimensions,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParetoFrontier(test.benchmarks, nil, RenderNsPerOp, test.y)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+// ===== scatterChart tests =====
+
+func TestNewScatterPoints(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkMap/fast", NsPerOp: 60, AllocedBytesPerOp: 6000},
+		{Name: "BenchmarkMap/slow", NsPerOp: 200, AllocedBytesPerOp: 7000},
+		{Name: "BenchmarkMap/fast", NsPerOp: 80, AllocedBytesPerOp: 6000},
+	}
+
+	got, err := newScatterPoints(benchmarks, nil, RenderNsPerOp, RenderBytesPerOp)
+	if err != nil {
+		t.Fatalf("Could not create points - error: %v", err)
+	}
+
+	want := []scatterPoint{
+		{label: "fast", x: 70, y: 6000, optimal: true},
+		{label: "slow", x: 200, y: 7000, optimal: false},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestScatterChart_AxisRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		logScale bool
+		wantMin  float64
+		wantMax  float64
+	}{
+		{
+			name:    "linear from zero",
+			values:  []float64{100, 200},
+			wantMin: 0,
+			wantMax: 210,
+		},
+		{
+			name:    "all zero",
+			values:  []float64{0, 0},
+			wantMin: 0,
+			wantMax: 1,
+		},
+		{
+			name:     "logarithmic",
+			values:   []float64{15, 1500},
+			logScale: true,
+			wantMin:  10,
+			wantMax:  10000,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := scatterChart{logScale: test.logScale}.axisRange(test.values)
+			if test.wantMin != got.GetMin() || test.wantMax != got.GetMax() {
+				t.Errorf("Want range [%v, %v], got [%v, %v]", test.wantMin, test.wantMax, got.GetMin(), got.GetMax())
+			}
+		})
+	}
+}
+
+func TestScatterChart_Render(t *testing.T) {
+	palette, err := LightTheme.palette()
+	if err != nil {
+		t.Fatalf("Could not create palette - error: %v", err)
+	}
+	points := []scatterPoint{
+		{label: "fast", x: 70, y: 6000, optimal: true},
+		{label: "small", x: 300, y: 1500, optimal: true},
+		{label: "slow", x: 400, y: 7000},
+	}
+
+	for _, logScale := range []bool{false, true} {
+		buf := bytes.Buffer{}
+		s := scatterChart{
+			title:      "BenchmarkMap",
+			width:      1024,
+			height:     512,
+			points:     points,
+			xDimension: RenderNsPerOp,
+			yDimension: RenderBytesPerOp,
+			palette:    palette,
+			logScale:   logScale,
+		}
+		err = s.Render(chart.SVG, &buf)
+		if err != nil {
+			t.Fatalf("Could not render chart - error: %v", err)
+		}
+
+		xmlData := make([]interface{}, 0)
+		err = xml.Unmarshal(buf.Bytes(), &xmlData)
+		if err != nil {
+			t.Errorf("Error unmarshalling SVG as XML - error: %v", err)
+		}
+
+		for _, want := range []string{"BenchmarkMap", "fast", "small", "slow", "Time per op", "Bytes per op", "Pareto optimal"} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("Wanted SVG output to contain %q", want)
+			}
+		}
+	}
+
+	err = scatterChart{}.Render(chart.SVG, &bytes.Buffer{})
+	if !errors.Is(err, ErrNoBenchmarksProvided) {
+		t.Errorf("Want error '%v', got error '%v'", ErrNoBenchmarksProvided, err)
+	}
+}
@@ -0,0 +1,275 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ===== SortOrder =====
+
+// SortOrder determines the order of benchmarks, and of the sets they are grouped into.
+type SortOrder int
+
+const (
+	// InputOrder keeps the order the benchmarks were read in.
+	InputOrder SortOrder = iota
+	// NameOrder orders by name, comparing runs of digits by their numeric value - so that "size=20" comes before
+	// "size=100".
+	NameOrder
+	// ValueAscendingOrder orders by value in the sorted dimension, smallest first.
+	ValueAscendingOrder
+	// ValueDescendingOrder orders by value in the sorted dimension, largest first.
+	ValueDescendingOrder
+	// LabelOrder orders by the value of a label, such as a name parameter - numerically, where the values are numbers.
+	LabelOrder
+)
+
+func (s SortOrder) String() string {
+	switch s {
+	case InputOrder:
+		return "INPUT"
+	case NameOrder:
+		return "NAME"
+	case ValueAscendingOrder:
+		return "VALUE_ASC"
+	case ValueDescendingOrder:
+		return "VALUE_DESC"
+	case LabelOrder:
+		return "LABEL"
+	default:
+		return fmt.Sprintf("Unknown (%d)", s)
+	}
+}
+
+func SortOrderFromString(str string) (SortOrder, error) {
+	switch str {
+	case "INPUT":
+		return InputOrder, nil
+	case "NAME":
+		return NameOrder, nil
+	case "VALUE_ASC":
+		return ValueAscendingOrder, nil
+	case "VALUE_DESC":
+		return ValueDescendingOrder, nil
+	case "LABEL":
+		return LabelOrder, nil
+	default:
+		return -1, fmt.Errorf("sort order %q not supported: %w", str, ErrUnknownSortOrder)
+	}
+}
+
+// ===== Sorter =====
+
+// Sorter orders benchmarks, and the sets they are grouped into.  Repeated samples of a benchmark run with the same
+// configuration are kept together, and ordered by their mean value.  Ties keep the order the benchmarks were read in.
+type Sorter struct {
+	Order SortOrder
+	// Dimension is the dimension ordered by, for the value orders.
+	Dimension Dimension
+	// Keys are the labels ordered by, for LabelOrder - compared in turn, see ParseProjectionKeys for the supported
+	// keys.  Benchmarks without a label come after those with it.
+	Keys []string
+	// Configs holds the configuration of each benchmark, such as its "pkg" - for use as the Key, and to tell apart
+	// benchmarks sharing a name.
+	Configs BenchmarkConfigs
+}
+
+// sortEntry is a single item being sorted - a benchmark's samples, or a set of benchmarks.
+type sortEntry struct {
+	name   string
+	value  float64
+	labels []string
+	// hasLabels reports whether the entry has each of the labels being sorted by.
+	hasLabels  []bool
+	benchmarks []parse.Benchmark
+}
+
+// Sort provides the benchmarks in order, with repeated samples of a benchmark placed together.  If the order or
+// dimension is unknown, an error is returned.
+func (s Sorter) Sort(benchmarks []parse.Benchmark) ([]parse.Benchmark, error) {
+	if s.Order == InputOrder {
+		return benchmarks, nil
+	}
+
+	groups := GroupSamplesByConfig(benchmarks, s.Configs)
+	entries := make([]sortEntry, 0, len(groups))
+	for _, samples := range groups {
+		entry, err := s.newEntry(samples.Name, samples.Benchmarks)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	err := s.sortEntries(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]parse.Benchmark, 0, len(benchmarks))
+	for _, entry := range entries {
+		results = append(results, entry.benchmarks...)
+	}
+	return results, nil
+}
+
+// SortSets provides the names of the sets in order.  Sets are ordered by their name, the mean value of their
+// benchmarks, or the label of their first benchmark.  For InputOrder, input holds the benchmarks in the order they
+// were read, and sets are in order of their first benchmark's appearance.  If the order or dimension is unknown, an
+// error is returned.
+func (s Sorter) SortSets(sets BenchmarkSets, input []parse.Benchmark) ([]string, error) {
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	// Sorting begins from name order, so that ties are broken the same way every time.
+	sort.Strings(names)
+
+	if s.Order == InputOrder {
+		positions := make(map[string]int, len(input))
+		for index := len(input) - 1; index >= 0; index-- {
+			positions[input[index].Name] = index
+		}
+		first := func(name string) int {
+			result := len(input)
+			for _, benchmark := range sets[name] {
+				if position, ok := positions[benchmark.Name]; ok && position < result {
+					result = position
+				}
+			}
+			return result
+		}
+		sort.SliceStable(names, func(i, j int) bool {
+			return first(names[i]) < first(names[j])
+		})
+		return names, nil
+	}
+
+	entries := make([]sortEntry, 0, len(names))
+	for _, name := range names {
+		entry, err := s.newEntry(name, sets[name])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	err := s.sortEntries(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	for index, entry := range entries {
+		names[index] = entry.name
+	}
+	return names, nil
+}
+
+// newEntry creates an entry for the benchmarks, with their mean value and the label of the first benchmark.
+func (s Sorter) newEntry(name string, benchmarks []parse.Benchmark) (sortEntry, error) {
+	entry := sortEntry{name: name, benchmarks: benchmarks}
+
+	if s.Order == ValueAscendingOrder || s.Order == ValueDescendingOrder {
+		var total float64
+		for _, benchmark := range benchmarks {
+			value, err := s.Dimension.Value(benchmark)
+			if err != nil {
+				return sortEntry{}, err
+			}
+			total += value
+		}
+		if len(benchmarks) > 0 {
+			entry.value = total / float64(len(benchmarks))
+		}
+	}
+
+	if s.Order == LabelOrder && len(benchmarks) > 0 {
+		for _, key := range s.Keys {
+			label, ok := labelValue(key, benchmarks[0], s.Configs[benchmarks[0].Ord])
+			entry.labels = append(entry.labels, label)
+			entry.hasLabels = append(entry.hasLabels, ok)
+		}
+	}
+	return entry, nil
+}
+
+// sortEntries sorts the entries, keeping the order of ties.
+func (s Sorter) sortEntries(entries []sortEntry) error {
+	var less func(a, b sortEntry) bool
+	switch s.Order {
+	case NameOrder:
+		less = func(a, b sortEntry) bool {
+			return naturalLess(a.name, b.name)
+		}
+	case ValueAscendingOrder:
+		less = func(a, b sortEntry) bool {
+			return a.value < b.value
+		}
+	case ValueDescendingOrder:
+		less = func(a, b sortEntry) bool {
+			return a.value > b.value
+		}
+	case LabelOrder:
+		less = func(a, b sortEntry) bool {
+			for index := range a.labels {
+				if a.hasLabels[index] != b.hasLabels[index] {
+					return a.hasLabels[index]
+				}
+				if a.labels[index] != b.labels[index] {
+					return naturalLess(a.labels[index], b.labels[index])
+				}
+			}
+			return false
+		}
+	default:
+		return fmt.Errorf("sort order %q not supported: %w", s.Order, ErrUnknownSortOrder)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return less(entries[i], entries[j])
+	})
+	return nil
+}
+
+// naturalLess reports whether a comes before b in natural order - comparing runs of digits by their numeric value,
+// and everything else character by character.
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		aDigits, bDigits := leadingDigits(a), leadingDigits(b)
+		if aDigits != "" && bDigits != "" {
+			aNumber, bNumber := strings.TrimLeft(aDigits, "0"), strings.TrimLeft(bDigits, "0")
+			if len(aNumber) != len(bNumber) {
+				return len(aNumber) < len(bNumber)
+			}
+			if aNumber != bNumber {
+				return aNumber < bNumber
+			}
+			// Equal numbers with fewer leading zeros come first.
+			if len(aDigits) != len(bDigits) {
+				return len(aDigits) < len(bDigits)
+			}
+			a, b = a[len(aDigits):], b[len(bDigits):]
+			continue
+		}
+
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// leadingDigits provides the run of digits at the start of the string.
+func leadingDigits(str string) string {
+	end := strings.IndexFunc(str, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+	if end < 0 {
+		return str
+	}
+	return str[:end]
+}
@@ -0,0 +1,231 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"testing"
+)
+
+func TestSortOrder_String(t *testing.T) {
+	tests := []struct {
+		input SortOrder
+		want  string
+	}{
+		{InputOrder, "INPUT"},
+		{NameOrder, "NAME"},
+		{ValueAscendingOrder, "VALUE_ASC"},
+		{ValueDescendingOrder, "VALUE_DESC"},
+		{LabelOrder, "LABEL"},
+		{SortOrder(1000), "Unknown (1000)"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			got := test.input.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestSortOrderFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    SortOrder
+		wantErr error
+	}{
+		{input: "INPUT", want: InputOrder},
+		{input: "NAME", want: NameOrder},
+		{input: "VALUE_ASC", want: ValueAscendingOrder},
+		{input: "VALUE_DESC", want: ValueDescendingOrder},
+		{input: "LABEL", want: LabelOrder},
+		{input: "abc123", want: SortOrder(-1), wantErr: ErrUnknownSortOrder},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := SortOrderFromString(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+// sortNames provides the names of the benchmarks, in order.
+func sortNames(benchmarks []parse.Benchmark) []string {
+	names := make([]string, 0, len(benchmarks))
+	for _, benchmark := range benchmarks {
+		names = append(names, benchmark.Name)
+	}
+	return names
+}
+
+func TestSorter_Sort(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/size=10-8", NsPerOp: 30, Ord: 0},
+		{Name: "BenchmarkSort/size=100-8", NsPerOp: 10, Ord: 1},
+		{Name: "BenchmarkSort/size=20-8", NsPerOp: 20, Ord: 2},
+		{Name: "BenchmarkSort/size=100-8", NsPerOp: 60, Ord: 3},
+		{Name: "BenchmarkSort/other-8", NsPerOp: 40, Ord: 4},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "b"},
+		1: {"pkg": "a"},
+		2: {"pkg": "a"},
+		3: {"pkg": "a"},
+		4: {"pkg": "c"},
+	}
+
+	tests := []struct {
+		name   string
+		sorter Sorter
+		want   []string
+	}{
+		{
+			name:   "input",
+			sorter: Sorter{Order: InputOrder},
+			want:   []string{"BenchmarkSort/size=10-8", "BenchmarkSort/size=100-8", "BenchmarkSort/size=20-8", "BenchmarkSort/size=100-8", "BenchmarkSort/other-8"},
+		},
+		{
+			name:   "name",
+			sorter: Sorter{Order: NameOrder},
+			want:   []string{"BenchmarkSort/other-8", "BenchmarkSort/size=10-8", "BenchmarkSort/size=20-8", "BenchmarkSort/size=100-8", "BenchmarkSort/size=100-8"},
+		},
+		{
+			name:   "value ascending by mean of samples",
+			sorter: Sorter{Order: ValueAscendingOrder, Dimension: RenderNsPerOp},
+			want:   []string{"BenchmarkSort/size=20-8", "BenchmarkSort/size=10-8", "BenchmarkSort/size=100-8", "BenchmarkSort/size=100-8", "BenchmarkSort/other-8"},
+		},
+		{
+			name:   "value descending by mean of samples",
+			sorter: Sorter{Order: ValueDescendingOrder, Dimension: RenderNsPerOp},
+			want:   []string{"BenchmarkSort/other-8", "BenchmarkSort/size=100-8", "BenchmarkSort/size=100-8", "BenchmarkSort/size=10-8", "BenchmarkSort/size=20-8"},
+		},
+		{
+			name:   "label with missing values last",
+			sorter: Sorter{Order: LabelOrder, Keys: []string{"size"}},
+			want:   []string{"BenchmarkSort/size=10-8", "BenchmarkSort/size=20-8", "BenchmarkSort/size=100-8", "BenchmarkSort/size=100-8", "BenchmarkSort/other-8"},
+		},
+		{
+			name:   "labels compared in turn",
+			sorter: Sorter{Order: LabelOrder, Keys: []string{"pkg", "size"}, Configs: configs},
+			want:   []string{"BenchmarkSort/size=20-8", "BenchmarkSort/size=100-8", "BenchmarkSort/size=100-8", "BenchmarkSort/size=10-8", "BenchmarkSort/other-8"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.sorter.Sort(benchmarks)
+			if err != nil {
+				t.Fatalf("Could not sort benchmarks - error: %v", err)
+			}
+			if !reflect.DeepEqual(test.want, sortNames(got)) {
+				t.Errorf("Want %v, got %v", test.want, sortNames(got))
+			}
+		})
+	}
+}
+
+func TestSorter_SortConfigs(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort-8", NsPerOp: 10, Ord: 0},
+		{Name: "BenchmarkSort-8", NsPerOp: 50, Ord: 1},
+		{Name: "BenchmarkOther-8", NsPerOp: 30, Ord: 2},
+		{Name: "BenchmarkSort-8", NsPerOp: 20, Ord: 3},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "a"},
+		1: {"pkg": "b"},
+		2: {"pkg": "a"},
+		3: {"pkg": "a"},
+	}
+
+	sorter := Sorter{Order: ValueAscendingOrder, Dimension: RenderNsPerOp, Configs: configs}
+	got, err := sorter.Sort(benchmarks)
+	if err != nil {
+		t.Fatalf("Could not sort benchmarks - error: %v", err)
+	}
+
+	var gotOrds []int
+	for _, benchmark := range got {
+		gotOrds = append(gotOrds, benchmark.Ord)
+	}
+	wantOrds := []int{0, 3, 2, 1}
+	if !reflect.DeepEqual(wantOrds, gotOrds) {
+		t.Errorf("Want %v, got %v", wantOrds, gotOrds)
+	}
+}
+
+func TestSorter_SortErrors(t *testing.T) {
+	benchmarks := []parse.Benchmark{{Name: "BenchmarkOne", NsPerOp: 10}, {Name: "BenchmarkTwo", NsPerOp: 20}}
+
+	_, err := Sorter{Order: SortOrder(1000)}.Sort(benchmarks)
+	if !errors.Is(err, ErrUnknownSortOrder) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownSortOrder, err)
+	}
+
+	_, err = Sorter{Order: ValueAscendingOrder, Dimension: RenderDimension(1000)}.Sort(benchmarks)
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+}
+
+func TestSorter_SortSets(t *testing.T) {
+	input := []parse.Benchmark{
+		{Name: "BenchmarkB/size=1", NsPerOp: 10},
+		{Name: "BenchmarkC10/size=1", NsPerOp: 30},
+		{Name: "BenchmarkA/size=2", NsPerOp: 5},
+		{Name: "BenchmarkC9/size=3", NsPerOp: 20},
+	}
+	sets := SeparateBenchmarks(input)
+
+	tests := []struct {
+		name   string
+		sorter Sorter
+		want   []string
+	}{
+		{name: "input", sorter: Sorter{Order: InputOrder}, want: []string{"BenchmarkB", "BenchmarkC10", "BenchmarkA", "BenchmarkC9"}},
+		{name: "name", sorter: Sorter{Order: NameOrder}, want: []string{"BenchmarkA", "BenchmarkB", "BenchmarkC9", "BenchmarkC10"}},
+		{name: "value ascending", sorter: Sorter{Order: ValueAscendingOrder, Dimension: RenderNsPerOp}, want: []string{"BenchmarkA", "BenchmarkB", "BenchmarkC9", "BenchmarkC10"}},
+		{name: "value descending", sorter: Sorter{Order: ValueDescendingOrder, Dimension: RenderNsPerOp}, want: []string{"BenchmarkC10", "BenchmarkC9", "BenchmarkB", "BenchmarkA"}},
+		{name: "label", sorter: Sorter{Order: LabelOrder, Keys: []string{"size"}}, want: []string{"BenchmarkB", "BenchmarkC10", "BenchmarkA", "BenchmarkC9"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.sorter.SortSets(sets, input)
+			if err != nil {
+				t.Fatalf("Could not sort sets - error: %v", err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestNaturalLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "size=20", b: "size=100", want: true},
+		{a: "size=100", b: "size=20", want: false},
+		{a: "a", b: "b", want: true},
+		{a: "a", b: "ab", want: true},
+		{a: "a1b2", b: "a1b10", want: true},
+		{a: "01", b: "1", want: false},
+		{a: "1", b: "01", want: true},
+		{a: "same", b: "same", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.a+" "+test.b, func(t *testing.T) {
+			got := naturalLess(test.a, test.b)
+			if test.want != got {
+				t.Errorf("Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
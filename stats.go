@@ -0,0 +1,265 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+	"sort"
+	"strings"
+)
+
+// BenchmarkSamples holds the repeated runs of a single benchmark - such as those produced by `go test -count`.
+type BenchmarkSamples struct {
+	Name       string
+	Benchmarks []parse.Benchmark
+}
+
+// GroupSamples groups the benchmarks sharing a name together, in order of each name's first appearance.
+func GroupSamples(benchmarks []parse.Benchmark) []BenchmarkSamples {
+	var results []BenchmarkSamples
+	indexes := make(map[string]int)
+	for _, benchmark := range benchmarks {
+		index, ok := indexes[benchmark.Name]
+		if !ok {
+			index = len(results)
+			indexes[benchmark.Name] = index
+			results = append(results, BenchmarkSamples{Name: benchmark.Name})
+		}
+		results[index].Benchmarks = append(results[index].Benchmarks, benchmark)
+	}
+	return results
+}
+
+// GroupSamplesByConfig groups the benchmarks sharing both a name and a configuration together, in order of their
+// first appearance - so that benchmarks of the same name from different packages are kept apart.
+func GroupSamplesByConfig(benchmarks []parse.Benchmark, configs BenchmarkConfigs) []BenchmarkSamples {
+	var results []BenchmarkSamples
+	indexes := make(map[string]int)
+	for _, benchmark := range benchmarks {
+		key := sampleKey(benchmark, configs)
+		index, ok := indexes[key]
+		if !ok {
+			index = len(results)
+			indexes[key] = index
+			results = append(results, BenchmarkSamples{Name: benchmark.Name})
+		}
+		results[index].Benchmarks = append(results[index].Benchmarks, benchmark)
+	}
+	return results
+}
+
+// sampleKey provides a key identifying the samples the benchmark belongs to, by its name and configuration.
+func sampleKey(benchmark parse.Benchmark, configs BenchmarkConfigs) string {
+	return benchmark.Name + "\n" + configKey(configs[benchmark.Ord])
+}
+
+// configKey provides a key identifying the configuration by its contents.
+func configKey(config Config) string {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	for _, key := range keys {
+		builder.WriteString(key)
+		builder.WriteString("=")
+		builder.WriteString(config[key])
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// Values provides the value of each sample in the provided dimension.  If the dimension is unknown, an
+// ErrUnknownDimensionType is returned.
+func (s BenchmarkSamples) Values(dimension Dimension) ([]float64, error) {
+	values := make([]float64, 0, len(s.Benchmarks))
+	for _, benchmark := range s.Benchmarks {
+		value, err := dimension.Value(benchmark)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Mean provides a single benchmark, whose metrics are the mean of the samples' metrics.
+func (s BenchmarkSamples) Mean() parse.Benchmark {
+	result := parse.Benchmark{Name: s.Name}
+	if len(s.Benchmarks) == 0 {
+		return result
+	}
+
+	var n, nsPerOp, bytesPerOp, allocsPerOp, mbPerS float64
+	for _, benchmark := range s.Benchmarks {
+		n += float64(benchmark.N)
+		nsPerOp += benchmark.NsPerOp
+		bytesPerOp += float64(benchmark.AllocedBytesPerOp)
+		allocsPerOp += float64(benchmark.AllocsPerOp)
+		mbPerS += benchmark.MBPerS
+		result.Measured |= benchmark.Measured
+	}
+
+	count := float64(len(s.Benchmarks))
+	result.N = int(math.Round(n / count))
+	result.NsPerOp = nsPerOp / count
+	result.AllocedBytesPerOp = uint64(math.Round(bytesPerOp / count))
+	result.AllocsPerOp = uint64(math.Round(allocsPerOp / count))
+	result.MBPerS = mbPerS / count
+	result.Ord = s.Benchmarks[0].Ord
+	return result
+}
+
+// ===== ErrorBars =====
+
+// ErrorBars determines how the run-to-run variance of repeated benchmark samples is displayed.
+type ErrorBars int
+
+const (
+	NoErrorBars ErrorBars = iota
+	// StdDevErrorBars displays one sample standard deviation either side of the mean.
+	StdDevErrorBars
+	// ConfidenceIntervalErrorBars displays the 95% confidence interval of the mean.
+	ConfidenceIntervalErrorBars
+)
+
+func (e ErrorBars) String() string {
+	switch e {
+	case NoErrorBars:
+		return "NONE"
+	case StdDevErrorBars:
+		return "STDDEV"
+	case ConfidenceIntervalErrorBars:
+		return "CI95"
+	default:
+		return fmt.Sprintf("Unknown (%d)", e)
+	}
+}
+
+func ErrorBarsFromString(str string) (ErrorBars, error) {
+	switch str {
+	case "NONE":
+		return NoErrorBars, nil
+	case "STDDEV":
+		return StdDevErrorBars, nil
+	case "CI95":
+		return ConfidenceIntervalErrorBars, nil
+	default:
+		return -1, fmt.Errorf("error bars %q not supported: %w", str, ErrUnknownErrorBars)
+	}
+}
+
+// Error provides the distance either side of the mean covered by the error bar.  Fewer than two values have no
+// variance, so their error is zero.
+func (e ErrorBars) Error(values []float64) (float64, error) {
+	if len(values) < 2 {
+		return 0, nil
+	}
+
+	switch e {
+	case NoErrorBars:
+		return 0, nil
+	case StdDevErrorBars:
+		return stdDev(values), nil
+	case ConfidenceIntervalErrorBars:
+		return tCritical95(len(values)-1) * stdDev(values) / math.Sqrt(float64(len(values))), nil
+	default:
+		return 0, fmt.Errorf("error bars %q not supported: %w", e, ErrUnknownErrorBars)
+	}
+}
+
+// mean provides the arithmetic mean of the values.
+func mean(values []float64) float64 {
+	var total float64
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
+
+// stdDev provides the sample standard deviation of the values.
+func stdDev(values []float64) float64 {
+	m := mean(values)
+	var total float64
+	for _, value := range values {
+		total += (value - m) * (value - m)
+	}
+	return math.Sqrt(total / float64(len(values)-1))
+}
+
+// tCritical95Values are the two-sided 95% critical values of Student's t-distribution, for 1 to 30 degrees of freedom.
+var tCritical95Values = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tCritical95 provides the two-sided 95% critical value of Student's t-distribution for the degrees of freedom.
+// Beyond 30 degrees of freedom, the normal approximation is used.
+func tCritical95(degreesOfFreedom int) float64 {
+	if degreesOfFreedom < 1 {
+		return math.NaN()
+	}
+	if degreesOfFreedom > len(tCritical95Values) {
+		return 1.960
+	}
+	return tCritical95Values[degreesOfFreedom-1]
+}
+
+// ===== Box plots =====
+
+// boxPlotWhiskerRange is the distance, in interquartile ranges, beyond which samples are treated as outliers.
+const boxPlotWhiskerRange = 1.5
+
+// BoxPlot summarises the distribution of a benchmark's samples by their quartiles.  The whiskers reach the most
+// extreme samples within 1.5 interquartile ranges of the box - samples beyond them are outliers.
+type BoxPlot struct {
+	LowerWhisker  float64
+	LowerQuartile float64
+	Median        float64
+	UpperQuartile float64
+	UpperWhisker  float64
+	Outliers      []float64
+}
+
+// NewBoxPlot summarises the distribution of the values.  If there are no values, an ErrNoBenchmarksProvided is
+// returned.
+func NewBoxPlot(values []float64) (BoxPlot, error) {
+	if len(values) == 0 {
+		return BoxPlot{}, ErrNoBenchmarksProvided
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	box := BoxPlot{
+		LowerQuartile: quantile(sorted, 0.25),
+		Median:        quantile(sorted, 0.5),
+		UpperQuartile: quantile(sorted, 0.75),
+	}
+
+	iqr := box.UpperQuartile - box.LowerQuartile
+	lowerFence := box.LowerQuartile - boxPlotWhiskerRange*iqr
+	upperFence := box.UpperQuartile + boxPlotWhiskerRange*iqr
+
+	box.LowerWhisker, box.UpperWhisker = box.LowerQuartile, box.UpperQuartile
+	for _, value := range sorted {
+		if value < lowerFence || value > upperFence {
+			box.Outliers = append(box.Outliers, value)
+			continue
+		}
+		box.LowerWhisker = math.Min(box.LowerWhisker, value)
+		box.UpperWhisker = math.Max(box.UpperWhisker, value)
+	}
+	return box, nil
+}
+
+// quantile provides the q-th quantile of the sorted values, interpolating linearly between the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
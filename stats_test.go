@@ -0,0 +1,303 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// ===== GroupSamples tests =====
+
+func TestGroupSamples(t *testing.T) {
+	a1 := parse.Benchmark{Name: "BenchmarkOne/A", NsPerOp: 10}
+	a2 := parse.Benchmark{Name: "BenchmarkOne/A", NsPerOp: 12}
+	b1 := parse.Benchmark{Name: "BenchmarkOne/B", NsPerOp: 20}
+
+	tests := []struct {
+		name       string
+		benchmarks []parse.Benchmark
+		want       []BenchmarkSamples
+	}{
+		{
+			name: "no benchmarks",
+		},
+		{
+			name:       "single samples",
+			benchmarks: []parse.Benchmark{a1, b1},
+			want: []BenchmarkSamples{
+				{Name: "BenchmarkOne/A", Benchmarks: []parse.Benchmark{a1}},
+				{Name: "BenchmarkOne/B", Benchmarks: []parse.Benchmark{b1}},
+			},
+		},
+		{
+			name:       "interleaved samples keep first appearance order",
+			benchmarks: []parse.Benchmark{a1, b1, a2},
+			want: []BenchmarkSamples{
+				{Name: "BenchmarkOne/A", Benchmarks: []parse.Benchmark{a1, a2}},
+				{Name: "BenchmarkOne/B", Benchmarks: []parse.Benchmark{b1}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := GroupSamples(test.benchmarks)
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+// ===== BenchmarkSamples tests =====
+
+func TestBenchmarkSamples_Values(t *testing.T) {
+	samples := BenchmarkSamples{
+		Name: "BenchmarkOne",
+		Benchmarks: []parse.Benchmark{
+			{Name: "BenchmarkOne", NsPerOp: 10, AllocsPerOp: 1},
+			{Name: "BenchmarkOne", NsPerOp: 12, AllocsPerOp: 3},
+		},
+	}
+
+	got, err := samples.Values(RenderAllocsPerOp)
+	if err != nil {
+		t.Fatalf("Could not get values - error: %v", err)
+	}
+	want := []float64{1, 3}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	_, err = samples.Values(RenderDimension(1000))
+	if !errors.Is(err, ErrUnknownDimensionType) {
+		t.Errorf("Want error '%v', got error '%v'", ErrUnknownDimensionType, err)
+	}
+}
+
+func TestBenchmarkSamples_Mean(t *testing.T) {
+	samples := BenchmarkSamples{
+		Name: "BenchmarkOne",
+		Benchmarks: []parse.Benchmark{
+			{Name: "BenchmarkOne", N: 100, NsPerOp: 10, AllocedBytesPerOp: 64, AllocsPerOp: 1, Measured: parse.NsPerOp, Ord: 3},
+			{Name: "BenchmarkOne", N: 200, NsPerOp: 15, AllocedBytesPerOp: 128, AllocsPerOp: 2, Measured: parse.NsPerOp | parse.AllocsPerOp, Ord: 5},
+		},
+	}
+
+	want := parse.Benchmark{
+		Name:              "BenchmarkOne",
+		N:                 150,
+		NsPerOp:           12.5,
+		AllocedBytesPerOp: 96,
+		AllocsPerOp:       2,
+		Measured:          parse.NsPerOp | parse.AllocsPerOp,
+		Ord:               3,
+	}
+	got := samples.Mean()
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+// ===== ErrorBars tests =====
+
+func TestErrorBars_String(t *testing.T) {
+	tests := []struct {
+		input ErrorBars
+		want  string
+	}{
+		{NoErrorBars, "NONE"},
+		{StdDevErrorBars, "STDDEV"},
+		{ConfidenceIntervalErrorBars, "CI95"},
+		{ErrorBars(1000), "Unknown (1000)"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			got := test.input.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestErrorBarsFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ErrorBars
+		wantErr error
+	}{
+		{input: "NONE", want: NoErrorBars},
+		{input: "STDDEV", want: StdDevErrorBars},
+		{input: "CI95", want: ConfidenceIntervalErrorBars},
+		{input: "abc123", want: ErrorBars(-1), wantErr: ErrUnknownErrorBars},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ErrorBarsFromString(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestErrorBars_Error(t *testing.T) {
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	tests := []struct {
+		name      string
+		errorBars ErrorBars
+		values    []float64
+		want      float64
+		wantErr   error
+	}{
+		{
+			name:      "none",
+			errorBars: NoErrorBars,
+			values:    values,
+			want:      0,
+		},
+		{
+			name:      "standard deviation",
+			errorBars: StdDevErrorBars,
+			values:    values,
+			want:      2.138,
+		},
+		{
+			name:      "confidence interval",
+			errorBars: ConfidenceIntervalErrorBars,
+			values:    values,
+			want:      1.787,
+		},
+		{
+			name:      "single sample",
+			errorBars: StdDevErrorBars,
+			values:    []float64{5},
+			want:      0,
+		},
+		{
+			name:      "unknown",
+			errorBars: ErrorBars(1000),
+			values:    values,
+			wantErr:   ErrUnknownErrorBars,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.errorBars.Error(test.values)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if math.Abs(test.want-got) > 0.001 {
+				t.Errorf("want %.3f, got %.3f", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTCritical95(t *testing.T) {
+	tests := []struct {
+		degreesOfFreedom int
+		want             float64
+	}{
+		{1, 12.706},
+		{30, 2.042},
+		{1000, 1.960},
+	}
+	for _, test := range tests {
+		got := tCritical95(test.degreesOfFreedom)
+		if test.want != got {
+			t.Errorf("Degrees of freedom %d - want %v, got %v", test.degreesOfFreedom, test.want, got)
+		}
+	}
+
+	if !math.IsNaN(tCritical95(0)) {
+		t.Error("Want NaN for zero degrees of freedom")
+	}
+}
+
+// ===== BoxPlot tests =====
+
+func TestNewBoxPlot(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []float64
+		want    BoxPlot
+		wantErr error
+	}{
+		{
+			name:   "no outliers",
+			values: []float64{5, 1, 4, 2, 3},
+			want: BoxPlot{
+				LowerWhisker:  1,
+				LowerQuartile: 2,
+				Median:        3,
+				UpperQuartile: 4,
+				UpperWhisker:  5,
+			},
+		},
+		{
+			name:   "outliers beyond whiskers",
+			values: []float64{10, 11, 12, 13, 14, 50, -20},
+			want: BoxPlot{
+				LowerWhisker:  10,
+				LowerQuartile: 10.5,
+				Median:        12,
+				UpperQuartile: 13.5,
+				UpperWhisker:  14,
+				Outliers:      []float64{-20, 50},
+			},
+		},
+		{
+			name:   "single value",
+			values: []float64{7},
+			want: BoxPlot{
+				LowerWhisker:  7,
+				LowerQuartile: 7,
+				Median:        7,
+				UpperQuartile: 7,
+				UpperWhisker:  7,
+			},
+		},
+		{
+			name:    "no values",
+			wantErr: ErrNoBenchmarksProvided,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewBoxPlot(test.values)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4}
+
+	tests := []struct {
+		q    float64
+		want float64
+	}{
+		{0, 1},
+		{0.25, 1.75},
+		{0.5, 2.5},
+		{1, 4},
+	}
+	for _, test := range tests {
+		got := quantile(sorted, test.q)
+		if test.want != got {
+			t.Errorf("Quantile %v - want %v, got %v", test.q, test.want, got)
+		}
+	}
+}
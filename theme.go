@@ -0,0 +1,210 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	"gopkg.in/yaml.v3"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Theme describes the colours used when rendering charts.  Colours are CSS style hex codes, such as "#1e1e1e".
+// Any colours left unset, as in the zero Theme, are taken from the LightTheme.
+type Theme struct {
+	Name       string   `json:"name" yaml:"name"`
+	Background string   `json:"background" yaml:"background"`
+	Canvas     string   `json:"canvas" yaml:"canvas"`
+	Text       string   `json:"text" yaml:"text"`
+	Axis       string   `json:"axis" yaml:"axis"`
+	Series     []string `json:"series" yaml:"series"`
+}
+
+var (
+	// LightTheme matches the default styling of the underlying charting library.
+	LightTheme = Theme{
+		Name:       "LIGHT",
+		Background: "#ffffff",
+		Canvas:     "#ffffff",
+		Text:       "#333333",
+		Axis:       "#333333",
+		Series:     []string{"#6ac3cb", "#2abe89", "#6e808b", "#f0ae5a", "#0074d9", "#00d965", "#d90074", "#00d9d2", "#d96500"},
+	}
+
+	// DarkTheme is intended for documentation sites using a dark colour scheme.
+	DarkTheme = Theme{
+		Name:       "DARK",
+		Background: "#1e1e1e",
+		Canvas:     "#1e1e1e",
+		Text:       "#d4d4d4",
+		Axis:       "#a0a0a0",
+		Series:     []string{"#4fc1ff", "#6bd968", "#ffb454", "#f07178", "#c792ea", "#89ddff", "#ffcb6b"},
+	}
+
+	// HighContrastTheme uses pure black and white, with strongly saturated series colours.
+	HighContrastTheme = Theme{
+		Name:       "HIGH_CONTRAST",
+		Background: "#ffffff",
+		Canvas:     "#ffffff",
+		Text:       "#000000",
+		Axis:       "#000000",
+		Series:     []string{"#000000", "#0000ff", "#d00000", "#008000", "#ff8c00", "#800080"},
+	}
+
+	// ColourBlindSafeTheme uses the Okabe-Ito palette, which remains distinguishable for the common forms of
+	// colour vision deficiency.
+	ColourBlindSafeTheme = Theme{
+		Name:       "COLOUR_BLIND_SAFE",
+		Background: "#ffffff",
+		Canvas:     "#ffffff",
+		Text:       "#000000",
+		Axis:       "#000000",
+		Series:     []string{"#0072b2", "#e69f00", "#56b4e9", "#009e73", "#f0e442", "#d55e00", "#cc79a7", "#000000"},
+	}
+)
+
+// ThemeFromString provides the built-in theme with the provided name - for instance, DarkTheme for "DARK".
+// If there is no built-in theme with that name, an ErrUnknownTheme is returned.
+func ThemeFromString(str string) (Theme, error) {
+	switch str {
+	case "LIGHT":
+		return LightTheme, nil
+	case "DARK":
+		return DarkTheme, nil
+	case "HIGH_CONTRAST":
+		return HighContrastTheme, nil
+	case "COLOUR_BLIND_SAFE":
+		return ColourBlindSafeTheme, nil
+	default:
+		return Theme{}, fmt.Errorf("theme %q not supported: %w", str, ErrUnknownTheme)
+	}
+}
+
+// ReadTheme reads a custom theme from the provided reader.  The theme may be either JSON or YAML formatted.
+// Any colours not specified are taken from the LightTheme.  If the theme cannot be read, or contains invalid
+// colours, an error is returned.
+func ReadTheme(reader io.Reader) (Theme, error) {
+	var theme Theme
+	// YAML is a superset of JSON, so a single decoder handles both formats.
+	err := yaml.NewDecoder(reader).Decode(&theme)
+	if err != nil {
+		return Theme{}, fmt.Errorf("could not decode theme: %w", err)
+	}
+
+	theme = theme.withDefaults(LightTheme)
+
+	_, err = theme.palette()
+	if err != nil {
+		return Theme{}, err
+	}
+	return theme, nil
+}
+
+// withDefaults fills in any unset colours of the theme using the provided defaults.
+func (t Theme) withDefaults(defaults Theme) Theme {
+	if t.Background == "" {
+		t.Background = defaults.Background
+	}
+	if t.Canvas == "" {
+		t.Canvas = defaults.Canvas
+	}
+	if t.Text == "" {
+		t.Text = defaults.Text
+	}
+	if t.Axis == "" {
+		t.Axis = defaults.Axis
+	}
+	if len(t.Series) == 0 {
+		t.Series = defaults.Series
+	}
+	return t
+}
+
+// palette converts the theme into a go-chart colour palette, with any unset colours taken from the LightTheme.  If any
+// of the theme colours are invalid, an ErrInvalidThemeColour is returned.
+func (t Theme) palette() (chart.ColorPalette, error) {
+	t = t.withDefaults(LightTheme)
+
+	var p themePalette
+	var err error
+
+	colours := []struct {
+		hex  string
+		dest *drawing.Color
+	}{
+		{t.Background, &p.background},
+		{t.Canvas, &p.canvas},
+		{t.Text, &p.text},
+		{t.Axis, &p.axis},
+	}
+	for _, c := range colours {
+		*c.dest, err = parseColour(c.hex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, hex := range t.Series {
+		colour, err := parseColour(hex)
+		if err != nil {
+			return nil, err
+		}
+		p.series = append(p.series, colour)
+	}
+
+	return p, nil
+}
+
+// applyToBarChart applies the theme to the provided bar chart.
+func (t Theme) applyToBarChart(graph *chart.BarChart) error {
+	p, err := t.palette()
+	if err != nil {
+		return err
+	}
+	graph.ColorPalette = p
+	return nil
+}
+
+// parseColour parses a CSS style hex colour - either "#rgb", "#rrggbb" or "#rrggbbaa".
+func parseColour(hex string) (drawing.Color, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if len(digits) != 8 || err != nil {
+		return drawing.Color{}, fmt.Errorf("colour %q invalid: %w", hex, ErrInvalidThemeColour)
+	}
+
+	return drawing.Color{
+		R: uint8(value >> 24),
+		G: uint8(value >> 16),
+		B: uint8(value >> 8),
+		A: uint8(value),
+	}, nil
+}
+
+// themePalette implements the go-chart ColorPalette interface for a Theme.
+type themePalette struct {
+	background drawing.Color
+	canvas     drawing.Color
+	text       drawing.Color
+	axis       drawing.Color
+	series     []drawing.Color
+}
+
+func (p themePalette) BackgroundColor() drawing.Color       { return p.background }
+func (p themePalette) BackgroundStrokeColor() drawing.Color { return p.background }
+func (p themePalette) CanvasColor() drawing.Color           { return p.canvas }
+func (p themePalette) CanvasStrokeColor() drawing.Color     { return p.canvas }
+func (p themePalette) AxisStrokeColor() drawing.Color       { return p.axis }
+func (p themePalette) TextColor() drawing.Color             { return p.text }
+
+func (p themePalette) GetSeriesColor(index int) drawing.Color {
+	return p.series[index%len(p.series)]
+}
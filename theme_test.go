@@ -0,0 +1,230 @@
+package go_benchpress
+
+import (
+	"errors"
+	"github.com/wcharczuk/go-chart/drawing"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ===== ThemeFromString tests =====
+
+func TestThemeFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Theme
+		wantErr error
+	}{
+		{
+			name:  "light",
+			input: "LIGHT",
+			want:  LightTheme,
+		},
+		{
+			name:  "dark",
+			input: "DARK",
+			want:  DarkTheme,
+		},
+		{
+			name:  "high contrast",
+			input: "HIGH_CONTRAST",
+			want:  HighContrastTheme,
+		},
+		{
+			name:  "colour blind safe",
+			input: "COLOUR_BLIND_SAFE",
+			want:  ColourBlindSafeTheme,
+		},
+		{
+			name:    "unknown",
+			input:   "abc123",
+			wantErr: ErrUnknownTheme,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ThemeFromString(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBuiltInThemesValid(t *testing.T) {
+	for _, theme := range []Theme{LightTheme, DarkTheme, HighContrastTheme, ColourBlindSafeTheme} {
+		t.Run(theme.Name, func(t *testing.T) {
+			_, err := theme.palette()
+			if err != nil {
+				t.Errorf("Built-in theme invalid - error: %v", err)
+			}
+		})
+	}
+}
+
+// ===== ReadTheme tests =====
+
+func TestReadTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Theme
+		wantErr error
+	}{
+		{
+			name:  "json",
+			input: `{"name": "Docs", "background": "#000000", "canvas": "#111111", "text": "#eeeeee", "axis": "#cccccc", "series": ["#ff0000", "#00ff00"]}`,
+			want: Theme{
+				Name:       "Docs",
+				Background: "#000000",
+				Canvas:     "#111111",
+				Text:       "#eeeeee",
+				Axis:       "#cccccc",
+				Series:     []string{"#ff0000", "#00ff00"},
+			},
+		},
+		{
+			name: "yaml",
+			input: `name: Docs
+background: "#000000"
+canvas: "#111111"
+text: "#eeeeee"
+axis: "#cccccc"
+series:
+  - "#ff0000"
+  - "#00ff00"
+`,
+			want: Theme{
+				Name:       "Docs",
+				Background: "#000000",
+				Canvas:     "#111111",
+				Text:       "#eeeeee",
+				Axis:       "#cccccc",
+				Series:     []string{"#ff0000", "#00ff00"},
+			},
+		},
+		{
+			name:  "missing colours default to light theme",
+			input: `{"name": "Partial", "background": "#000"}`,
+			want: Theme{
+				Name:       "Partial",
+				Background: "#000",
+				Canvas:     LightTheme.Canvas,
+				Text:       LightTheme.Text,
+				Axis:       LightTheme.Axis,
+				Series:     LightTheme.Series,
+			},
+		},
+		{
+			name:    "invalid colour",
+			input:   `{"background": "not a colour"}`,
+			wantErr: ErrInvalidThemeColour,
+		},
+		{
+			name:    "invalid series colour",
+			input:   `{"series": ["#12345"]}`,
+			wantErr: ErrInvalidThemeColour,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ReadTheme(strings.NewReader(test.input))
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestReadTheme_Malformed(t *testing.T) {
+	_, err := ReadTheme(strings.NewReader(`{"series": `))
+	if err == nil {
+		t.Error("Wanted error reading malformed theme, got nil")
+	}
+}
+
+// ===== parseColour tests =====
+
+func TestParseColour(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    drawing.Color
+		wantErr error
+	}{
+		{
+			name:  "six digits",
+			input: "#0072b2",
+			want:  drawing.Color{R: 0x00, G: 0x72, B: 0xb2, A: 0xff},
+		},
+		{
+			name:  "three digits",
+			input: "#fa0",
+			want:  drawing.Color{R: 0xff, G: 0xaa, B: 0x00, A: 0xff},
+		},
+		{
+			name:  "eight digits",
+			input: "#11223344",
+			want:  drawing.Color{R: 0x11, G: 0x22, B: 0x33, A: 0x44},
+		},
+		{
+			name:  "no hash",
+			input: "ffffff",
+			want:  drawing.Color{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		},
+		{
+			name:    "invalid digits",
+			input:   "#gggggg",
+			wantErr: ErrInvalidThemeColour,
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: ErrInvalidThemeColour,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseColour(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+// ===== themePalette tests =====
+
+func TestThemePalette_GetSeriesColor(t *testing.T) {
+	theme := Theme{
+		Background: "#ffffff",
+		Canvas:     "#ffffff",
+		Text:       "#000000",
+		Axis:       "#000000",
+		Series:     []string{"#ff0000", "#00ff00"},
+	}
+	p, err := theme.palette()
+	if err != nil {
+		t.Fatalf("Could not create palette - error: %v", err)
+	}
+
+	red := drawing.Color{R: 0xff, A: 0xff}
+	green := drawing.Color{G: 0xff, A: 0xff}
+	for index, want := range []drawing.Color{red, green, red} {
+		got := p.GetSeriesColor(index)
+		if want != got {
+			t.Errorf("Index %d - want %v, got %v", index, want, got)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package go_benchpress
+
+import (
+	"fmt"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	"golang.org/x/tools/benchmark/parse"
+	"sort"
+	"strings"
+)
+
+// ===== TopSelection =====
+
+// TopSelection determines which benchmarks are kept by TopN.
+type TopSelection int
+
+const (
+	// LargestSelection keeps the benchmarks with the largest values - for instance, the slowest.
+	LargestSelection TopSelection = iota
+	// SmallestSelection keeps the benchmarks with the smallest values - for instance, the fastest.
+	SmallestSelection
+)
+
+func (t TopSelection) String() string {
+	switch t {
+	case LargestSelection:
+		return "LARGEST"
+	case SmallestSelection:
+		return "SMALLEST"
+	default:
+		return fmt.Sprintf("Unknown (%d)", t)
+	}
+}
+
+func TopSelectionFromString(str string) (TopSelection, error) {
+	switch str {
+	case "LARGEST":
+		return LargestSelection, nil
+	case "SMALLEST":
+		return SmallestSelection, nil
+	default:
+		return -1, fmt.Errorf("top selection %q not supported: %w", str, ErrUnknownTopSelection)
+	}
+}
+
+// ===== OthersMode =====
+
+// OthersMode determines what becomes of the benchmarks not kept by TopN.
+type OthersMode int
+
+const (
+	// DropOthers leaves the other benchmarks out.
+	DropOthers OthersMode = iota
+	// AggregateOthers combines the other benchmarks into a single benchmark, whose metrics are their mean.
+	AggregateOthers
+)
+
+func (o OthersMode) String() string {
+	switch o {
+	case DropOthers:
+		return "DROP"
+	case AggregateOthers:
+		return "AGGREGATE"
+	default:
+		return fmt.Sprintf("Unknown (%d)", o)
+	}
+}
+
+func OthersModeFromString(str string) (OthersMode, error) {
+	switch str {
+	case "DROP":
+		return DropOthers, nil
+	case "AGGREGATE":
+		return AggregateOthers, nil
+	default:
+		return -1, fmt.Errorf("others mode %q not supported: %w", str, ErrUnknownOthersMode)
+	}
+}
+
+// ===== TopN =====
+
+// TopN keeps the benchmarks with the largest (or smallest) values, so that crowded charts remain readable.  Repeated
+// samples of a benchmark run with the same configuration are kept or left out together, chosen by their mean value.
+type TopN struct {
+	// Count is the number of benchmarks kept - if zero or less, every benchmark is kept.
+	Count     int
+	Selection TopSelection
+	Others    OthersMode
+	Dimension Dimension
+	// Configs holds the configuration of each benchmark, such as its "pkg" - so that benchmarks sharing a name but
+	// run with different configurations are ranked separately.
+	Configs BenchmarkConfigs
+}
+
+// Apply provides the benchmarks kept, in their original order, along with the number of other benchmarks (counting
+// repeated samples once).  Where the others are aggregated, their mean follows the benchmarks kept, named by
+// OthersName.  If the dimension, selection or others mode is unknown, an error is returned.
+func (t TopN) Apply(benchmarks []parse.Benchmark) ([]parse.Benchmark, int, error) {
+	groups := GroupSamplesByConfig(benchmarks, t.Configs)
+	if t.Count <= 0 || len(groups) <= t.Count {
+		return benchmarks, 0, nil
+	}
+	if t.Others != DropOthers && t.Others != AggregateOthers {
+		return nil, 0, fmt.Errorf("others mode %q not supported: %w", t.Others, ErrUnknownOthersMode)
+	}
+
+	if t.Selection != LargestSelection && t.Selection != SmallestSelection {
+		return nil, 0, fmt.Errorf("top selection %q not supported: %w", t.Selection, ErrUnknownTopSelection)
+	}
+
+	values := make([]float64, len(groups))
+	for index, group := range groups {
+		var err error
+		values[index], err = t.Dimension.Value(group.Mean())
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	ranked := make([]int, len(groups))
+	for index := range ranked {
+		ranked[index] = index
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if t.Selection == SmallestSelection {
+			return values[ranked[i]] < values[ranked[j]]
+		}
+		return values[ranked[i]] > values[ranked[j]]
+	})
+
+	// kept holds the benchmarks kept, keyed by their name and configuration.
+	kept := make(map[string]bool, t.Count)
+	for _, index := range ranked[:t.Count] {
+		kept[sampleKey(groups[index].Benchmarks[0], t.Configs)] = true
+	}
+
+	results := make([]parse.Benchmark, 0, len(benchmarks))
+	var others []parse.Benchmark
+	for _, group := range groups {
+		if kept[sampleKey(group.Benchmarks[0], t.Configs)] {
+			continue
+		}
+		others = append(others, group.Mean())
+	}
+	for _, benchmark := range benchmarks {
+		if kept[sampleKey(benchmark, t.Configs)] {
+			results = append(results, benchmark)
+		}
+	}
+
+	if t.Others == AggregateOthers {
+		results = append(results, BenchmarkSamples{Name: OthersName(len(others)), Benchmarks: others}.Mean())
+	}
+	return results, len(others), nil
+}
+
+// Subtitle describes the benchmarks kept - for instance, "Largest 10 of 80 by time per op, 70 others averaged".  If
+// no benchmarks were left out, the subtitle is empty.
+func (t TopN) Subtitle(omitted int) string {
+	if omitted == 0 {
+		return ""
+	}
+
+	selection := "Largest"
+	if t.Selection == SmallestSelection {
+		selection = "Smallest"
+	}
+	outcome := "dropped"
+	if t.Others == AggregateOthers {
+		outcome = "averaged"
+	}
+	return fmt.Sprintf("%s %d of %d by %s, %d others %s", selection, t.Count, t.Count+omitted, lowerFirst(t.Dimension.Title()), omitted, outcome)
+}
+
+// OthersName provides the name of the benchmark aggregating the others, for the number of benchmarks aggregated.
+func OthersName(count int) string {
+	return fmt.Sprintf("Others (%d)", count)
+}
+
+// lowerFirst lower-cases the first letter of the title, for use within a sentence.
+func lowerFirst(title string) string {
+	if title == "" {
+		return title
+	}
+	return strings.ToLower(title[:1]) + title[1:]
+}
+
+// ===== Subtitles =====
+
+// subtitlePadding is the extra space reserved above the canvas of bar charts for a subtitle.
+const subtitlePadding = 20
+
+// drawSubtitle draws the subtitle centred beneath the chart title.
+func drawSubtitle(r chart.Renderer, textStyle chart.Style, subtitle string, width int) {
+	if subtitle == "" {
+		return
+	}
+
+	titleStyle := textStyle
+	titleStyle.FontSize = 18
+	titleStyle.WriteTextOptionsToRenderer(r)
+	titleHeight := r.MeasureText("Ag").Height()
+
+	subtitleStyle := textStyle
+	subtitleStyle.FontSize = chart.DefaultAxisFontSize
+	subtitleStyle.WriteTextOptionsToRenderer(r)
+	box := r.MeasureText(subtitle)
+	r.Text(subtitle, width>>1-box.Width()>>1, chart.DefaultTitleTop+titleHeight+box.Height()+4)
+}
+
+// renderSubtitle provides a chart element drawing the subtitle beneath the title of a bar chart.
+func renderSubtitle(subtitle string, width int, colour drawing.Color) chart.Renderable {
+	return func(r chart.Renderer, canvasBox chart.Box, defaults chart.Style) {
+		drawSubtitle(r, chart.Style{FontColor: colour}.InheritFrom(defaults), subtitle, width)
+	}
+}
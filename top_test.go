@@ -0,0 +1,234 @@
+package go_benchpress
+
+import (
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"testing"
+)
+
+func TestTopSelection_String(t *testing.T) {
+	tests := []struct {
+		input TopSelection
+		want  string
+	}{
+		{LargestSelection, "LARGEST"},
+		{SmallestSelection, "SMALLEST"},
+		{TopSelection(1000), "Unknown (1000)"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			got := test.input.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTopSelectionFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    TopSelection
+		wantErr error
+	}{
+		{input: "LARGEST", want: LargestSelection},
+		{input: "SMALLEST", want: SmallestSelection},
+		{input: "abc123", want: TopSelection(-1), wantErr: ErrUnknownTopSelection},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := TopSelectionFromString(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestOthersMode_String(t *testing.T) {
+	tests := []struct {
+		input OthersMode
+		want  string
+	}{
+		{DropOthers, "DROP"},
+		{AggregateOthers, "AGGREGATE"},
+		{OthersMode(1000), "Unknown (1000)"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			got := test.input.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestOthersModeFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    OthersMode
+		wantErr error
+	}{
+		{input: "DROP", want: DropOthers},
+		{input: "AGGREGATE", want: AggregateOthers},
+		{input: "abc123", want: OthersMode(-1), wantErr: ErrUnknownOthersMode},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := OthersModeFromString(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTopN_Apply(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/A", N: 100, NsPerOp: 100, AllocsPerOp: 1},
+		{Name: "BenchmarkOne/B", N: 100, NsPerOp: 300, AllocsPerOp: 2},
+		{Name: "BenchmarkOne/C", N: 100, NsPerOp: 200, AllocsPerOp: 3},
+		{Name: "BenchmarkOne/A", N: 100, NsPerOp: 500, AllocsPerOp: 1},
+		{Name: "BenchmarkOne/D", N: 300, NsPerOp: 50, AllocsPerOp: 4},
+	}
+
+	tests := []struct {
+		name        string
+		top         TopN
+		want        []parse.Benchmark
+		wantOmitted int
+	}{
+		{
+			name: "largest, with samples kept together",
+			top:  TopN{Count: 2, Dimension: RenderNsPerOp},
+			want: []parse.Benchmark{
+				{Name: "BenchmarkOne/A", N: 100, NsPerOp: 100, AllocsPerOp: 1},
+				{Name: "BenchmarkOne/B", N: 100, NsPerOp: 300, AllocsPerOp: 2},
+				{Name: "BenchmarkOne/A", N: 100, NsPerOp: 500, AllocsPerOp: 1},
+			},
+			wantOmitted: 2,
+		},
+		{
+			name: "smallest",
+			top:  TopN{Count: 2, Selection: SmallestSelection, Dimension: RenderNsPerOp},
+			want: []parse.Benchmark{
+				{Name: "BenchmarkOne/C", N: 100, NsPerOp: 200, AllocsPerOp: 3},
+				{Name: "BenchmarkOne/D", N: 300, NsPerOp: 50, AllocsPerOp: 4},
+			},
+			wantOmitted: 2,
+		},
+		{
+			name: "aggregated others",
+			top:  TopN{Count: 1, Others: AggregateOthers, Dimension: RenderAllocsPerOp},
+			want: []parse.Benchmark{
+				{Name: "BenchmarkOne/D", N: 300, NsPerOp: 50, AllocsPerOp: 4},
+				{Name: "Others (3)", N: 100, NsPerOp: 800.0 / 3, AllocsPerOp: 2},
+			},
+			wantOmitted: 3,
+		},
+		{
+			name:        "count covering every benchmark",
+			top:         TopN{Count: 4, Dimension: RenderNsPerOp},
+			want:        benchmarks,
+			wantOmitted: 0,
+		},
+		{
+			name:        "no count",
+			top:         TopN{Dimension: RenderNsPerOp},
+			want:        benchmarks,
+			wantOmitted: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, omitted, err := test.top.Apply(benchmarks)
+			if err != nil {
+				t.Fatalf("Could not apply top - error: %v", err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("Want %v, got %v", test.want, got)
+			}
+			if test.wantOmitted != omitted {
+				t.Errorf("Want %d omitted, got %d", test.wantOmitted, omitted)
+			}
+		})
+	}
+}
+
+func TestTopN_ApplyConfigs(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne", NsPerOp: 100, Ord: 0},
+		{Name: "BenchmarkOne", NsPerOp: 300, Ord: 1},
+		{Name: "BenchmarkOne", NsPerOp: 200, Ord: 2},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/a"},
+		1: {"pkg": "example.com/b"},
+		2: {"pkg": "example.com/a"},
+	}
+
+	top := TopN{Count: 1, Dimension: RenderNsPerOp, Configs: configs}
+	got, omitted, err := top.Apply(benchmarks)
+	if err != nil {
+		t.Fatalf("Could not apply top - error: %v", err)
+	}
+
+	want := []parse.Benchmark{{Name: "BenchmarkOne", NsPerOp: 300, Ord: 1}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+	if omitted != 1 {
+		t.Errorf("Want 1 omitted, got %d", omitted)
+	}
+}
+
+func TestTopN_ApplyErrors(t *testing.T) {
+	benchmarks := []parse.Benchmark{{Name: "BenchmarkOne", NsPerOp: 10}, {Name: "BenchmarkTwo", NsPerOp: 20}}
+
+	tests := []struct {
+		name    string
+		top     TopN
+		wantErr error
+	}{
+		{name: "unknown dimension", top: TopN{Count: 1, Dimension: RenderDimension(1000)}, wantErr: ErrUnknownDimensionType},
+		{name: "unknown selection", top: TopN{Count: 1, Selection: TopSelection(1000)}, wantErr: ErrUnknownTopSelection},
+		{name: "unknown others mode", top: TopN{Count: 1, Others: OthersMode(1000)}, wantErr: ErrUnknownOthersMode},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := test.top.Apply(benchmarks)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTopN_Subtitle(t *testing.T) {
+	tests := []struct {
+		name    string
+		top     TopN
+		omitted int
+		want    string
+	}{
+		{name: "dropped", top: TopN{Count: 10, Dimension: RenderNsPerOp}, omitted: 70, want: "Largest 10 of 80 by time per op, 70 others dropped"},
+		{name: "averaged", top: TopN{Count: 5, Selection: SmallestSelection, Others: AggregateOthers, Dimension: RenderBytesPerOp}, omitted: 2, want: "Smallest 5 of 7 by bytes per op, 2 others averaged"},
+		{name: "none omitted", top: TopN{Count: 10, Dimension: RenderNsPerOp}, want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.top.Subtitle(test.omitted)
+			if test.want != got {
+				t.Errorf("Want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package go_benchpress
+
+import (
+	"github.com/wcharczuk/go-chart"
+	"math"
+	"strconv"
+)
+
+// unit is a scaled unit of measure for a dimension - for instance, microseconds for RenderNsPerOp.
+type unit struct {
+	Symbol string
+	// Scale is the number of raw dimension values (nanoseconds, bytes, etc.) within a single unit.
+	Scale float64
+}
+
+var (
+	timeUnits  = []unit{{"ns", 1}, {"µs", 1e3}, {"ms", 1e6}, {"s", 1e9}}
+	byteUnits  = []unit{{"B", 1}, {"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}}
+	countUnits = []unit{{"", 1}, {"k", 1e3}, {"M", 1e6}, {"G", 1e9}}
+)
+
+// Title provides a human-readable name for the dimension, suitable for use in axis titles and table headings.
+func (r RenderDimension) Title() string {
+	switch r {
+	case RenderNsPerOp:
+		return "Time per op"
+	case RenderBytesPerOp:
+		return "Bytes per op"
+	case RenderAllocsPerOp:
+		return "Allocations per op"
+	default:
+		return r.String()
+	}
+}
+
+// units provides the units the dimension can be scaled to, from smallest to largest.
+func (r RenderDimension) units() []unit {
+	switch r {
+	case RenderNsPerOp:
+		return timeUnits
+	case RenderBytesPerOp:
+		return byteUnits
+	default:
+		return countUnits
+	}
+}
+
+// FormatValue formats the raw value of this dimension using the most readable unit - for instance,
+// "1.5ms" for 1500000 in RenderNsPerOp, or "2KiB" for 2048 in RenderBytesPerOp.
+func (r RenderDimension) FormatValue(value float64) string {
+	return formatValue(r, value)
+}
+
+// unitFor provides the largest unit of the dimension in which the value is at least one - for instance, milliseconds
+// for 1.5e6ns.
+func unitFor(dimension Dimension, value float64) unit {
+	units := dimension.units()
+	result := units[0]
+	for _, u := range units {
+		if math.Abs(value) >= u.Scale {
+			result = u
+		}
+	}
+	return result
+}
+
+// formatValue formats the raw value of the dimension using the most readable unit.
+func formatValue(dimension Dimension, value float64) string {
+	u := unitFor(dimension, value)
+	return formatNumber(value/u.Scale) + u.Symbol
+}
+
+// axisTitle provides the title for an axis displaying the dimension in the provided unit.
+func axisTitle(dimension Dimension, u unit) string {
+	if u.Symbol == "" {
+		return dimension.Title()
+	}
+	return dimension.Title() + " (" + u.Symbol + ")"
+}
+
+// axisFormatter provides a go-chart value formatter, displaying values in the provided unit without a symbol.
+func axisFormatter(u unit) func(v interface{}) string {
+	return func(v interface{}) string {
+		value, ok := v.(float64)
+		if !ok {
+			return ""
+		}
+		return formatNumber(value / u.Scale)
+	}
+}
+
+// axisLabels provides the name and tick formatter of an axis of the dimension covering the range.  Linear axes are
+// scaled to a single unit suited to the range, while logarithmic ticks span several units, so each is labelled with
+// its own.
+func axisLabels(dimension Dimension, valueRange chart.Range, logScale bool) (string, chart.ValueFormatter) {
+	if logScale {
+		return dimension.Title(), func(v interface{}) string {
+			value, _ := v.(float64)
+			return dimension.FormatValue(value)
+		}
+	}
+
+	axisUnit := unitFor(dimension, math.Max(math.Abs(valueRange.GetMin()), math.Abs(valueRange.GetMax())))
+	return axisTitle(dimension, axisUnit), axisFormatter(axisUnit)
+}
+
+// formatNumber formats the value to at most two decimal places, without trailing zeros.
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
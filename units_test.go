@@ -0,0 +1,122 @@
+package go_benchpress
+
+import (
+	"testing"
+)
+
+// ===== RenderDimension unit formatting tests =====
+
+func TestRenderDimension_Title(t *testing.T) {
+	tests := []struct {
+		name  string
+		input RenderDimension
+		want  string
+	}{
+		{
+			name:  "ns per op",
+			input: RenderNsPerOp,
+			want:  "Time per op",
+		},
+		{
+			name:  "bytes per op",
+			input: RenderBytesPerOp,
+			want:  "Bytes per op",
+		},
+		{
+			name:  "allocs per op",
+			input: RenderAllocsPerOp,
+			want:  "Allocations per op",
+		},
+		{
+			name:  "unknown",
+			input: RenderDimension(1000),
+			want:  "Unknown (1000)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.input.Title()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderDimension_FormatValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		dimension RenderDimension
+		value     float64
+		want      string
+	}{
+		{
+			name:      "nanoseconds",
+			dimension: RenderNsPerOp,
+			value:     193.9,
+			want:      "193.9ns",
+		},
+		{
+			name:      "microseconds",
+			dimension: RenderNsPerOp,
+			value:     95948,
+			want:      "95.95µs",
+		},
+		{
+			name:      "milliseconds",
+			dimension: RenderNsPerOp,
+			value:     1500000,
+			want:      "1.5ms",
+		},
+		{
+			name:      "seconds",
+			dimension: RenderNsPerOp,
+			value:     2e9,
+			want:      "2s",
+		},
+		{
+			name:      "bytes",
+			dimension: RenderBytesPerOp,
+			value:     64,
+			want:      "64B",
+		},
+		{
+			name:      "kibibytes",
+			dimension: RenderBytesPerOp,
+			value:     2048,
+			want:      "2KiB",
+		},
+		{
+			name:      "mebibytes",
+			dimension: RenderBytesPerOp,
+			value:     3 << 20,
+			want:      "3MiB",
+		},
+		{
+			name:      "count",
+			dimension: RenderAllocsPerOp,
+			value:     23,
+			want:      "23",
+		},
+		{
+			name:      "thousands",
+			dimension: RenderAllocsPerOp,
+			value:     12500,
+			want:      "12.5k",
+		},
+		{
+			name:      "zero",
+			dimension: RenderNsPerOp,
+			value:     0,
+			want:      "0ns",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.dimension.FormatValue(test.value)
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package go_benchpress
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang.org/x/tools/benchmark/parse"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// vegaLiteSchema is the Vega-Lite version the specifications are written for.
+const vegaLiteSchema = "https://vega.github.io/schema/vega-lite/v5.json"
+
+// ===== VegaLiteVariant =====
+
+// VegaLiteVariant determines the kind of chart described by a Vega-Lite specification.
+type VegaLiteVariant int
+
+const (
+	// VegaLiteBar describes a bar for each benchmark, mirroring the RasterRenderer's bar chart.
+	VegaLiteBar VegaLiteVariant = iota
+	// VegaLiteGroupedBar groups the bars by the last parameter of the benchmark names, with a bar in each group for
+	// every combination of the other parameters.
+	VegaLiteGroupedBar
+	// VegaLiteLine plots the last parameter of the benchmark names along the X axis, with a line for every
+	// combination of the other parameters.
+	VegaLiteLine
+)
+
+func (v VegaLiteVariant) String() string {
+	switch v {
+	case VegaLiteBar:
+		return "BAR"
+	case VegaLiteGroupedBar:
+		return "GROUPED"
+	case VegaLiteLine:
+		return "LINE"
+	default:
+		return fmt.Sprintf("Unknown (%d)", v)
+	}
+}
+
+func VegaLiteVariantFromString(str string) (VegaLiteVariant, error) {
+	switch str {
+	case "BAR":
+		return VegaLiteBar, nil
+	case "GROUPED":
+		return VegaLiteGroupedBar, nil
+	case "LINE":
+		return VegaLiteLine, nil
+	default:
+		return -1, fmt.Errorf("vega-lite variant %q not supported: %w", str, ErrUnknownVegaLiteVariant)
+	}
+}
+
+// ===== VegaLiteRenderer =====
+
+// VegaLiteRenderer outputs a Vega-Lite specification of a chart of the benchmarks, with the data inlined.
+type VegaLiteRenderer struct {
+	Title   string
+	Variant VegaLiteVariant
+	// LogScale renders the value axis on a logarithmic scale.
+	LogScale bool
+	// PivotX and PivotSeries are the keys arranging the benchmarks of the grouped and line variants along the X axis
+	// and into series - see ParseProjectionKeys.  If PivotX is empty, the last parameter of the benchmark names is
+	// used, and if PivotSeries is empty, the other parameters are.
+	PivotX      []string
+	PivotSeries []string
+	// Configs holds the configuration of each benchmark, such as its "pkg" - for use as pivot keys.
+	Configs BenchmarkConfigs
+}
+
+type vegaLiteSpec struct {
+	Schema   string                     `json:"$schema"`
+	Title    string                     `json:"title"`
+	Data     vegaLiteData               `json:"data"`
+	Mark     vegaLiteMark               `json:"mark"`
+	Encoding map[string]vegaLiteChannel `json:"encoding"`
+}
+
+type vegaLiteData struct {
+	Values []vegaLiteRow `json:"values"`
+}
+
+type vegaLiteMark struct {
+	Type    string `json:"type"`
+	Point   bool   `json:"point,omitempty"`
+	Tooltip bool   `json:"tooltip"`
+}
+
+type vegaLiteChannel struct {
+	Field     string            `json:"field"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title,omitempty"`
+	Aggregate string            `json:"aggregate,omitempty"`
+	Sort      []string          `json:"sort,omitempty"`
+	Scale     map[string]string `json:"scale,omitempty"`
+}
+
+// vegaLiteRow is a single benchmark within the inlined data.
+type vegaLiteRow struct {
+	Name  string  `json:"name"`
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+	// Series is the benchmark's parameters other than those on the X axis - used to group the bars and lines of
+	// variants.
+	Series string `json:"series"`
+	// X is the value of the benchmark's last parameter, or its pivot X keys - a number, where it is numeric.
+	X interface{} `json:"x"`
+}
+
+func (v *VegaLiteRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
+	if len(benchmarks) == 0 {
+		return ErrNoBenchmarksProvided
+	}
+
+	title := v.Title
+	if title == "" {
+		title = parentBenchmark
+	}
+
+	rows, err := v.newRows(benchmarks, dimension)
+	if err != nil {
+		return err
+	}
+
+	valueChannel := vegaLiteChannel{
+		Field:     "value",
+		Type:      "quantitative",
+		Title:     axisTitle(dimension, dimension.units()[0]),
+		Aggregate: "mean",
+	}
+	if v.LogScale {
+		valueChannel.Scale = map[string]string{"type": "log"}
+	}
+
+	spec := vegaLiteSpec{
+		Schema:   vegaLiteSchema,
+		Title:    title,
+		Data:     vegaLiteData{Values: rows},
+		Mark:     vegaLiteMark{Type: "bar", Tooltip: true},
+		Encoding: map[string]vegaLiteChannel{"y": valueChannel},
+	}
+
+	labels, xValues, seriesValues := vegaLiteOrders(rows)
+	xTitle := strings.Join(v.PivotX, ", ")
+	if len(v.PivotX) == 0 {
+		xTitle = lastParameterKey(benchmarks)
+	}
+	xChannel := vegaLiteChannel{Field: "x", Type: "ordinal", Title: xTitle, Sort: xValues}
+	if vegaLiteNumeric(rows) {
+		// Numeric values are sorted in ascending order by Vega-Lite already.
+		xChannel.Sort = nil
+	}
+	seriesChannel := vegaLiteChannel{Field: "series", Type: "nominal", Sort: seriesValues}
+
+	switch v.Variant {
+	case VegaLiteBar:
+		spec.Encoding["x"] = vegaLiteChannel{Field: "label", Type: "nominal", Title: "Benchmark", Sort: labels}
+	case VegaLiteGroupedBar:
+		spec.Encoding["x"] = xChannel
+		spec.Encoding["xOffset"] = seriesChannel
+		spec.Encoding["color"] = seriesChannel
+	case VegaLiteLine:
+		if vegaLiteNumeric(rows) {
+			xChannel.Type = "quantitative"
+			if v.LogScale {
+				xChannel.Scale = map[string]string{"type": "log"}
+			}
+		}
+		spec.Mark = vegaLiteMark{Type: "line", Point: true, Tooltip: true}
+		spec.Encoding["x"] = xChannel
+		spec.Encoding["color"] = seriesChannel
+	default:
+		return fmt.Errorf("vega-lite variant %q not supported: %w", v.Variant, ErrUnknownVegaLiteVariant)
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// newRows provides a row of inlined data for each benchmark.
+func (v *VegaLiteRenderer) newRows(benchmarks []parse.Benchmark, dimension Dimension) ([]vegaLiteRow, error) {
+	onX := make(map[string]bool, len(v.PivotX))
+	for _, key := range v.PivotX {
+		onX[key] = true
+	}
+
+	rows := make([]vegaLiteRow, 0, len(benchmarks))
+	for _, benchmark := range benchmarks {
+		value, err := dimension.Value(benchmark)
+		if err != nil {
+			return nil, err
+		}
+
+		row := vegaLiteRow{
+			Name:  benchmark.Name,
+			Label: subBenchmarkLabel(benchmark.Name),
+			Value: value,
+			X:     "",
+		}
+
+		config := v.Configs[benchmark.Ord]
+		parameters := ParseBenchmarkName(benchmark.Name).Parameters
+		x := ""
+		if len(v.PivotX) > 0 {
+			x = projectionValue(v.PivotX, benchmark, config)
+
+			var series []NameParameter
+			for _, parameter := range parameters {
+				if parameter.Key == "" || !onX[parameter.Key] {
+					series = append(series, parameter)
+				}
+			}
+			row.Series = joinParameters(series)
+		} else if len(parameters) > 0 {
+			x = parameters[len(parameters)-1].Value
+			row.Series = joinParameters(parameters[:len(parameters)-1])
+		}
+		if len(v.PivotSeries) > 0 {
+			row.Series = projectionValue(v.PivotSeries, benchmark, config)
+		}
+
+		row.X = x
+		if number, err := strconv.ParseFloat(x, 64); err == nil {
+			row.X = number
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// joinParameters joins the parameters into the form they take within a benchmark name, such as "algo=quick/10".
+func joinParameters(parameters []NameParameter) string {
+	parts := make([]string, 0, len(parameters))
+	for _, parameter := range parameters {
+		if parameter.Key == "" {
+			parts = append(parts, parameter.Value)
+		} else {
+			parts = append(parts, parameter.Key+"="+parameter.Value)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// vegaLiteOrders provides the distinct labels, X values and series of the rows, in order of first appearance - so
+// that the chart keeps the order the benchmarks were run in.
+func vegaLiteOrders(rows []vegaLiteRow) (labels, xValues, series []string) {
+	for _, row := range rows {
+		labels = appendUnique(labels, row.Label)
+		xValues = appendUnique(xValues, fmt.Sprint(row.X))
+		series = appendUnique(series, row.Series)
+	}
+	return labels, xValues, series
+}
+
+// vegaLiteNumeric reports whether every row has a numeric X value.
+func vegaLiteNumeric(rows []vegaLiteRow) bool {
+	for _, row := range rows {
+		if _, ok := row.X.(float64); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// lastParameterKey provides the key of the last parameter of the benchmark names, if they all share it.
+func lastParameterKey(benchmarks []parse.Benchmark) string {
+	var key string
+	for index, benchmark := range benchmarks {
+		parameters := ParseBenchmarkName(benchmark.Name).Parameters
+		if len(parameters) == 0 {
+			return ""
+		}
+		last := parameters[len(parameters)-1].Key
+		if index > 0 && last != key {
+			return ""
+		}
+		key = last
+	}
+	return key
+}
@@ -0,0 +1,304 @@
+package go_benchpress
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"golang.org/x/tools/benchmark/parse"
+	"reflect"
+	"testing"
+)
+
+func TestVegaLiteVariant_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		input VegaLiteVariant
+		want  string
+	}{
+		{
+			name:  "bar",
+			input: VegaLiteBar,
+			want:  "BAR",
+		},
+		{
+			name:  "grouped",
+			input: VegaLiteGroupedBar,
+			want:  "GROUPED",
+		},
+		{
+			name:  "line",
+			input: VegaLiteLine,
+			want:  "LINE",
+		},
+		{
+			name:  "unknown",
+			input: VegaLiteVariant(1000),
+			want:  "Unknown (1000)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.input.String()
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestVegaLiteVariantFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    VegaLiteVariant
+		wantErr error
+	}{
+		{
+			name:  "bar",
+			input: "BAR",
+			want:  VegaLiteBar,
+		},
+		{
+			name:  "grouped",
+			input: "GROUPED",
+			want:  VegaLiteGroupedBar,
+		},
+		{
+			name:  "line",
+			input: "LINE",
+			want:  VegaLiteLine,
+		},
+		{
+			name:    "unknown",
+			input:   "abc123",
+			want:    VegaLiteVariant(-1),
+			wantErr: ErrUnknownVegaLiteVariant,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := VegaLiteVariantFromString(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error '%v', got error '%v'", test.wantErr, err)
+			}
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestVegaLiteRenderer_Render(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=quick/size=1024-8", NsPerOp: 300},
+		{Name: "BenchmarkSort/algo=quick/size=64-8", NsPerOp: 20},
+		{Name: "BenchmarkSort/algo=radix/size=1024-8", NsPerOp: 200},
+		{Name: "BenchmarkSort/algo=radix/size=64-8", NsPerOp: 40},
+	}
+
+	tests := []struct {
+		name     string
+		renderer VegaLiteRenderer
+		wantMark string
+		// wantEncoding holds the wanted field of each encoding channel.
+		wantEncoding map[string]string
+		wantXType    string
+		wantLogScale bool
+	}{
+		{
+			name:         "bar",
+			renderer:     VegaLiteRenderer{Variant: VegaLiteBar},
+			wantMark:     "bar",
+			wantEncoding: map[string]string{"x": "label", "y": "value"},
+			wantXType:    "nominal",
+		},
+		{
+			name:         "grouped",
+			renderer:     VegaLiteRenderer{Variant: VegaLiteGroupedBar},
+			wantMark:     "bar",
+			wantEncoding: map[string]string{"x": "x", "xOffset": "series", "color": "series", "y": "value"},
+			wantXType:    "ordinal",
+		},
+		{
+			name:         "line",
+			renderer:     VegaLiteRenderer{Variant: VegaLiteLine},
+			wantMark:     "line",
+			wantEncoding: map[string]string{"x": "x", "color": "series", "y": "value"},
+			wantXType:    "quantitative",
+		},
+		{
+			name:         "log scale",
+			renderer:     VegaLiteRenderer{Variant: VegaLiteBar, LogScale: true},
+			wantMark:     "bar",
+			wantEncoding: map[string]string{"x": "label", "y": "value"},
+			wantXType:    "nominal",
+			wantLogScale: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := test.renderer.Render(&output, "BenchmarkSort", RenderNsPerOp, benchmarks)
+			if err != nil {
+				t.Fatalf("Error rendering Vega-Lite: %v", err)
+			}
+
+			var spec vegaLiteSpec
+			err = json.Unmarshal(output.Bytes(), &spec)
+			if err != nil {
+				t.Fatalf("Could not decode Vega-Lite specification - error: %v", err)
+			}
+
+			if spec.Schema != vegaLiteSchema {
+				t.Errorf("Wanted schema %q, got %q", vegaLiteSchema, spec.Schema)
+			}
+			if spec.Title != "BenchmarkSort" {
+				t.Errorf("Wanted title %q, got %q", "BenchmarkSort", spec.Title)
+			}
+			if len(spec.Data.Values) != len(benchmarks) {
+				t.Errorf("Wanted %d data values, got %d", len(benchmarks), len(spec.Data.Values))
+			}
+			if spec.Mark.Type != test.wantMark {
+				t.Errorf("Wanted mark %q, got %q", test.wantMark, spec.Mark.Type)
+			}
+
+			gotEncoding := map[string]string{}
+			for channel, encoding := range spec.Encoding {
+				gotEncoding[channel] = encoding.Field
+			}
+			if !reflect.DeepEqual(test.wantEncoding, gotEncoding) {
+				t.Errorf("Wanted encoding %v, got %v", test.wantEncoding, gotEncoding)
+			}
+			if spec.Encoding["x"].Type != test.wantXType {
+				t.Errorf("Wanted X type %q, got %q", test.wantXType, spec.Encoding["x"].Type)
+			}
+
+			y := spec.Encoding["y"]
+			if y.Title != "Time per op (ns)" {
+				t.Errorf("Wanted value title %q, got %q", "Time per op (ns)", y.Title)
+			}
+			if gotLogScale := y.Scale["type"] == "log"; gotLogScale != test.wantLogScale {
+				t.Errorf("Wanted log scale %t, got %t", test.wantLogScale, gotLogScale)
+			}
+		})
+	}
+}
+
+func TestVegaLiteRenderer_RenderRows(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=quick/size=1024-8", NsPerOp: 300},
+		{Name: "BenchmarkSort/algo=quick/size=large-8", NsPerOp: 900},
+	}
+
+	renderer := VegaLiteRenderer{}
+	rows, err := renderer.newRows(benchmarks, RenderNsPerOp)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []vegaLiteRow{
+		{Name: "BenchmarkSort/algo=quick/size=1024-8", Label: subBenchmarkLabel("BenchmarkSort/algo=quick/size=1024-8"), Value: 300, Series: "algo=quick", X: 1024.0},
+		{Name: "BenchmarkSort/algo=quick/size=large-8", Label: subBenchmarkLabel("BenchmarkSort/algo=quick/size=large-8"), Value: 900, Series: "algo=quick", X: "large"},
+	}
+	if !reflect.DeepEqual(want, rows) {
+		t.Errorf("Wanted rows %v, got %v", want, rows)
+	}
+	if vegaLiteNumeric(rows) {
+		t.Error("Wanted rows with a non-numeric X value to not be numeric")
+	}
+	if key := lastParameterKey(benchmarks); key != "size" {
+		t.Errorf("Wanted last parameter key %q, got %q", "size", key)
+	}
+}
+
+func TestVegaLiteRenderer_RenderRowsPivot(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkSort/algo=quick/size=1024/file=old-8", NsPerOp: 300},
+		{Name: "BenchmarkSort/algo=quick/size=1024/file=new-8", NsPerOp: 200, Ord: 1},
+	}
+	configs := BenchmarkConfigs{
+		0: {"pkg": "example.com/sort"},
+	}
+
+	tests := []struct {
+		name       string
+		renderer   VegaLiteRenderer
+		wantX      []interface{}
+		wantSeries []string
+	}{
+		{
+			name:       "x keys",
+			renderer:   VegaLiteRenderer{PivotX: []string{"size"}},
+			wantX:      []interface{}{1024.0, 1024.0},
+			wantSeries: []string{"algo=quick/file=old", "algo=quick/file=new"},
+		},
+		{
+			name:       "x and series keys",
+			renderer:   VegaLiteRenderer{PivotX: []string{"algo"}, PivotSeries: []string{"file"}},
+			wantX:      []interface{}{"quick", "quick"},
+			wantSeries: []string{"old", "new"},
+		},
+		{
+			name:       "configuration keys",
+			renderer:   VegaLiteRenderer{PivotX: []string{"pkg", "size"}, Configs: configs},
+			wantX:      []interface{}{"example.com/sort/1024", "/1024"},
+			wantSeries: []string{"algo=quick/file=old", "algo=quick/file=new"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := test.renderer.newRows(benchmarks, RenderNsPerOp)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var gotX []interface{}
+			var gotSeries []string
+			for _, row := range rows {
+				gotX = append(gotX, row.X)
+				gotSeries = append(gotSeries, row.Series)
+			}
+			if !reflect.DeepEqual(test.wantX, gotX) {
+				t.Errorf("Wanted X %v, got %v", test.wantX, gotX)
+			}
+			if !reflect.DeepEqual(test.wantSeries, gotSeries) {
+				t.Errorf("Wanted series %v, got %v", test.wantSeries, gotSeries)
+			}
+		})
+	}
+}
+
+func TestVegaLiteRenderer_RenderErrors(t *testing.T) {
+	benchmarks := []parse.Benchmark{
+		{Name: "BenchmarkOne/SubBenchmark", NsPerOp: 100},
+	}
+
+	tests := []struct {
+		name       string
+		renderer   VegaLiteRenderer
+		benchmarks []parse.Benchmark
+		wantErr    error
+	}{
+		{
+			name:       "no benchmarks",
+			renderer:   VegaLiteRenderer{},
+			benchmarks: nil,
+			wantErr:    ErrNoBenchmarksProvided,
+		},
+		{
+			name:       "unknown variant",
+			renderer:   VegaLiteRenderer{Variant: VegaLiteVariant(1000)},
+			benchmarks: benchmarks,
+			wantErr:    ErrUnknownVegaLiteVariant,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := test.renderer.Render(&output, "BenchmarkOne", RenderNsPerOp, test.benchmarks)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Wanted error '%v', got error '%v'", test.wantErr, err)
+			}
+		})
+	}
+}
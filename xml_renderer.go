@@ -15,7 +15,7 @@ type xmlBenchmarkRecord struct {
 	Benchmarks []parse.Benchmark
 }
 
-func (x *XMLRenderer) Render(writer io.Writer, parentBenchmark string, dimension RenderDimension, benchmarks []parse.Benchmark) error {
+func (x *XMLRenderer) Render(writer io.Writer, parentBenchmark string, dimension Dimension, benchmarks []parse.Benchmark) error {
 
 	record := xmlBenchmarkRecord{
 		ParentBenchmark: parentBenchmark,